@@ -18,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -82,6 +83,42 @@ func TestCalcHash(t *testing.T) {
 	})
 }
 
+func TestContractManifestDiff(t *testing.T) {
+	e := newExecutor(t, false)
+
+	manifestPath := "./testdata/verify.manifest.json"
+	manifestBytes, err := ioutil.ReadFile(manifestPath)
+	require.NoError(t, err)
+	m := &manifest.Manifest{}
+	require.NoError(t, json.Unmarshal(manifestBytes, m))
+	m.ABI.Methods = append(m.ABI.Methods, manifest.Method{Name: "newMethod", ReturnType: smartcontract.VoidType})
+	newPath := path.Join(os.TempDir(), "neogo.manifestdiff.manifest.json")
+	t.Cleanup(func() {
+		os.Remove(newPath)
+	})
+	newBytes, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(newPath, newBytes, os.ModePerm))
+
+	cmd := []string{"neo-go", "contract", "manifest-diff"}
+	t.Run("missing argument", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, manifestPath)...)
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, manifestPath, newPath+"123")...)
+	})
+	t.Run("equal", func(t *testing.T) {
+		e.Run(t, append(cmd, manifestPath, manifestPath)...)
+		e.checkNextLine(t, "Manifests are equal.")
+		e.checkEOF(t)
+	})
+	t.Run("added method", func(t *testing.T) {
+		e.Run(t, append(cmd, manifestPath, newPath)...)
+		e.checkNextLine(t, "method added: newMethod/0")
+		e.checkEOF(t)
+	})
+}
+
 func TestContractInitAndCompile(t *testing.T) {
 	tmpDir := path.Join(os.TempDir(), "neogo.inittest")
 	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
@@ -308,6 +345,11 @@ func TestComlileAndInvokeFunction(t *testing.T) {
 			"--sender", validatorAddr, "--in", nefName,
 			"--manifest", manifestName)
 		e.checkNextLine(t, h.StringLE())
+
+		e.Run(t, "neo-go", "contract", "hash",
+			"--sender", validatorAddr, "--nef", nefName,
+			"--manifest", manifestName)
+		e.checkNextLine(t, h.StringLE())
 	})
 
 	cmd := []string{"neo-go", "contract", "testinvokefunction",
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
@@ -63,3 +64,133 @@ func TestDBRestore(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, d1, d2, "dumps differ")
 }
+
+func TestDBExportImport(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "neogo.exporttest")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	newCfgDir := func(t *testing.T, name string) string {
+		cfg, err := config.LoadFile("../config/protocol.unit_testnet.yml")
+		require.NoError(t, err, "could not load config")
+		cfg.ApplicationConfiguration.DBConfiguration.Type = "leveldb"
+		cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = path.Join(tmpDir, name, "chain")
+
+		out, err := yaml.Marshal(cfg)
+		require.NoError(t, err)
+
+		cfgDir := path.Join(tmpDir, name)
+		require.NoError(t, os.Mkdir(cfgDir, os.ModePerm))
+		require.NoError(t, ioutil.WriteFile(path.Join(cfgDir, "protocol.unit_testnet.yml"), out, os.ModePerm))
+		return cfgDir
+	}
+	srcDir := newCfgDir(t, "src")
+	dstDir := newCfgDir(t, "dst")
+
+	e := newExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest",
+		"--config-path", srcDir, "--in", "./testdata/chain50x2.acc")
+
+	snapshot := path.Join(tmpDir, "snapshot")
+	e.Run(t, "neo-go", "db", "export", "--unittest",
+		"--config-path", srcDir, "--out", snapshot)
+	e.Run(t, "neo-go", "db", "import", "--unittest",
+		"--config-path", dstDir, "--in", snapshot)
+	// DB is not empty now.
+	e.RunWithError(t, "neo-go", "db", "import", "--unittest",
+		"--config-path", dstDir, "--in", snapshot)
+	// Not a snapshot.
+	e.RunWithError(t, "neo-go", "db", "import", "--unittest",
+		"--config-path", newCfgDir(t, "bad"), "--in", "./testdata/chain50x2.acc")
+
+	// Both DBs contain the same chain.
+	srcDump := path.Join(tmpDir, "src.acc")
+	e.Run(t, "neo-go", "db", "dump", "--unittest",
+		"--config-path", srcDir, "--out", srcDump)
+	dstDump := path.Join(tmpDir, "dst.acc")
+	e.Run(t, "neo-go", "db", "dump", "--unittest",
+		"--config-path", dstDir, "--out", dstDump)
+
+	d1, err := ioutil.ReadFile(srcDump)
+	require.NoError(t, err)
+	d2, err := ioutil.ReadFile(dstDump)
+	require.NoError(t, err)
+	require.Equal(t, d1, d2, "dumps differ")
+}
+
+func TestDBCompact(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "neogo.compacttest")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	cfg, err := config.LoadFile("../config/protocol.unit_testnet.yml")
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = "leveldb"
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = path.Join(tmpDir, "chain")
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path.Join(tmpDir, "protocol.unit_testnet.yml"), out, os.ModePerm))
+
+	e := newExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest",
+		"--config-path", tmpDir, "--in", "./testdata/chain50x2.acc")
+	e.Run(t, "neo-go", "db", "compact", "--unittest", "--config-path", tmpDir)
+	e.checkNextLine(t, "DB size before compaction: [0-9]+ bytes, after: [0-9]+ bytes")
+	e.checkEOF(t)
+
+	t.Run("unsupported", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "compact", "--unittest", "--config-path", "../config")
+	})
+}
+
+func TestDBStateRoot(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "neogo.stateroottest")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	cfg, err := config.LoadFile("../config/protocol.unit_testnet.yml")
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = "leveldb"
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = path.Join(tmpDir, "chain")
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path.Join(tmpDir, "protocol.unit_testnet.yml"), out, os.ModePerm))
+
+	e := newExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest",
+		"--config-path", tmpDir, "--in", "./testdata/chain50x2.acc", "--count", "20")
+
+	e.Run(t, "neo-go", "db", "state-root", "--unittest", "--config-path", tmpDir, "--height", "10")
+	root10 := e.getNextLine(t)
+	_, err = util.Uint256DecodeStringLE(root10)
+	require.NoError(t, err)
+	e.checkEOF(t)
+
+	e.Run(t, "neo-go", "db", "state-root", "--unittest", "--config-path", tmpDir)
+	root19 := e.getNextLine(t)
+	require.NotEqual(t, root10, root19)
+	e.checkEOF(t)
+
+	e.Run(t, "neo-go", "db", "state-root", "--unittest", "--config-path", tmpDir, "--height", "19")
+	e.checkNextLine(t, root19)
+	e.checkEOF(t)
+
+	e.RunWithError(t, "neo-go", "db", "state-root", "--unittest", "--config-path", tmpDir, "--height", "20")
+
+	t.Run("diff", func(t *testing.T) {
+		e.Run(t, "neo-go", "db", "state-diff", "--unittest", "--config-path", tmpDir, "--height", "19")
+		e.checkEOF(t)
+
+		e.Run(t, "neo-go", "db", "state-diff", "--unittest", "--config-path", tmpDir, "--height", "10", "--to", "19")
+		line := e.getNextLine(t)
+		require.Regexp(t, "^[0-9a-f]+: [0-9a-f]* -> [0-9a-f]*$", line)
+
+		e.RunWithError(t, "neo-go", "db", "state-diff", "--unittest", "--config-path", tmpDir, "--height", "10", "--to", "20")
+	})
+}
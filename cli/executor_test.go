@@ -182,6 +182,16 @@ func (e *executor) RunWithError(t *testing.T, args ...string) {
 	checkExit(t, ch, 1)
 }
 
+// RunWithErrorCheck runs command and checks that it exits with an error
+// containing the given message.
+func (e *executor) RunWithErrorCheck(t *testing.T, msg string, args ...string) {
+	ch := setExitFunc()
+	err := e.run(args...)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), msg)
+	checkExit(t, ch, 1)
+}
+
 // Run runs command and checks that there were no errors.
 func (e *executor) Run(t *testing.T, args ...string) {
 	ch := setExitFunc()
@@ -5,12 +5,14 @@ import (
 	"math/big"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -155,4 +157,38 @@ func TestSignMultisigTx(t *testing.T) {
 		b, _ = e.Chain.GetGoverningTokenBalance(multisigHash)
 		require.Equal(t, big.NewInt(2), b)
 	})
+
+	t.Run("via transfer --in", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", wallet1Path, "--from", multisigAddr,
+			"--to", priv.Address(), "--token", "NEO", "--amount", "1",
+			"--out", txPath)
+		line, err := e.Out.ReadString('\n')
+		require.NoError(t, err)
+		h, err := util.Uint256DecodeStringLE(strings.TrimSpace(line))
+		require.NoError(t, err)
+
+		t.Run("not a signer", func(t *testing.T) {
+			e.In.WriteString("pass\r")
+			e.RunWithError(t, "neo-go", "wallet", "nep17", "transfer",
+				"--rpc-endpoint", "http://"+e.RPC.Addr,
+				"--wallet", wallet1Path, "--from", simplePriv.Address(),
+				"--in", txPath)
+		})
+
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", wallet2Path, "--from", multisigAddr,
+			"--in", txPath)
+		tx, _ := e.checkTxPersisted(t)
+		require.Equal(t, h, tx.Hash())
+
+		b, _ := e.Chain.GetGoverningTokenBalance(priv.GetScriptHash())
+		require.Equal(t, big.NewInt(3), b)
+		b, _ = e.Chain.GetGoverningTokenBalance(multisigHash)
+		require.Equal(t, big.NewInt(1), b)
+	})
 }
@@ -123,6 +123,12 @@ func TestNEP17Transfer(t *testing.T) {
 		e.In.Reset()
 	})
 
+	t.Run("fractional amount for indivisible token", func(t *testing.T) {
+		fracArgs := append([]string{}, args[:len(args)-4]...)
+		e.In.WriteString("one\r")
+		e.RunWithErrorCheck(t, "invalid amount", append(fracArgs, "--amount", "0.5", "--from", validatorAddr)...)
+	})
+
 	e.In.WriteString("one\r")
 	e.Run(t, args...)
 	e.checkTxPersisted(t)
@@ -0,0 +1,109 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/urfave/cli"
+)
+
+// policyState is a set of native Policy contract parameters.
+type policyState struct {
+	FeePerByte    int64           `json:"feeperbyte"`
+	ExecFeeFactor int64           `json:"execfeefactor"`
+	StoragePrice  int64           `json:"storageprice"`
+	Blocked       map[string]bool `json:"blocked,omitempty"`
+}
+
+// NewCommands returns query commands for neo-go CLI.
+func NewCommands() []cli.Command {
+	queryPolicyFlags := append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "output parameters in JSON format",
+		},
+	}, options.RPC...)
+	return []cli.Command{{
+		Name:  "query",
+		Usage: "Query data from RPC node",
+		Subcommands: []cli.Command{
+			{
+				Name:  "policy",
+				Usage: "Query native Policy contract parameters",
+				UsageText: `query policy -r endpoint [-s timeout] [--json] [<address>...]
+
+   Prints current fee per byte, execution fee factor and storage price
+   set in the native Policy contract. If any addresses (or script hashes)
+   are specified, their blocked status is printed as well.`,
+				Action: queryPolicy,
+				Flags:  queryPolicyFlags,
+			},
+		},
+	}}
+}
+
+func queryPolicy(ctx *cli.Context) error {
+	var (
+		err error
+		ps  policyState
+	)
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	ps.FeePerByte, err = c.GetFeePerByte()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get fee per byte: %w", err), 1)
+	}
+	ps.ExecFeeFactor, err = c.GetExecFeeFactor()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get execution fee factor: %w", err), 1)
+	}
+	ps.StoragePrice, err = c.GetStoragePrice()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get storage price: %w", err), 1)
+	}
+	args := ctx.Args()
+	addrs := make([]string, 0, len(args))
+	if len(args) != 0 {
+		ps.Blocked = make(map[string]bool, len(args))
+	}
+	for _, s := range args {
+		u, err := flags.ParseAddress(s)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid address %s: %w", s, err), 1)
+		}
+		blocked, err := c.IsBlocked(u)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to check %s: %w", s, err), 1)
+		}
+		addr := address.Uint160ToString(u)
+		addrs = append(addrs, addr)
+		ps.Blocked[addr] = blocked
+	}
+
+	if ctx.Bool("json") {
+		b, err := json.Marshal(ps)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, string(b))
+		return nil
+	}
+	tw := tabwriter.NewWriter(ctx.App.Writer, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "FeePerByte:\t%d\n", ps.FeePerByte)
+	fmt.Fprintf(tw, "ExecFeeFactor:\t%d\n", ps.ExecFeeFactor)
+	fmt.Fprintf(tw, "StoragePrice:\t%d\n", ps.StoragePrice)
+	for _, addr := range addrs {
+		fmt.Fprintf(tw, "Blocked %s:\t%t\n", addr, ps.Blocked[addr])
+	}
+	return tw.Flush()
+}
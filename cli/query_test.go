@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryPolicy(t *testing.T) {
+	e := newExecutor(t, true)
+
+	t.Run("missing endpoint", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "query", "policy")
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "query", "policy",
+			"--rpc-endpoint", "http://"+e.RPC.Addr, "not-an-address")
+	})
+	t.Run("text", func(t *testing.T) {
+		e.Run(t, "neo-go", "query", "policy",
+			"--rpc-endpoint", "http://"+e.RPC.Addr, validatorAddr)
+		e.checkNextLine(t, `^FeePerByte:\s+`+strconv.FormatInt(e.Chain.FeePerByte(), 10)+`$`)
+		e.checkNextLine(t, `^ExecFeeFactor:\s+`+strconv.FormatInt(e.Chain.GetBaseExecFee(), 10)+`$`)
+		e.checkNextLine(t, `^StoragePrice:\s+`+strconv.FormatInt(e.Chain.GetStoragePrice(), 10)+`$`)
+		e.checkNextLine(t, `^Blocked `+validatorAddr+`:\s+false$`)
+		e.checkEOF(t)
+	})
+	t.Run("json", func(t *testing.T) {
+		e.Run(t, "neo-go", "query", "policy",
+			"--rpc-endpoint", "http://"+e.RPC.Addr, "--json", validatorHash.StringLE())
+		var actual map[string]interface{}
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), &actual))
+		require.Equal(t, map[string]interface{}{
+			"feeperbyte":    float64(e.Chain.FeePerByte()),
+			"execfeefactor": float64(e.Chain.GetBaseExecFee()),
+			"storageprice":  float64(e.Chain.GetStoragePrice()),
+			"blocked":       map[string]interface{}{validatorAddr: false},
+		}, actual)
+	})
+}
@@ -1,10 +1,15 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/nspcc-dev/neo-go/cli/options"
@@ -12,6 +17,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network"
@@ -65,6 +72,38 @@ func NewCommands() []cli.Command {
 			Usage: "directory for storing JSON dumps",
 		},
 	)
+	var cfgOutFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgOutFlags, cfgFlags)
+	cfgOutFlags = append(cfgOutFlags,
+		cli.StringFlag{
+			Name:  "out, o",
+			Usage: "Output file (stdout if not given)",
+		},
+	)
+	var cfgInFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgInFlags, cfgFlags)
+	cfgInFlags = append(cfgInFlags,
+		cli.StringFlag{
+			Name:  "in, i",
+			Usage: "Input file (stdin if not given)",
+		},
+	)
+	var cfgHeightFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgHeightFlags, cfgFlags)
+	cfgHeightFlags = append(cfgHeightFlags,
+		cli.UintFlag{
+			Name:  "height",
+			Usage: "block height to get state for (default: current height)",
+		},
+	)
+	var cfgDiffFlags = make([]cli.Flag, len(cfgHeightFlags))
+	copy(cfgDiffFlags, cfgHeightFlags)
+	cfgDiffFlags = append(cfgDiffFlags,
+		cli.UintFlag{
+			Name:  "to",
+			Usage: "block height to compare the state with (default: current height)",
+		},
+	)
 	return []cli.Command{
 		{
 			Name:   "node",
@@ -88,6 +127,36 @@ func NewCommands() []cli.Command {
 					Action: restoreDB,
 					Flags:  cfgCountInFlags,
 				},
+				{
+					Name:   "export",
+					Usage:  "export the whole DB contents (chain state snapshot) to the file",
+					Action: exportDB,
+					Flags:  cfgOutFlags,
+				},
+				{
+					Name:   "import",
+					Usage:  "import DB contents from the file made by 'db export' into an empty DB",
+					Action: importDB,
+					Flags:  cfgInFlags,
+				},
+				{
+					Name:   "state-root",
+					Usage:  "compute state root hash of the whole contract storage at the given height",
+					Action: stateRootDB,
+					Flags:  cfgHeightFlags,
+				},
+				{
+					Name:   "state-diff",
+					Usage:  "print contract storage items differing between states at the given heights",
+					Action: stateDiffDB,
+					Flags:  cfgDiffFlags,
+				},
+				{
+					Name:   "compact",
+					Usage:  "compact DB to reclaim disk space (if supported by DB)",
+					Action: compactDB,
+					Flags:  cfgFlags,
+				},
 			},
 		},
 	}
@@ -296,6 +365,206 @@ func restoreDB(ctx *cli.Context) error {
 	return nil
 }
 
+// exportDB writes all DB contents to the file. It works with DB directly, so
+// the node using the same DB must be stopped.
+func exportDB(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	var outStream = os.Stdout
+	if out := ctx.String("out"); out != "" {
+		outStream, err = os.Create(out)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer outStream.Close()
+	}
+
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+
+	bw := bufio.NewWriter(outStream)
+	err = chaindump.ExportStore(store, io.NewBinWriterFromIO(bw))
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to export DB: %w", err), 1)
+	}
+	return nil
+}
+
+// importDB fills an empty DB with the contents of the file made by exportDB.
+func importDB(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	var inStream = os.Stdin
+	if in := ctx.String("in"); in != "" {
+		inStream, err = os.Open(in)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer inStream.Close()
+	}
+
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+
+	_, err = chaindump.ImportStore(store, io.NewBinReaderFromIO(bufio.NewReader(inStream)))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to import DB: %w", err), 1)
+	}
+	return nil
+}
+
+// compactDB compacts DB and reports its size before and after compaction. It
+// works with DB directly, so the node using the same DB must be stopped.
+func compactDB(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	dbCfg := cfg.ApplicationConfiguration.DBConfiguration
+	store, err := storage.NewStore(dbCfg)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+
+	before, sizeErr := dbSize(dbCfg)
+	if err := store.Compact(); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to compact %s DB: %w", dbCfg.Type, err), 1)
+	}
+	if sizeErr != nil {
+		fmt.Fprintf(ctx.App.Writer, "DB is compacted, size is unknown: %v\n", sizeErr)
+		return nil
+	}
+	after, err := dbSize(dbCfg)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get DB size: %w", err), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "DB size before compaction: %d bytes, after: %d bytes\n", before, after)
+	return nil
+}
+
+// stateRootDB computes state root hash of the whole contract storage at the
+// specified height, it can be used to compare node's state with other nodes.
+func stateRootDB(ctx *cli.Context) error {
+	chain, err := initStateChain(ctx)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+
+	d, err := getStateAt(ctx, chain, "height")
+	if err != nil {
+		return err
+	}
+	root, err := core.ComputeStateRoot(d)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to compute state root: %w", err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, root.StringLE())
+	return nil
+}
+
+// stateDiffDB prints contract storage items (hex-encoded keys with contract
+// ID prefix and values) that differ between states at the specified heights.
+func stateDiffDB(ctx *cli.Context) error {
+	chain, err := initStateChain(ctx)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+
+	left, err := getStateAt(ctx, chain, "height")
+	if err != nil {
+		return err
+	}
+	right, err := getStateAt(ctx, chain, "to")
+	if err != nil {
+		return err
+	}
+	for _, d := range core.DiffStates(left, right) {
+		fmt.Fprintf(ctx.App.Writer, "%s: %s -> %s\n", hex.EncodeToString(d.Key),
+			hex.EncodeToString(d.Left), hex.EncodeToString(d.Right))
+	}
+	return nil
+}
+
+// initStateChain initializes and runs the blockchain for state-related
+// commands.
+func initStateChain(ctx *cli.Context) (*core.Blockchain, error) {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return nil, cli.NewExitError(err, 1)
+	}
+	log, err := handleLoggingParams(ctx, cfg.ApplicationConfiguration)
+	if err != nil {
+		return nil, cli.NewExitError(err, 1)
+	}
+	chain, err := initBlockChain(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	go chain.Run()
+	return chain, nil
+}
+
+// getStateAt returns the state at the height specified by the given flag
+// (current height if it's not set).
+func getStateAt(ctx *cli.Context, chain *core.Blockchain, flag string) (*dao.Simple, error) {
+	height := chain.BlockHeight()
+	if ctx.IsSet(flag) {
+		h := uint32(ctx.Uint(flag))
+		if h > height {
+			return nil, cli.NewExitError(fmt.Errorf("chain is not that high (%d)", height), 1)
+		}
+		height = h
+	}
+	d, err := chain.GetStateAt(height)
+	if err != nil {
+		return nil, cli.NewExitError(fmt.Errorf("failed to get state for height %d: %w", height, err), 1)
+	}
+	return d, nil
+}
+
+// dbSize returns the size of the files used by DB with the given
+// configuration.
+func dbSize(cfg storage.DBConfiguration) (int64, error) {
+	var path string
+	switch cfg.Type {
+	case "leveldb":
+		path = cfg.LevelDBOptions.DataDirectoryPath
+	case "boltdb":
+		path = cfg.BoltDBOptions.FilePath
+	case "badgerdb":
+		path = cfg.BadgerDBOptions.Dir
+	default:
+		return 0, fmt.Errorf("%s DB is not file-based", cfg.Type)
+	}
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
 func startServer(ctx *cli.Context) error {
 	cfg, err := getConfigFromContext(ctx)
 	if err != nil {
@@ -320,6 +589,9 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("failed to create network server: %w", err), 1)
 	}
+	if err := loadMemPool(chain, cfg.ApplicationConfiguration.MemPoolPersistence, log); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to load mempool: %w", err), 1)
+	}
 	rpcServer := server.New(chain, cfg.ApplicationConfiguration.RPC, serv, serv.GetOracle(), log)
 	errChan := make(chan error)
 
@@ -360,6 +632,9 @@ Main:
 			}
 			prometheus.ShutDown()
 			pprof.ShutDown()
+			if err := saveMemPool(chain, cfg.ApplicationConfiguration.MemPoolPersistence); err != nil {
+				log.Error("failed to save mempool", zap.Error(err))
+			}
 			chain.Close()
 			break Main
 		}
@@ -372,6 +647,79 @@ Main:
 	return nil
 }
 
+// getMemPoolKey reads mempool encryption key from the file specified in the
+// configuration, nil is returned if it's not set.
+func getMemPoolKey(cfg config.MemPoolPersistence) ([]byte, error) {
+	if cfg.KeyFile == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("can't read key file: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return key, nil
+}
+
+// loadMemPool adds transactions saved by saveMemPool to the chain's mempool,
+// the ones that are no longer valid are skipped.
+func loadMemPool(chain *core.Blockchain, cfg config.MemPoolPersistence, log *zap.Logger) error {
+	if cfg.Path == "" {
+		return nil
+	}
+	key, err := getMemPoolKey(cfg)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(cfg.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	txes, err := mempool.Load(f, key)
+	if err != nil {
+		return err
+	}
+	var added int
+	for _, tx := range txes {
+		if err := chain.PoolTx(tx); err != nil {
+			log.Debug("saved transaction is not added to the mempool",
+				zap.Stringer("hash", tx.Hash()), zap.Error(err))
+			continue
+		}
+		added++
+	}
+	log.Info("mempool loaded", zap.Int("saved", len(txes)), zap.Int("added", added))
+	return nil
+}
+
+// saveMemPool saves the chain's mempool transactions to the file specified in
+// the configuration.
+func saveMemPool(chain *core.Blockchain, cfg config.MemPoolPersistence) error {
+	if cfg.Path == "" {
+		return nil
+	}
+	key, err := getMemPoolKey(cfg)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(cfg.Path)
+	if err != nil {
+		return err
+	}
+	err = chain.GetMemPool().Save(f, key)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 // configureAddresses sets up addresses for RPC, Prometheus and Pprof depending from the provided config.
 // In case RPC or Prometheus or Pprof Address provided each of them will use it.
 // In case global Address (of the node) provided and RPC/Prometheus/Pprof don't have configured addresses they will
@@ -1,19 +1,27 @@
 package server
 
 import (
+	"encoding/hex"
+	"errors"
 	"flag"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/network/metrics"
 	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestGetConfigFromContext(t *testing.T) {
@@ -240,3 +248,61 @@ func TestInitBlockChain(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestMemPoolPersistence(t *testing.T) {
+	d, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(d) })
+
+	cfg, err := config.Load("../../config", netmode.UnitTestNet)
+	require.NoError(t, err)
+	newChain := func(t *testing.T) *core.Blockchain {
+		chain, err := initBlockChain(cfg, zaptest.NewLogger(t))
+		require.NoError(t, err)
+		go chain.Run()
+		t.Cleanup(chain.Close)
+		return chain
+	}
+	check := func(t *testing.T, mpCfg config.MemPoolPersistence) {
+		chain := newChain(t)
+		tx, err := testchain.NewTransferFromOwner(chain, chain.UtilityTokenHash(), util.Uint160{1, 2, 3}, 1, 0, 100)
+		require.NoError(t, err)
+		require.NoError(t, chain.PoolTx(tx))
+		require.NoError(t, saveMemPool(chain, mpCfg))
+
+		chain = newChain(t)
+		require.NoError(t, loadMemPool(chain, mpCfg, zaptest.NewLogger(t)))
+		require.Equal(t, 1, chain.GetMemPool().Count())
+		require.True(t, chain.GetMemPool().ContainsKey(tx.Hash()))
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		chain := newChain(t)
+		require.NoError(t, saveMemPool(chain, config.MemPoolPersistence{}))
+		require.NoError(t, loadMemPool(chain, config.MemPoolPersistence{}, zaptest.NewLogger(t)))
+	})
+	t.Run("no file", func(t *testing.T) {
+		chain := newChain(t)
+		mpCfg := config.MemPoolPersistence{Path: filepath.Join(d, "missing")}
+		require.NoError(t, loadMemPool(chain, mpCfg, zaptest.NewLogger(t)))
+	})
+	t.Run("plain", func(t *testing.T) {
+		check(t, config.MemPoolPersistence{Path: filepath.Join(d, "plain")})
+	})
+	t.Run("encrypted", func(t *testing.T) {
+		keyFile := filepath.Join(d, "key")
+		require.NoError(t, ioutil.WriteFile(keyFile, []byte(hex.EncodeToString(make([]byte, 32))+"\n"), 0600))
+		mpCfg := config.MemPoolPersistence{Path: filepath.Join(d, "encrypted"), KeyFile: keyFile}
+		check(t, mpCfg)
+
+		chain := newChain(t)
+		err := loadMemPool(chain, config.MemPoolPersistence{Path: mpCfg.Path}, zaptest.NewLogger(t))
+		require.True(t, errors.Is(err, mempool.ErrWrongKey), err)
+	})
+	t.Run("bad key", func(t *testing.T) {
+		keyFile := filepath.Join(d, "badkey")
+		require.NoError(t, ioutil.WriteFile(keyFile, []byte("not a hex"), 0600))
+		chain := newChain(t)
+		require.Error(t, saveMemPool(chain, config.MemPoolPersistence{Path: filepath.Join(d, "bad"), KeyFile: keyFile}))
+	})
+}
@@ -334,16 +334,17 @@ func NewCommands() []cli.Command {
 				},
 			},
 			{
-				Name:   "calc-hash",
-				Usage:  "calculates hash of a contract after deployment",
-				Action: calcHash,
+				Name:    "calc-hash",
+				Aliases: []string{"hash"},
+				Usage:   "calculates hash of a contract after deployment",
+				Action:  calcHash,
 				Flags: []cli.Flag{
 					flags.AddressFlag{
 						Name:  "sender, s",
 						Usage: "sender script hash or address",
 					},
 					cli.StringFlag{
-						Name:  "in",
+						Name:  "in, nef",
 						Usage: "path to NEF file",
 					},
 					cli.StringFlag{
@@ -352,6 +353,16 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "manifest-diff",
+				Usage:     "shows differences between two contract manifests",
+				UsageText: "neo-go contract manifest-diff <old.manifest.json> <new.manifest.json>",
+				Description: `Compares two manifests (usually the one of deployed contract and the one
+   of its new version) and prints added, removed and changed methods, events,
+   permissions and supported standards. Method offsets are not compared.
+`,
+				Action: manifestDiff,
+			},
 		},
 	}}
 }
@@ -477,17 +488,49 @@ func calcHash(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("can't unmarshal .nef file: %w", err), 1)
 	}
-	manifestBytes, err := ioutil.ReadFile(mpath)
+	m, err := readManifest(mpath)
 	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, "Contract hash:", state.CreateContractHash(sender.Uint160(), nefFile.Checksum, m.Name).StringLE())
+	return nil
+}
+
+func manifestDiff(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return cli.NewExitError("two manifest files should be provided", 1)
+	}
+	var ms [2]*manifest.Manifest
+	for i := range ms {
+		m, err := readManifest(ctx.Args().Get(i))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		ms[i] = m
+	}
+	diff := manifest.Diff(ms[0], ms[1])
+	if len(diff) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "Manifests are equal.")
+		return nil
+	}
+	for _, d := range diff {
+		fmt.Fprintln(ctx.App.Writer, d)
+	}
+	return nil
+}
+
+// readManifest reads and unmarshals manifest from the given file.
+func readManifest(path string) (*manifest.Manifest, error) {
+	manifestBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest file: %w", err)
 	}
 	m := &manifest.Manifest{}
 	err = json.Unmarshal(manifestBytes, m)
 	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+		return nil, fmt.Errorf("failed to restore manifest file: %w", err)
 	}
-	fmt.Fprintln(ctx.App.Writer, "Contract hash:", state.CreateContractHash(sender.Uint160(), nefFile.Checksum, m.Name).StringLE())
-	return nil
+	return m, nil
 }
 
 func testInvokeFunction(ctx *cli.Context) error {
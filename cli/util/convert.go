@@ -23,6 +23,24 @@ func NewCommands() []cli.Command {
         and converted to other formats. Strings are escaped and output in quotes.`,
 					Action: handleParse,
 				},
+				{
+					Name:      "decode-tx",
+					Usage:     "Decode hex or base64-encoded transaction and print its contents",
+					UsageText: "decode-tx <tx>",
+					Action:    decodeTx,
+				},
+				{
+					Name:      "decode-block",
+					Usage:     "Decode hex or base64-encoded block and print its contents",
+					UsageText: "decode-block [--stateroot] <block>",
+					Action:    decodeBlock,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "stateroot",
+							Usage: "block header contains state root (StateRootInHeader setting)",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	nio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/urfave/cli"
+)
+
+var errNoData = errors.New("no data to decode")
+
+// getDataFromArgs returns the only argument decoded from hex or base64.
+func getDataFromArgs(ctx *cli.Context) ([]byte, error) {
+	args := ctx.Args()
+	if len(args) == 0 {
+		return nil, errNoData
+	}
+	if len(args) > 1 {
+		return nil, errors.New("too many arguments")
+	}
+	if b, err := hex.DecodeString(args[0]); err == nil {
+		return b, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		return nil, errors.New("data is neither hex nor base64")
+	}
+	return b, nil
+}
+
+func decodeTx(ctx *cli.Context) error {
+	b, err := getDataFromArgs(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	tx, err := transaction.NewTransactionFromBytes(b)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to decode transaction: %w", err), 1)
+	}
+	printTx(ctx.App.Writer, tx, "")
+	return nil
+}
+
+func decodeBlock(ctx *cli.Context) error {
+	b, err := getDataFromArgs(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	blk := block.New(ctx.Bool("stateroot"))
+	br := nio.NewBinReaderFromBuf(b)
+	blk.DecodeBinary(br)
+	if br.Err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to decode block: %w", br.Err), 1)
+	}
+	w := ctx.App.Writer
+	fmt.Fprintf(w, "Hash: %s\n", blk.Hash().StringLE())
+	fmt.Fprintf(w, "Size: %d\n", len(b))
+	fmt.Fprintf(w, "Version: %d\n", blk.Version)
+	fmt.Fprintf(w, "Index: %d\n", blk.Index)
+	fmt.Fprintf(w, "Timestamp: %d (%s)\n", blk.Timestamp,
+		time.Unix(0, int64(blk.Timestamp)*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "PrevHash: %s\n", blk.PrevHash.StringLE())
+	fmt.Fprintf(w, "MerkleRoot: %s\n", blk.MerkleRoot.StringLE())
+	if blk.StateRootEnabled {
+		fmt.Fprintf(w, "PrevStateRoot: %s\n", blk.PrevStateRoot.StringLE())
+	}
+	fmt.Fprintf(w, "PrimaryIndex: %d\n", blk.PrimaryIndex)
+	fmt.Fprintf(w, "NextConsensus: %s\n", address.Uint160ToString(blk.NextConsensus))
+	printWitness(w, blk.Script, "")
+	fmt.Fprintf(w, "Transactions: %d\n", len(blk.Transactions))
+	for i, tx := range blk.Transactions {
+		fmt.Fprintf(w, "Transaction #%d:\n", i)
+		printTx(w, tx, "  ")
+	}
+	return nil
+}
+
+// printTx prints human-readable transaction representation prefixing each
+// line with the given indentation.
+func printTx(w io.Writer, tx *transaction.Transaction, indent string) {
+	fmt.Fprintf(w, "%sHash: %s\n", indent, tx.Hash().StringLE())
+	fmt.Fprintf(w, "%sSize: %d\n", indent, tx.Size())
+	fmt.Fprintf(w, "%sVersion: %d\n", indent, tx.Version)
+	fmt.Fprintf(w, "%sNonce: %d\n", indent, tx.Nonce)
+	fmt.Fprintf(w, "%sSystemFee: %s GAS\n", indent, fixedn.Fixed8(tx.SystemFee))
+	fmt.Fprintf(w, "%sNetworkFee: %s GAS\n", indent, fixedn.Fixed8(tx.NetworkFee))
+	fmt.Fprintf(w, "%sValidUntilBlock: %d\n", indent, tx.ValidUntilBlock)
+	fmt.Fprintf(w, "%sSender: %s\n", indent, address.Uint160ToString(tx.Sender()))
+	for i, s := range tx.Signers {
+		scopes, _ := s.Scopes.MarshalJSON()
+		str, _ := strconv.Unquote(string(scopes))
+		fmt.Fprintf(w, "%sSigner #%d: %s (%s)\n", indent, i, address.Uint160ToString(s.Account), str)
+		for _, c := range s.AllowedContracts {
+			fmt.Fprintf(w, "%s  Allowed contract: %s\n", indent, c.StringLE())
+		}
+		for _, g := range s.AllowedGroups {
+			fmt.Fprintf(w, "%s  Allowed group: %s\n", indent, g.String())
+		}
+	}
+	for i := range tx.Attributes {
+		attr, _ := json.Marshal(&tx.Attributes[i])
+		fmt.Fprintf(w, "%sAttribute #%d: %s\n", indent, i, attr)
+	}
+	for i := range tx.Scripts {
+		printWitness(w, tx.Scripts[i], indent)
+	}
+	fmt.Fprintf(w, "%sScript:\n", indent)
+	v := vm.New()
+	v.LoadScript(tx.Script)
+	v.PrintOps(w)
+}
+
+// printWitness prints witness scripts encoded in hex.
+func printWitness(w io.Writer, wit transaction.Witness, indent string) {
+	fmt.Fprintf(w, "%sWitness: %s\n", indent, wit.ScriptHash().StringLE())
+	fmt.Fprintf(w, "%s  Invocation: %s\n", indent, hex.EncodeToString(wit.InvocationScript))
+	fmt.Fprintf(w, "%s  Verification: %s\n", indent, hex.EncodeToString(wit.VerificationScript))
+}
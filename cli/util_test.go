@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Transaction and block from pkg/rpc/client tests.
+const (
+	base64TxMoveNeo = "AAIAAADA2KcAAAAAAAx5QwAAAAAAsAQAAAHe7nnBifMAmLC6ai65CzqSWKbH/wEAWwsCGN31BQwUVVQtU+0PVUb61E1umZEoZwIvzl4MFN7uecGJ8wCYsLpqLrkLOpJYpsf/FMAfDAh0cmFuc2ZlcgwU9WPqQLwoPU0OBcSOowWz8qBzQO9BYn1bUjkBxgxATIm2/0zMxdiM7XnPfK71cV4fd0elAZwc7YH+0St3IWmPKYSMYfidX9xgLc98wLi8Ikp9cEmp7PUTyqoHqYmrqAxAbxxWY+bt2me1JH8pTHNMIfcnSLr7ZVW92P+jjp/Bzd0QrO1Sy4J2k990Z9YFgci0AcwJXY6yZw38Q0hqn0po3wxAhDKcmR3uZX5Egc5T6D/Ywttnw0vu01LewZMemWX+Wg7tPSBa1sz4rcZL8+EqwMoAnSXczJAV2GT1GrZDvNvBTJMTDCECEDp/fdAWVYWX95YNJ8UWpDlP2Wi55lFV60sBPkBAQG4MIQKnvFX+hoTgEZdo0QS6MHlb3MhmGehkrdJhVnI+0YXNYgwhArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43CDCED2QwH32PmkM53kS4Qq1GsyUS2aGAje2CMT4+DCece5pkUQXvObKU="
+	base64B1        = "AAAAAAwIVa2D6Yha3tArd5XnwkAf7deJBsdyyvpYb2xMZGBb/YwjvRiYdH/LL9atXcWbYsXLHqkKEutiil4zsK7lKrFxU6tEeAEAAAEAAAAA3u55wYnzAJiwumouuQs6klimx/8BxgxAUfn6Pu/yxlYcuGzwM7RzacB9z9YG7J77DR/j9NfrNR7leWUd0qTqnqiD1H9Vydj401smVWnTg/XAisjZTFpT+gxAAT3EbjC87Gb5UEe+Pvx3AP31lJeIuQL1gKcm6SfJBMaHon2g1dAah3xrKXrj3nGRypvWTKCVEXXDFzEL3ZndswxA/eGxm/DUde1jWbvi+avLdId0VH2roTyqJScLblo5xtRRHm0uKf6NObl6cNJLnSjcumrOQbMVgruDb7WIaOl0E5MTDCECEDp/fdAWVYWX95YNJ8UWpDlP2Wi55lFV60sBPkBAQG4MIQKnvFX+hoTgEZdo0QS6MHlb3MhmGehkrdJhVnI+0YXNYgwhArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43CDCED2QwH32PmkM53kS4Qq1GsyUS2aGAje2CMT4+DCece5pkUQXvObKUCAAIAAADA2KcAAAAAAAx5QwAAAAAAsAQAAAHe7nnBifMAmLC6ai65CzqSWKbH/wEAWwsCGN31BQwUVVQtU+0PVUb61E1umZEoZwIvzl4MFN7uecGJ8wCYsLpqLrkLOpJYpsf/FMAfDAh0cmFuc2ZlcgwU9WPqQLwoPU0OBcSOowWz8qBzQO9BYn1bUjkBxgxATIm2/0zMxdiM7XnPfK71cV4fd0elAZwc7YH+0St3IWmPKYSMYfidX9xgLc98wLi8Ikp9cEmp7PUTyqoHqYmrqAxAbxxWY+bt2me1JH8pTHNMIfcnSLr7ZVW92P+jjp/Bzd0QrO1Sy4J2k990Z9YFgci0AcwJXY6yZw38Q0hqn0po3wxAhDKcmR3uZX5Egc5T6D/Ywttnw0vu01LewZMemWX+Wg7tPSBa1sz4rcZL8+EqwMoAnSXczJAV2GT1GrZDvNvBTJMTDCECEDp/fdAWVYWX95YNJ8UWpDlP2Wi55lFV60sBPkBAQG4MIQKnvFX+hoTgEZdo0QS6MHlb3MhmGehkrdJhVnI+0YXNYgwhArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43CDCED2QwH32PmkM53kS4Qq1GsyUS2aGAje2CMT4+DCece5pkUQXvObKUAAwAAAMDYpwAAAAAArIhDAAAAAACwBAAAAd7uecGJ8wCYsLpqLrkLOpJYpsf/AQBfCwMA6HZIFwAAAAwUVVQtU+0PVUb61E1umZEoZwIvzl4MFN7uecGJ8wCYsLpqLrkLOpJYpsf/FMAfDAh0cmFuc2ZlcgwUz3bii9AGLEpHjuNVYQETGfPPpNJBYn1bUjkBxgxA1E8pqjQrEDsUL7B2U+u2h95Jr6yvObCHbWif6tRx6cpNqy7VFJ/5A5T6W5NLLIZBD9os5ZQq+rRIgOliQOWRiwxAysxPLL6wVsETJZm2vcVQ3ZBH7IHa82wjQoyKGrhQH+rygFF/TmVH6E5oEOz/bsQwudk60CWJKcrFyXzfXlK5KAxAjH0w9It2Tlax1xv3T5xstaSl9le2fyYDa+smDwR+ytnmGRkSNn3oWsHdS8B7A1TzP76W3Dixn2NFFp9/j3D3cpMTDCECEDp/fdAWVYWX95YNJ8UWpDlP2Wi55lFV60sBPkBAQG4MIQKnvFX+hoTgEZdo0QS6MHlb3MhmGehkrdJhVnI+0YXNYgwhArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43CDCED2QwH32PmkM53kS4Qq1GsyUS2aGAje2CMT4+DCece5pkUQXvObKU="
+)
+
+func TestUtilDecodeTx(t *testing.T) {
+	e := newExecutor(t, false)
+
+	checkTx := func(t *testing.T) {
+		e.checkNextLine(t, "^Hash: f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275$")
+		e.checkNextLine(t, "^Size: 488$")
+		e.checkNextLine(t, "^Version: 0$")
+		e.checkNextLine(t, "^Nonce: 2$")
+		e.checkNextLine(t, "^SystemFee: 0.11 GAS$")
+		e.checkNextLine(t, "^NetworkFee: 0.044219 GAS$")
+		e.checkNextLine(t, "^ValidUntilBlock: 1200$")
+		e.checkNextLine(t, "^Sender: NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6$")
+		e.checkNextLine(t, "^Signer #0: NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6 \\(CalledByEntry\\)$")
+		e.checkNextLine(t, "^Witness: ffc7a658923a0bb92e6abab09800f389c179eede$")
+		e.checkNextLine(t, "^  Invocation: 0c40")
+		e.checkNextLine(t, "^  Verification: 130c21")
+		e.checkNextLine(t, "^Script:$")
+		e.checkNextLine(t, "^INDEX\\s+OPCODE\\s+PARAMETER")
+		e.checkNextLine(t, "^0\\s+PUSHNULL")
+		e.checkNextLine(t, "^1\\s+PUSHINT32\\s+99999000")
+		e.checkNextLine(t, "^6\\s+PUSHDATA1\\s+55542d53ed0f5546fad44d6e99912867022fce5e")
+		e.checkNextLine(t, "^28\\s+PUSHDATA1\\s+deee79c189f30098b0ba6a2eb90b3a9258a6c7ff")
+		e.checkNextLine(t, "^50\\s+PUSH4")
+		e.checkNextLine(t, "^51\\s+PACK")
+		e.checkNextLine(t, "^52\\s+PUSH15")
+		e.checkNextLine(t, "^53\\s+PUSHDATA1\\s+7472616e73666572")
+		e.checkNextLine(t, "^63\\s+PUSHDATA1\\s+f563ea40bc283d4d0e05c48ea305b3f2a07340ef")
+		e.checkNextLine(t, "^85\\s+SYSCALL\\s+System.Contract.Call")
+		e.checkNextLine(t, "^90\\s+ASSERT")
+		e.checkEOF(t)
+	}
+
+	t.Run("base64", func(t *testing.T) {
+		e.Run(t, "neo-go", "util", "decode-tx", base64TxMoveNeo)
+		checkTx(t)
+	})
+	t.Run("hex", func(t *testing.T) {
+		raw, err := base64.StdEncoding.DecodeString(base64TxMoveNeo)
+		require.NoError(t, err)
+		e.Run(t, "neo-go", "util", "decode-tx", hex.EncodeToString(raw))
+		checkTx(t)
+	})
+	t.Run("missing argument", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "decode-tx")
+	})
+	t.Run("invalid encoding", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "decode-tx", "not a transaction")
+	})
+	t.Run("invalid transaction", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "decode-tx", "0102")
+	})
+}
+
+func TestUtilDecodeBlock(t *testing.T) {
+	e := newExecutor(t, false)
+
+	e.Run(t, "neo-go", "util", "decode-block", base64B1)
+	e.checkNextLine(t, "^Hash: 81a439175d3bdd8961b6223a9b6f6d234f996824c5cfce6af17e6fc14cd84355$")
+	e.checkNextLine(t, "^Size: 1430$")
+	e.checkNextLine(t, "^Version: 0$")
+	e.checkNextLine(t, "^Index: 1$")
+	e.checkNextLine(t, "^Timestamp: 1616059782001 \\(2021-03-18T09:29:42Z\\)$")
+	e.checkNextLine(t, "^PrevHash: 5b60644c6c6f58faca72c70689d7ed1f40c2e795772bd0de5a88e983ad55080c$")
+	e.checkNextLine(t, "^MerkleRoot: b12ae5aeb0335e8a62eb120aa91ecbc5629bc55dadd62fcb7f749818bd238cfd$")
+	e.checkNextLine(t, "^PrimaryIndex: 0$")
+	e.checkNextLine(t, "^NextConsensus: NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6$")
+	e.checkNextLine(t, "^Witness: ffc7a658923a0bb92e6abab09800f389c179eede$")
+	e.checkNextLine(t, "^  Invocation: ")
+	e.checkNextLine(t, "^  Verification: ")
+	e.checkNextLine(t, "^Transactions: 2$")
+	e.checkNextLine(t, "^Transaction #0:$")
+	e.checkNextLine(t, "^  Hash: f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275$")
+
+	t.Run("invalid block", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "decode-block", base64TxMoveNeo)
+	})
+	t.Run("state root mismatch", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "decode-block", "--stateroot", base64B1)
+	})
+}
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -8,6 +9,9 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
 )
 
@@ -31,30 +35,9 @@ func signStoredTransaction(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	tx, ok := c.Verifiable.(*transaction.Transaction)
-	if !ok {
-		return cli.NewExitError("verifiable item is not a transaction", 1)
-	}
-
-	ch, err := address.StringToUint160(acc.Address)
+	tx, err := addAccountSignature(c, acc)
 	if err != nil {
-		return cli.NewExitError(fmt.Errorf("wallet contains invalid account: %s", acc.Address), 1)
-	}
-	signerFound := false
-	for i := range tx.Signers {
-		if tx.Signers[i].Account == ch {
-			signerFound = true
-			break
-		}
-	}
-	if !signerFound {
-		return cli.NewExitError("tx signers don't contain provided account", 1)
-	}
-
-	priv := acc.PrivateKey()
-	sign := priv.SignHashable(uint32(c.Network), tx)
-	if err := c.AddSignature(ch, acc.Contract, priv.PublicKey(), sign); err != nil {
-		return cli.NewExitError(fmt.Errorf("can't add signature: %w", err), 1)
+		return cli.NewExitError(err, 1)
 	}
 	if out := ctx.String("out"); out != "" {
 		if err := paramcontext.Save(c, out); err != nil {
@@ -62,12 +45,8 @@ func signStoredTransaction(ctx *cli.Context) error {
 		}
 	}
 	if len(ctx.String(options.RPCEndpointFlag)) != 0 {
-		for i := range tx.Signers {
-			w, err := c.GetWitness(tx.Signers[i].Account)
-			if err != nil {
-				return cli.NewExitError(err, 1)
-			}
-			tx.Scripts = append(tx.Scripts, *w)
+		if err := addWitnesses(c, tx); err != nil {
+			return cli.NewExitError(err, 1)
 		}
 
 		gctx, cancel := options.GetTimeoutContext(ctx)
@@ -89,3 +68,81 @@ func signStoredTransaction(ctx *cli.Context) error {
 	fmt.Fprintln(ctx.App.Writer, tx.Hash().StringLE())
 	return nil
 }
+
+// cosignStoredTransaction adds acc signature to the transaction stored in the
+// file specified by `--in` flag and sends it if it's completely signed.
+// Otherwise the transaction is saved to the file specified by `--out` flag
+// to be signed by other parties with `wallet sign`.
+func cosignStoredTransaction(ctx *cli.Context, c *client.Client, acc *wallet.Account) error {
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	tx, err := addAccountSignature(pc, acc)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err := addWitnesses(pc, tx); err != nil {
+		out := ctx.String("out")
+		if out == "" {
+			return cli.NewExitError(fmt.Errorf("transaction is not completely signed (%v), use --out to save it", err), 1)
+		}
+		if err := paramcontext.Save(pc, out); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, tx.Hash().StringLE())
+		return nil
+	}
+	res, err := c.SendRawTransaction(tx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, res.StringLE())
+	return nil
+}
+
+// addAccountSignature signs the transaction from the parameter context with
+// acc and adds the signature to the context.
+func addAccountSignature(c *context.ParameterContext, acc *wallet.Account) (*transaction.Transaction, error) {
+	tx, ok := c.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return nil, errors.New("verifiable item is not a transaction")
+	}
+
+	ch, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("wallet contains invalid account: %s", acc.Address)
+	}
+	signerFound := false
+	for i := range tx.Signers {
+		if tx.Signers[i].Account == ch {
+			signerFound = true
+			break
+		}
+	}
+	if !signerFound {
+		return nil, errors.New("tx signers don't contain provided account")
+	}
+
+	priv := acc.PrivateKey()
+	sign := priv.SignHashable(uint32(c.Network), tx)
+	if err := c.AddSignature(ch, acc.Contract, priv.PublicKey(), sign); err != nil {
+		return nil, fmt.Errorf("can't add signature: %w", err)
+	}
+	return tx, nil
+}
+
+// addWitnesses creates witnesses for all transaction signers from the
+// parameter context.
+func addWitnesses(c *context.ParameterContext, tx *transaction.Transaction) error {
+	scripts := make([]transaction.Witness, 0, len(tx.Signers))
+	for i := range tx.Signers {
+		w, err := c.GetWitness(tx.Signers[i].Account)
+		if err != nil {
+			return err
+		}
+		scripts = append(scripts, *w)
+	}
+	tx.Scripts = scripts
+	return nil
+}
@@ -58,6 +58,7 @@ var (
 	multiTransferFlags = append([]cli.Flag{
 		walletPathFlag,
 		outFlag,
+		inFlag,
 		fromAddrFlag,
 		gasFlag,
 	}, options.RPC...)
@@ -69,6 +70,7 @@ func newNEP17Commands() []cli.Command {
 	balanceFlags = append(balanceFlags, options.RPC...)
 	transferFlags := make([]cli.Flag, len(baseTransferFlags))
 	copy(transferFlags, baseTransferFlags)
+	transferFlags = append(transferFlags, inFlag)
 	transferFlags = append(transferFlags, options.RPC...)
 	return []cli.Command{
 		{
@@ -117,6 +119,11 @@ func newNEP17Commands() []cli.Command {
    for the details about 'data' parameter and cosigners syntax. If no 'data' is
    given then default nil value will be used. If no cosigners are given then the
    sender with CalledByEntry scope will be used as the only signer.
+
+   If '--in' is given, the transfer parameters are ignored and a partially
+   signed transaction is loaded from the file instead, it's signed by the
+   sender account and sent if all the signatures are collected (or saved to
+   '--out' file otherwise).
 `,
 		},
 		{
@@ -126,6 +133,11 @@ func newNEP17Commands() []cli.Command {
 				` <token1>:<addr1>:<amount1> [<token2>:<addr2>:<amount2> [...]] [-- <cosigner1:Scope> [<cosigner2> [...]]]`,
 			Action: multiTransferNEP17,
 			Flags:  multiTransferFlags,
+			Description: `Transfers specified NEP17 token amounts to multiple recipients. If '--in' is
+   given, recipients are ignored and a partially signed transaction is loaded
+   from the file instead, it's signed by the sender account and sent if all
+   the signatures are collected (or saved to '--out' file otherwise).
+`,
 		},
 	}
 }
@@ -418,6 +430,9 @@ func multiTransferNEP17(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
+	if ctx.String("in") != "" {
+		return cosignStoredTransaction(ctx, c, acc)
+	}
 	if ctx.NArg() == 0 {
 		return cli.NewExitError("empty recipients list", 1)
 	}
@@ -505,6 +520,9 @@ func transferNEP(ctx *cli.Context, standard string) error {
 		return cli.NewExitError(err, 1)
 	}
 
+	if ctx.String("in") != "" {
+		return cosignStoredTransaction(ctx, c, acc)
+	}
 	toFlag := ctx.Generic("to").(*flags.Address)
 	to := toFlag.Uint160()
 	token, err := getMatchingToken(ctx, wall, ctx.String("token"), standard)
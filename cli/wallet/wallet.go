@@ -10,7 +10,6 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/input"
 	"github.com/nspcc-dev/neo-go/cli/options"
-	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -120,6 +119,10 @@ func NewCommands() []cli.Command {
 				Action: addAccount,
 				Flags: []cli.Flag{
 					walletPathFlag,
+					cli.StringFlag{
+						Name:  "watch-only",
+						Usage: "address or hex-encoded public key to create watch-only account (that can't sign) for",
+					},
 				},
 			},
 			{
@@ -171,6 +174,31 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "derive",
+				Usage:     "derive account key from the seed and add it to the wallet",
+				UsageText: "derive --wallet <path> [--path <derivation_path>] [--index <n>]",
+				Description: `Derives private key from the hex-encoded seed (16-64 bytes) entered
+   interactively using BIP-32 derivation scheme adapted for secp256r1 curve
+   (SLIP-0010). Key is derived using the given base path (` + wallet.DefaultDerivationPath + `
+   by default) and account index appended to it (0 by default), so the same
+   seed always produces the same keys. Derived key is encrypted and added to
+   the wallet the same way it's done by import command.
+`,
+				Action: deriveAccount,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					cli.StringFlag{
+						Name:  "path",
+						Usage: "Base derivation path",
+						Value: wallet.DefaultDerivationPath,
+					},
+					cli.UintFlag{
+						Name:  "index",
+						Usage: "Account index",
+					},
+				},
+			},
 			{
 				Name:  "import-multisig",
 				Usage: "import multisig contract",
@@ -273,11 +301,7 @@ func claimGas(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	neoContractHash, err := c.GetNativeContractHash(nativenames.Neo)
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
-	hash, err := c.TransferNEP17(acc, scriptHash, neoContractHash, 0, 0, nil, nil)
+	hash, err := c.ClaimGAS(acc)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -323,6 +347,18 @@ func addAccount(ctx *cli.Context) error {
 
 	defer wall.Close()
 
+	if watchOnly := ctx.String("watch-only"); watchOnly != "" {
+		acc, err := newWatchOnlyAccount(watchOnly)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		rawName, _ := input.ReadLine("Enter the name of the account > ")
+		acc.Label = strings.TrimRight(rawName, "\n")
+		if err := addAccountAndSave(wall, acc); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		return nil
+	}
 	if err := createAccount(wall); err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -330,6 +366,19 @@ func addAccount(ctx *cli.Context) error {
 	return nil
 }
 
+// newWatchOnlyAccount creates watch-only account from the given address or
+// hex-encoded public key.
+func newWatchOnlyAccount(s string) (*wallet.Account, error) {
+	if pub, err := keys.NewPublicKeyFromString(s); err == nil {
+		return wallet.NewWatchOnlyAccountFromPublicKey(pub), nil
+	}
+	acc, err := wallet.NewWatchOnlyAccount(s)
+	if err != nil {
+		return nil, fmt.Errorf("neither address nor public key: %s", s)
+	}
+	return acc, nil
+}
+
 func exportKeys(ctx *cli.Context) error {
 	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
@@ -358,6 +407,9 @@ loop:
 			continue
 		}
 
+		if a.EncryptedWIF == "" {
+			continue
+		}
 		for i := range wifs {
 			if a.EncryptedWIF == wifs[i] {
 				continue loop
@@ -515,6 +567,53 @@ func importWallet(ctx *cli.Context) error {
 	return nil
 }
 
+func deriveAccount(ctx *cli.Context) error {
+	wall, err := openWallet(ctx.String("wallet"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	path, err := wallet.ParseDerivationPath(ctx.String("path"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	index := ctx.Uint("index")
+	if uint64(index) >= uint64(wallet.HardenedKeyStart) {
+		return cli.NewExitError("invalid account index", 1)
+	}
+	rawSeed, err := input.ReadPassword("Enter hex-encoded seed > ")
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	seed, err := hex.DecodeString(rawSeed)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid seed: %w", err), 1)
+	}
+	master, err := wallet.NewMasterKey(seed)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	k, err := master.Derive(append(path, uint32(index)))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc := wallet.NewAccountFromPrivateKey(k.PrivateKey())
+	name, pass, err := readAccountInfo()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc.Label = name
+	if err := acc.Encrypt(pass); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err := addAccountAndSave(wall, acc); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, acc.Address)
+	return nil
+}
+
 func removeAccount(ctx *cli.Context) error {
 	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
@@ -598,6 +697,12 @@ func dumpKeys(ctx *cli.Context) error {
 
 	hasPrinted := false
 	for _, acc := range accounts {
+		if acc.Contract == nil {
+			if addrFlag.IsSet {
+				return cli.NewExitError(fmt.Errorf("no verification script for address %s", acc.Address), 1)
+			}
+			continue
+		}
 		pub, ok := vm.ParseSignatureContract(acc.Contract.Script)
 		if ok {
 			if hasPrinted {
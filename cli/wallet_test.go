@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -78,6 +79,87 @@ func TestWalletInit(t *testing.T) {
 		})
 	})
 
+	t.Run("CreateWatchOnly", func(t *testing.T) {
+		privs, _ := generateKeys(t, 2)
+		cmd := []string{"neo-go", "wallet", "create", "--wallet", walletPath}
+		t.Run("InvalidAddress", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, "--watch-only", "notanaddress")...)
+		})
+
+		e.In.WriteString("watch_addr\r")
+		e.Run(t, append(cmd, "--watch-only", privs[0].Address())...)
+		e.In.WriteString("watch_key\r")
+		e.Run(t, append(cmd, "--watch-only", hex.EncodeToString(privs[1].PublicKey().Bytes()))...)
+
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		t.Cleanup(w.Close)
+		for i, label := range []string{"watch_addr", "watch_key"} {
+			acc := w.GetAccount(privs[i].GetScriptHash())
+			require.NotNil(t, acc)
+			require.Equal(t, label, acc.Label)
+			require.True(t, acc.IsWatchOnly())
+		}
+		require.Nil(t, w.GetAccount(privs[0].GetScriptHash()).Contract)
+		require.Equal(t, privs[1].PublicKey().GetVerificationScript(), w.GetAccount(privs[1].GetScriptHash()).Contract.Script)
+
+		t.Run("AlreadyExists", func(t *testing.T) {
+			e.In.WriteString("watch_addr\r")
+			e.RunWithError(t, append(cmd, "--watch-only", privs[0].Address())...)
+		})
+		t.Run("DumpKeys", func(t *testing.T) {
+			e.RunWithError(t, "neo-go", "wallet", "dump-keys", "--wallet", walletPath, "--address", privs[0].Address())
+			e.Run(t, "neo-go", "wallet", "dump-keys", "--wallet", walletPath, "--address", privs[1].Address())
+			e.checkNextLine(t, privs[1].Address())
+			e.checkNextLine(t, hex.EncodeToString(privs[1].PublicKey().Bytes()))
+			e.checkEOF(t)
+		})
+	})
+
+	t.Run("Derive", func(t *testing.T) {
+		const seedHex = "000102030405060708090a0b0c0d0e0f"
+		seed, err := hex.DecodeString(seedHex)
+		require.NoError(t, err)
+		cmd := []string{"neo-go", "wallet", "derive", "--wallet", walletPath}
+		t.Run("InvalidSeed", func(t *testing.T) {
+			e.In.WriteString("notahex\r")
+			e.RunWithError(t, cmd...)
+			e.In.WriteString("0102\r")
+			e.RunWithError(t, cmd...)
+		})
+		t.Run("InvalidPath", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, "--path", "44'/888'")...)
+		})
+
+		for _, index := range []uint32{0, 1} {
+			expected, err := wallet.NewAccountFromSeed(seed, index)
+			require.NoError(t, err)
+
+			e.In.WriteString(seedHex + "\r")
+			e.In.WriteString("derived\r")
+			e.In.WriteString("pass\r")
+			e.In.WriteString("pass\r")
+			e.Run(t, append(cmd, "--index", strconv.FormatUint(uint64(index), 10))...)
+			e.checkNextLine(t, expected.Address)
+
+			w, err := wallet.NewWalletFromFile(walletPath)
+			require.NoError(t, err)
+			actual := w.GetAccount(expected.Contract.ScriptHash())
+			require.NotNil(t, actual)
+			require.NoError(t, actual.Decrypt("pass"))
+			require.Equal(t, expected.PrivateKey().Bytes(), actual.PrivateKey().Bytes())
+			w.Close()
+		}
+
+		t.Run("AlreadyExists", func(t *testing.T) {
+			e.In.WriteString(seedHex + "\r")
+			e.In.WriteString("derived\r")
+			e.In.WriteString("pass\r")
+			e.In.WriteString("pass\r")
+			e.RunWithError(t, cmd...)
+		})
+	})
+
 	t.Run("Import", func(t *testing.T) {
 		t.Run("WIF", func(t *testing.T) {
 			priv, err := keys.NewPrivateKey()
@@ -1,6 +1,7 @@
 package fakechain
 
 import (
+	"context"
 	"errors"
 	"math"
 	"math/big"
@@ -284,6 +285,21 @@ func (chain *FakeChain) GetEnrollments() ([]state.Validator, error) {
 	panic("TODO")
 }
 
+// GetVoters implements Blockchainer interface.
+func (chain *FakeChain) GetVoters() ([]state.Voter, error) {
+	panic("TODO")
+}
+
+// TraceTransaction implements Blockchainer interface.
+func (chain *FakeChain) TraceTransaction(context.Context, util.Uint256, int) (*state.AppExecResult, []vm.TraceStep, error) {
+	panic("TODO")
+}
+
+// ListContracts implements Blockchainer interface.
+func (chain *FakeChain) ListContracts() ([]*state.Contract, error) {
+	panic("TODO")
+}
+
 // GetStateModule implements Blockchainer interface.
 func (chain *FakeChain) GetStateModule() blockchainer.StateRoot {
 	return nil
@@ -304,6 +320,11 @@ func (chain *FakeChain) GetStorageItems(id int32) (map[string]state.StorageItem,
 	panic("TODO")
 }
 
+// GetStorageItemsSorted implements Blockchainer interface.
+func (chain *FakeChain) GetStorageItemsSorted(id int32) ([]state.StorageItemWithKey, error) {
+	panic("TODO")
+}
+
 // CurrentHeaderHash implements Blockchainer interface.
 func (chain *FakeChain) CurrentHeaderHash() util.Uint256 {
 	return util.Uint256{}
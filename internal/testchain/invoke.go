@@ -0,0 +1,151 @@
+package testchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// DefaultInvokeSysfee is the system fee used by InvokeMethod.
+const DefaultInvokeSysfee = 1_0000_0000
+
+// InvokeMethod invokes the method of the contract with the given arguments in
+// a new block signed by the signer and returns the execution result. Signer
+// can be either bool (`true` for committee, `false` for validators),
+// *wallet.Account or []*wallet.Account, accounts should have enough GAS to pay
+// for the transaction.
+func InvokeMethod(bc blockchainer.Blockchainer, signer interface{}, contract util.Uint160, method string, args ...interface{}) (*state.AppExecResult, error) {
+	return InvokeMethodWithFee(bc, DefaultInvokeSysfee, signer, contract, method, args...)
+}
+
+// InvokeMethodWithFee is similar to InvokeMethod, but allows to specify the
+// system fee of the transaction.
+func InvokeMethodWithFee(bc blockchainer.Blockchainer, sysfee int64, signer interface{}, contract util.Uint160, method string, args ...interface{}) (*state.AppExecResult, error) {
+	tx, err := NewInvocationTx(bc, sysfee, signer, contract, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	aers, err := PersistBlock(bc, tx)
+	if err != nil {
+		return nil, err
+	}
+	return aers[0], nil
+}
+
+// NewInvocationTx returns a transaction invoking the method of the contract
+// with the given arguments signed by the signer (see InvokeMethod), it's
+// valid for the next block only.
+func NewInvocationTx(bc blockchainer.Blockchainer, sysfee int64, signer interface{}, contract util.Uint160, method string, args ...interface{}) (*transaction.Transaction, error) {
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, method, callflag.All, args...)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	tx := transaction.New(w.Bytes(), sysfee)
+	tx.ValidUntilBlock = bc.BlockHeight() + 1
+	switch s := signer.(type) {
+	case bool:
+		if s {
+			tx.Signers = []transaction.Signer{{Account: CommitteeScriptHash(), Scopes: transaction.CalledByEntry}}
+			return tx, SignTxCommittee(bc, tx)
+		}
+		tx.Signers = []transaction.Signer{{Account: ownerHash, Scopes: transaction.CalledByEntry}}
+		return tx, SignTx(bc, tx)
+	case *wallet.Account:
+		return tx, SignTxWithAccounts(bc, tx, s)
+	case []*wallet.Account:
+		return tx, SignTxWithAccounts(bc, tx, s...)
+	default:
+		return nil, errors.New("invalid signer")
+	}
+}
+
+// SignTxWithAccounts adds accounts as transaction signers (the first one with
+// CalledByEntry scope, others with Global) and signs it with them.
+func SignTxWithAccounts(bc blockchainer.Blockchainer, tx *transaction.Transaction, accs ...*wallet.Account) error {
+	scope := transaction.CalledByEntry
+	for _, acc := range accs {
+		tx.Signers = append(tx.Signers, transaction.Signer{
+			Account: acc.PrivateKey().GetScriptHash(),
+			Scopes:  scope,
+		})
+		scope = transaction.Global
+	}
+	size := io.GetVarSize(tx)
+	for _, acc := range accs {
+		netFee, sizeDelta := fee.Calculate(bc.GetPolicer().GetBaseExecFee(), acc.Contract.Script)
+		size += sizeDelta
+		tx.NetworkFee += netFee
+	}
+	tx.NetworkFee += int64(size) * bc.FeePerByte()
+
+	for _, acc := range accs {
+		if err := acc.SignTx(Network(), tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PersistBlock adds a new block with the given transactions to the chain and
+// returns their execution results.
+func PersistBlock(bc blockchainer.Blockchainer, txs ...*transaction.Transaction) ([]*state.AppExecResult, error) {
+	b, err := newNextBlock(bc, txs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := bc.AddBlock(b); err != nil {
+		return nil, err
+	}
+	aers := make([]*state.AppExecResult, len(txs))
+	for i, tx := range txs {
+		res, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
+		if err != nil {
+			return nil, err
+		}
+		aers[i] = &res[0]
+	}
+	return aers, nil
+}
+
+// newNextBlock creates a block following the current chain's top block.
+func newNextBlock(bc blockchainer.Blockchainer, txs ...*transaction.Transaction) (*block.Block, error) {
+	witness := transaction.Witness{VerificationScript: MultisigVerificationScript()}
+	hdr, err := bc.GetHeader(bc.GetHeaderHash(int(bc.BlockHeight())))
+	if err != nil {
+		return nil, err
+	}
+	b := &block.Block{
+		Header: block.Header{
+			PrevHash: hdr.Hash(),
+			// It only needs to be bigger than the previous one.
+			Timestamp:     hdr.Timestamp + 1,
+			Index:         hdr.Index + 1,
+			NextConsensus: witness.ScriptHash(),
+			Script:        witness,
+		},
+		Transactions: txs,
+	}
+	if bc.GetConfig().StateRootInHeader {
+		sr, err := bc.GetStateModule().GetStateRoot(hdr.Index)
+		if err != nil {
+			return nil, fmt.Errorf("can't get state root: %w", err)
+		}
+		b.StateRootEnabled = true
+		b.PrevStateRoot = sr.Root
+	}
+	b.RebuildMerkleRoot()
+	b.Script.InvocationScript = Sign(b)
+	return b, nil
+}
@@ -125,6 +125,7 @@ func signTxGeneric(bc blockchainer.Blockchainer, sign func(hash.Hashable) []byte
 		tx.NetworkFee += netFee
 		size += sizeDelta
 		tx.NetworkFee += int64(size) * bc.FeePerByte()
+		tx.NetworkFee += transaction.AttributesFee(bc.GetConfig().AttributeFeeBase, tx.Attributes)
 		tx.Scripts = []transaction.Witness{{
 			InvocationScript:   sign(tx),
 			VerificationScript: verif,
@@ -12,7 +12,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	istorage "github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/ledger"
 	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -43,6 +45,14 @@ func TestFindFlags(t *testing.T) {
 	require.EqualValues(t, storage.PickField1, istorage.FindPick1)
 }
 
+func TestWitnessScopes(t *testing.T) {
+	require.EqualValues(t, ledger.None, transaction.None)
+	require.EqualValues(t, ledger.CalledByEntry, transaction.CalledByEntry)
+	require.EqualValues(t, ledger.CustomContracts, transaction.CustomContracts)
+	require.EqualValues(t, ledger.CustomGroups, transaction.CustomGroups)
+	require.EqualValues(t, ledger.Global, transaction.Global)
+}
+
 type syscallTestCase struct {
 	method string
 	params []string
@@ -69,6 +79,7 @@ func TestSyscallExecution(t *testing.T) {
 		"iterator.Value":                   {interopnames.SystemIteratorValue, []string{"iterator.Iterator{}"}, false},
 		"runtime.BurnGas":                  {interopnames.SystemRuntimeBurnGas, []string{"1"}, true},
 		"runtime.CheckWitness":             {interopnames.SystemRuntimeCheckWitness, []string{b}, false},
+		"runtime.CurrentSigners":           {interopnames.SystemRuntimeCurrentSigners, nil, false},
 		"runtime.GasLeft":                  {interopnames.SystemRuntimeGasLeft, nil, false},
 		"runtime.GetCallingScriptHash":     {interopnames.SystemRuntimeGetCallingScriptHash, nil, false},
 		"runtime.GetEntryScriptHash":       {interopnames.SystemRuntimeGetEntryScriptHash, nil, false},
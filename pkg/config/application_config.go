@@ -32,4 +32,15 @@ type ApplicationConfiguration struct {
 	StateRoot         StateRoot               `yaml:"StateRoot"`
 	// ExtensiblePoolSize is the maximum amount of the extensible payloads from a single sender.
 	ExtensiblePoolSize int `yaml:"ExtensiblePoolSize"`
+	// BlockQueueSize is the maximum amount of blocks above the current height
+	// that can be stored in the block queue during synchronization.
+	BlockQueueSize int `yaml:"BlockQueueSize"`
+	// InstantBlocks makes consensus node of a single-validator network
+	// produce a block as soon as there are transactions in the mempool
+	// instead of waiting for SecondsPerBlock. It's intended for tests and
+	// private chains.
+	InstantBlocks bool `yaml:"InstantBlocks"`
+	// MemPoolPersistence allows to keep mempool transactions between node
+	// restarts.
+	MemPoolPersistence MemPoolPersistence `yaml:"MemPoolPersistence"`
 }
@@ -0,0 +1,13 @@
+package config
+
+// MemPoolPersistence contains settings for saving mempool transactions on
+// node shutdown and loading them back on start.
+type MemPoolPersistence struct {
+	// Path is a file to save transactions to, they're not saved if it's
+	// empty.
+	Path string `yaml:"Path"`
+	// KeyFile is a file with hex-encoded AES key (16, 24 or 32 bytes long)
+	// used to encrypt saved transactions, they're saved unencrypted if it's
+	// empty.
+	KeyFile string `yaml:"KeyFile"`
+}
@@ -0,0 +1,18 @@
+package config
+
+// PriceTable contains overrides for opcode, syscall and native contract method
+// prices. It's intended to be used for private networks and experiments, all
+// prices not specified here have their default (mainnet) values. Every price is
+// a coefficient which is multiplied by the base execution fee set via Policy
+// contract.
+type PriceTable struct {
+	// Opcodes maps opcode names (like "PUSHINT8") to their prices.
+	Opcodes map[string]int64 `yaml:"Opcodes"`
+	// Syscalls maps interop function names (like "System.Runtime.Log") to
+	// their prices.
+	Syscalls map[string]int64 `yaml:"Syscalls"`
+	// NativeMethods maps native contract methods specified as
+	// "ContractName.method" (like "PolicyContract.getFeePerByte") to their
+	// CPU prices. All overloads of the method get the same price.
+	NativeMethods map[string]int64 `yaml:"NativeMethods"`
+}
@@ -7,8 +7,23 @@ import (
 // ProtocolConfiguration represents the protocol config.
 type (
 	ProtocolConfiguration struct {
-		Magic       netmode.Magic `yaml:"Magic"`
-		MemPoolSize int           `yaml:"MemPoolSize"`
+		// AttributeFeeBase enables network fee escalation for transaction
+		// attributes, every subsequent attribute costs AttributeFeeBase
+		// more than the previous one. 0 (default) disables it, negative
+		// values and values that can overflow the fee are not allowed.
+		AttributeFeeBase int64         `yaml:"AttributeFeeBase"`
+		Magic            netmode.Magic `yaml:"Magic"`
+		MemPoolSize      int           `yaml:"MemPoolSize"`
+		// MemPoolNEP17Checks enables checks of native NEP17 token transfers
+		// made by transactions entering the mempool, those that would
+		// overdraw sender's balance given the transfers already pooled
+		// are rejected.
+		MemPoolNEP17Checks bool `yaml:"MemPoolNEP17Checks"`
+		// MemPoolSenderOrdering makes the mempool keep transactions of the
+		// same sender in the order they were added to it, so that the one
+		// added later is never included into block before the one added
+		// earlier.
+		MemPoolSenderOrdering bool `yaml:"MemPoolSenderOrdering"`
 		// P2PNotaryRequestPayloadPoolSize specifies the memory pool size for P2PNotaryRequestPayloads.
 		// It is valid only if P2PSigExtensions are enabled.
 		P2PNotaryRequestPayloadPoolSize int `yaml:"P2PNotaryRequestPayloadPoolSize"`
@@ -18,6 +33,12 @@ type (
 		KeepOnlyLatestState bool `yaml:"KeepOnlyLatestState"`
 		// RemoveUntraceableBlocks specifies if old blocks should be removed.
 		RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
+		// KeepBlocks enables pruning, when set only the latest KeepBlocks
+		// blocks are stored completely, older ones retain headers only (state
+		// is not affected). It takes precedence over RemoveUntraceableBlocks.
+		// It can't be lower than MaxTraceableBlocks, transactions from
+		// traceable blocks must be accessible to contracts.
+		KeepBlocks uint32 `yaml:"KeepBlocks"`
 		// MaxBlockSize is the maximum block size in bytes.
 		MaxBlockSize uint32 `yaml:"MaxBlockSize"`
 		// MaxBlockSystemFee is the maximum overall system fee per block.
@@ -26,8 +47,22 @@ type (
 		MaxTraceableBlocks uint32 `yaml:"MaxTraceableBlocks"`
 		// MaxTransactionsPerBlock is the maximum amount of transactions per block.
 		MaxTransactionsPerBlock uint16 `yaml:"MaxTransactionsPerBlock"`
+		// MaxCallDepth is the maximum depth of nested contract calls
+		// (System.Contract.Call and CALLT) in a single execution.
+		MaxCallDepth int `yaml:"MaxCallDepth"`
+		// MaxNotifications is the maximum number of notifications a single
+		// transaction execution can emit (including native contract ones),
+		// OnPersist and PostPersist executions are not limited. 0 (default)
+		// means no limit.
+		MaxNotifications int `yaml:"MaxNotifications"`
+		// MaxPolicyChangePercent limits single-step changes of native Policy
+		// contract values (in percents of the current value), committee can
+		// still override it with `force` flag. 0 means no limit.
+		MaxPolicyChangePercent uint32 `yaml:"MaxPolicyChangePercent"`
 		// NativeUpdateHistories is the list of histories of native contracts updates.
 		NativeUpdateHistories map[string][]uint32 `yaml:"NativeActivations"`
+		// PriceTable allows to override default opcode, syscall and native method prices.
+		PriceTable PriceTable `yaml:"PriceTable"`
 		// P2PSigExtensions enables additional signature-related logic.
 		P2PSigExtensions bool `yaml:"P2PSigExtensions"`
 		// ReservedAttributes allows to have reserved attributes range for experimental or private purposes.
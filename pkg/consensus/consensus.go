@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/dbft/block"
 	"github.com/nspcc-dev/dbft/crypto"
 	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/dbft/timer"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -55,6 +56,25 @@ type Service interface {
 	OnPayload(p *npayload.Extensible)
 	// OnTransaction is a callback to notify Service about new received transaction.
 	OnTransaction(tx *transaction.Transaction)
+	// GetState returns the state of the current consensus round.
+	GetState() State
+	// ForceBlock makes the node propose a new block with the current mempool
+	// contents right away. It only works in single-validator networks.
+	ForceBlock() error
+}
+
+// State represents the state of the consensus round.
+type State struct {
+	// Height is the index of the block being agreed on.
+	Height uint32
+	// View is the current view number.
+	View byte
+	// Primary is the index of the primary (speaker) node of the view.
+	Primary uint
+	// NextBlockTime is the expected time of the next block. It's based on
+	// the previous block timestamp for view 0 and on the view change time
+	// for other views, so it's just an estimation.
+	NextBlockTime time.Time
 }
 
 type service struct {
@@ -68,6 +88,8 @@ type service struct {
 	// everything in single thread.
 	messages     chan Payload
 	transactions chan *transaction.Transaction
+	// forceBlock is used to request immediate block proposal.
+	forceBlock chan struct{}
 	// blockEvents is used to pass a new block event to the consensus
 	// process.
 	blockEvents  chan *coreb.Block
@@ -83,6 +105,9 @@ type service struct {
 	// before block is accepted, so in case of change view it will contain
 	// updated value.
 	lastTimestamp uint64
+	// state contains the State of the current round, it's updated by the
+	// event loop after every event processed.
+	state atomic.Value
 }
 
 // Config is a configuration for consensus services.
@@ -101,6 +126,10 @@ type Config struct {
 	RequestTx func(h ...util.Uint256)
 	// TimePerBlock minimal time that should pass before next block is accepted.
 	TimePerBlock time.Duration
+	// InstantBlocks makes the node propose a new block as soon as there are
+	// transactions in the mempool without waiting for TimePerBlock. It can
+	// only be used in single-validator networks.
+	InstantBlocks bool
 	// Wallet is a local-node wallet configuration.
 	Wallet *config.Wallet
 }
@@ -114,6 +143,9 @@ func NewService(cfg Config) (Service, error) {
 	if cfg.Logger == nil {
 		return nil, errors.New("empty logger")
 	}
+	if cfg.InstantBlocks && cfg.ProtocolConfiguration.ValidatorsCount != 1 {
+		return nil, fmt.Errorf("InstantBlocks require single validator, got %d", cfg.ProtocolConfiguration.ValidatorsCount)
+	}
 
 	srv := &service{
 		Config: cfg,
@@ -123,11 +155,13 @@ func NewService(cfg Config) (Service, error) {
 		messages: make(chan Payload, 100),
 
 		transactions: make(chan *transaction.Transaction, 100),
+		forceBlock:   make(chan struct{}, 1),
 		blockEvents:  make(chan *coreb.Block, 1),
 		started:      atomic.NewBool(false),
 		quit:         make(chan struct{}),
 		finished:     make(chan struct{}),
 	}
+	srv.state.Store(State{})
 
 	if cfg.Wallet == nil {
 		return srv, nil
@@ -246,6 +280,7 @@ func (s *service) Start() {
 	if s.started.CAS(false, true) {
 		s.log.Info("starting consensus service")
 		s.dbft.Start()
+		s.updateState()
 		s.Chain.SubscribeForBlocks(s.blockEvents)
 		go s.eventLoop()
 	}
@@ -300,6 +335,8 @@ events:
 			s.dbft.OnReceive(&msg)
 		case tx := <-s.transactions:
 			s.dbft.OnTransaction(tx)
+		case <-s.forceBlock:
+			s.proposeNow()
 		case b := <-s.blockEvents:
 			s.handleChainBlock(b)
 		}
@@ -309,10 +346,112 @@ events:
 			s.handleChainBlock(b)
 		default:
 		}
+		s.tryInstantBlock()
+		s.updateState()
 	}
 	close(s.finished)
 }
 
+// tryInstantBlock makes the node propose a block right away if InstantBlocks
+// mode is enabled, the node is a primary that hasn't sent a request yet and
+// there are transactions to include. It must be called from the event loop.
+func (s *service) tryInstantBlock() {
+	if s.InstantBlocks && s.Chain.GetMemPool().Count() != 0 {
+		s.proposeNow()
+	}
+}
+
+// proposeNow fires consensus timer immediately if the node is a primary that
+// hasn't sent a request yet. It must be called from the event loop.
+func (s *service) proposeNow() {
+	if !s.dbft.IsPrimary() || s.dbft.RequestSentOrReceived() {
+		return
+	}
+	s.dbft.Timer.Reset(timer.HV{Height: s.dbft.BlockIndex, View: s.dbft.ViewNumber}, 0)
+}
+
+// ForceBlock implements Service interface.
+func (s *service) ForceBlock() error {
+	if s.dbft == nil || !s.started.Load() {
+		return errors.New("consensus service is not running")
+	}
+	if s.ProtocolConfiguration.ValidatorsCount != 1 {
+		return errors.New("block can only be forced in single-validator networks")
+	}
+	select {
+	case s.forceBlock <- struct{}{}:
+	default: // Already requested.
+	}
+	return nil
+}
+
+// GetState implements Service interface.
+func (s *service) GetState() State {
+	return s.state.Load().(State)
+}
+
+// updateState saves the state of the current round and logs view changes, it
+// must be called from the event loop.
+func (s *service) updateState() {
+	st := State{
+		Height:  s.dbft.BlockIndex,
+		View:    s.dbft.ViewNumber,
+		Primary: s.dbft.PrimaryIndex,
+	}
+	prev := s.state.Load().(State)
+	if st.Height != prev.Height || st.View != prev.View {
+		st.NextBlockTime = s.nextBlockTime(st.View)
+	} else {
+		st.NextBlockTime = prev.NextBlockTime
+	}
+	s.state.Store(st)
+	if st.Height != prev.Height || st.View <= prev.View {
+		return
+	}
+	viewChanges.Inc()
+	s.log.Info("view changed",
+		zap.Uint32("height", st.Height),
+		zap.Uint("from", uint(prev.View)),
+		zap.Uint("to", uint(st.View)),
+		zap.Uint("primary", st.Primary),
+		zap.Stringer("reason", s.viewChangeReason(st.View)))
+}
+
+// nextBlockTime returns the expected time of the next block for the given view
+// of the current round.
+func (s *service) nextBlockTime(view byte) time.Time {
+	if view == 0 {
+		h, err := s.Chain.GetHeader(s.Chain.CurrentBlockHash())
+		if err == nil {
+			return time.Unix(0, int64(h.Timestamp*nsInMs)).Add(s.TimePerBlock)
+		}
+	}
+	return time.Now().Add(s.TimePerBlock)
+}
+
+// viewChangeReason returns the most common reason of ChangeView messages
+// that led to the given view.
+func (s *service) viewChangeReason(view byte) payload.ChangeViewReason {
+	var (
+		reason payload.ChangeViewReason = payload.CVUnknown
+		counts                          = make(map[payload.ChangeViewReason]int)
+	)
+	for _, p := range s.dbft.LastChangeViewPayloads {
+		if p == nil {
+			continue
+		}
+		cv := p.GetChangeView()
+		if cv.NewViewNumber() != view {
+			continue
+		}
+		counts[cv.Reason()]++
+		if counts[cv.Reason()] > counts[reason] {
+			reason = cv.Reason()
+		}
+	}
+	return reason
+}
+
 func (s *service) handleChainBlock(b *coreb.Block) {
 	// We can get our own block here, so check for index.
 	if b.Index >= s.dbft.BlockIndex {
@@ -28,6 +28,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 )
@@ -219,6 +220,131 @@ func TestService_GetVerified(t *testing.T) {
 	})
 }
 
+func TestService_ViewChange(t *testing.T) {
+	srv := newTestService(t)
+	srv.dbft.Start()
+	srv.updateState()
+	genesis, err := srv.Chain.GetHeader(srv.Chain.CurrentBlockHash())
+	require.NoError(t, err)
+	require.Equal(t, State{
+		Height:        1,
+		View:          0,
+		Primary:       srv.dbft.PrimaryIndex,
+		NextBlockTime: time.Unix(0, int64(genesis.Timestamp)*nsInMs).Add(srv.TimePerBlock),
+	}, srv.GetState())
+
+	before := testutil.ToFloat64(viewChanges)
+	for i := 0; i < 4; i++ {
+		if i == srv.dbft.MyIndex {
+			continue
+		}
+		p := new(Payload)
+		p.SetType(payload.ChangeViewType)
+		p.SetPayload(&changeView{
+			newViewNumber: 1,
+			timestamp:     uint64(time.Now().UnixNano() / nsInMs),
+			reason:        payload.CVTxNotFound,
+		})
+		p.SetHeight(1)
+		p.SetValidatorIndex(uint16(i))
+
+		priv, _ := getTestValidator(i)
+		require.NoError(t, p.Sign(priv))
+
+		// Skip srv.OnPayload, because the service is not really started.
+		srv.dbft.OnReceive(p)
+	}
+	srv.updateState()
+
+	st := srv.GetState()
+	require.Equal(t, uint32(1), st.Height)
+	require.Equal(t, byte(1), st.View)
+	require.Equal(t, srv.dbft.PrimaryIndex, st.Primary)
+	require.True(t, st.NextBlockTime.After(time.Now()))
+	require.Equal(t, before+1, testutil.ToFloat64(viewChanges))
+	require.Equal(t, payload.CVTxNotFound, srv.viewChangeReason(1))
+
+	// No changes, no increments.
+	srv.updateState()
+	require.Equal(t, before+1, testutil.ToFloat64(viewChanges))
+}
+
+func newSingleTestConfig(bc *core.Blockchain, t *testing.T) Config {
+	return Config{
+		Logger:                zaptest.NewLogger(t),
+		Broadcast:             func(*npayload.Extensible) {},
+		Chain:                 bc,
+		ProtocolConfiguration: bc.GetConfig(),
+		RequestTx:             func(...util.Uint256) {},
+		TimePerBlock:          time.Hour,
+		Wallet: &config.Wallet{
+			Path:     "./testdata/wallet1.json",
+			Password: "one",
+		},
+	}
+}
+
+func TestService_InstantBlocks(t *testing.T) {
+	bc := newSingleTestChain(t)
+	cfg := newSingleTestConfig(bc, t)
+	cfg.InstantBlocks = true
+	t.Run("multiple validators", func(t *testing.T) {
+		cfg := cfg
+		cfg.ProtocolConfiguration.ValidatorsCount = 4
+		_, err := NewService(cfg)
+		require.Error(t, err)
+	})
+
+	s, err := NewService(cfg)
+	require.NoError(t, err)
+	srv := s.(*service)
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+
+	priv := testchain.PrivateKeyByID(0)
+	require.Equal(t, bc.GetStandByValidators()[0], priv.PublicKey())
+	rawScript, err := smartcontract.CreateMultiSigRedeemScript(1, keys.PublicKeys{priv.PublicKey()})
+	require.NoError(t, err)
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 100000)
+	tx.ValidUntilBlock = bc.BlockHeight() + 10
+	tx.Signers = []transaction.Signer{{Account: hash.Hash160(rawScript)}}
+	netFee, sizeDelta := fee.Calculate(bc.GetPolicer().GetBaseExecFee(), rawScript)
+	tx.NetworkFee = netFee + int64(io.GetVarSize(tx)+sizeDelta)*bc.FeePerByte()
+	tx.Scripts = []transaction.Witness{{
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, priv.SignHashable(uint32(testchain.Network()), tx)...),
+		VerificationScript: rawScript,
+	}}
+	require.NoError(t, bc.PoolTx(tx))
+	srv.OnTransaction(tx)
+
+	require.Eventually(t, func() bool { return bc.BlockHeight() == 1 }, 5*time.Second, 10*time.Millisecond)
+	b, err := bc.GetBlock(bc.GetHeaderHash(1))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(b.Transactions))
+	require.Equal(t, tx.Hash(), b.Transactions[0].Hash())
+}
+
+func TestService_ForceBlock(t *testing.T) {
+	bc := newSingleTestChain(t)
+	s, err := NewService(newSingleTestConfig(bc, t))
+	require.NoError(t, err)
+	srv := s.(*service)
+	require.Error(t, srv.ForceBlock())
+
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.NoError(t, srv.ForceBlock())
+	require.Eventually(t, func() bool { return bc.BlockHeight() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("multiple validators", func(t *testing.T) {
+		srv := newTestService(t)
+		srv.Start()
+		t.Cleanup(srv.Shutdown)
+		require.Error(t, srv.ForceBlock())
+	})
+}
+
 func TestService_ValidatePayload(t *testing.T) {
 	srv := newTestService(t)
 	priv, _ := getTestValidator(1)
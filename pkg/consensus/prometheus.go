@@ -0,0 +1,23 @@
+package consensus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics for monitoring service.
+var (
+	// viewChanges prometheus metric.
+	viewChanges = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of consensus view changes",
+			Name:      "consensus_view_changes",
+			Namespace: "neogo",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		viewChanges,
+	)
+}
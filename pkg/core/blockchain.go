@@ -2,11 +2,13 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer/services"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
@@ -35,6 +38,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"go.uber.org/zap"
 )
@@ -42,12 +46,13 @@ import (
 // Tuning parameters.
 const (
 	headerBatchCount = 2000
-	version          = "0.1.0"
+	version          = "0.1.1"
 
 	defaultMemPoolSize                     = 50000
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
 	defaultMaxBlockSize                    = 262144
 	defaultMaxBlockSystemFee               = 900000000000
+	defaultMaxCallDepth                    = vm.MaxInvocationStackSize
 	defaultMaxTraceableBlocks              = 2102400 // 1 year of 15s blocks
 	defaultMaxTransactionsPerBlock         = 512
 	verificationGasLimit                   = 100000000 // 1 GAS
@@ -71,6 +76,12 @@ var (
 	// conflicts with other transaction in the chain or pool according to
 	// Conflicts attribute.
 	ErrHasConflicts = errors.New("has conflicts")
+	// ErrBlockPruned is returned when trying to get a block which data was
+	// removed because of pruning (only header is available for it).
+	ErrBlockPruned = errors.New("block is pruned")
+	// ErrStateUnavailable is returned when trying to use historical state
+	// which is not kept by the node.
+	ErrStateUnavailable = errors.New("historical state is not available")
 )
 var (
 	persistInterval = 1 * time.Second
@@ -134,6 +145,11 @@ type Blockchain struct {
 
 	contracts native.Contracts
 
+	// interops is a list of syscalls with prices possibly overridden by config.
+	interops []interop.Function
+	// opcodePrices contains opcode price overrides from config.
+	opcodePrices fee.Table
+
 	extensible atomic.Value
 
 	// defaultBlockWitness stores transaction.Witness with m out of n multisig,
@@ -185,11 +201,22 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		cfg.MaxTraceableBlocks = defaultMaxTraceableBlocks
 		log.Info("MaxTraceableBlocks is not set or wrong, using default value", zap.Uint32("MaxTraceableBlocks", cfg.MaxTraceableBlocks))
 	}
+	if cfg.KeepBlocks != 0 && cfg.KeepBlocks < cfg.MaxTraceableBlocks {
+		return nil, fmt.Errorf("KeepBlocks (%d) is lower than MaxTraceableBlocks (%d)", cfg.KeepBlocks, cfg.MaxTraceableBlocks)
+	}
 	if cfg.MaxTransactionsPerBlock == 0 {
 		cfg.MaxTransactionsPerBlock = defaultMaxTransactionsPerBlock
 		log.Info("MaxTransactionsPerBlock is not set or wrong, using default value",
 			zap.Uint16("MaxTransactionsPerBlock", cfg.MaxTransactionsPerBlock))
 	}
+	if cfg.MaxCallDepth <= 0 {
+		cfg.MaxCallDepth = defaultMaxCallDepth
+		log.Info("MaxCallDepth is not set or wrong, using default value",
+			zap.Int("MaxCallDepth", cfg.MaxCallDepth))
+	}
+	if cfg.AttributeFeeBase < 0 || cfg.AttributeFeeBase > transaction.MaxAttributesFeeBase {
+		return nil, fmt.Errorf("AttributeFeeBase should be in [0, %d] range", int64(transaction.MaxAttributesFeeBase))
+	}
 	committee, err := committeeFromConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -198,23 +225,36 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		cfg.NativeUpdateHistories = map[string][]uint32{}
 		log.Info("NativeActivations are not set, using default values")
 	}
+	var memPool *mempool.Pool
+	if cfg.MemPoolSenderOrdering {
+		memPool = mempool.NewWithSenderOrdering(cfg.MemPoolSize, 0, false)
+	} else {
+		memPool = mempool.New(cfg.MemPoolSize, 0, false)
+	}
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
 		stopCh:      make(chan struct{}),
 		runToExitCh: make(chan struct{}),
-		memPool:     mempool.New(cfg.MemPoolSize, 0, false),
+		memPool:     memPool,
 		sbCommittee: committee,
 		log:         log,
 		events:      make(chan bcEvent),
 		subCh:       make(chan interface{}),
 		unsubCh:     make(chan interface{}),
 
-		contracts: *native.NewContracts(cfg.P2PSigExtensions, cfg.NativeUpdateHistories),
+		contracts: *native.NewContracts(cfg.P2PSigExtensions, cfg.NativeUpdateHistories, cfg.MaxPolicyChangePercent),
+	}
+
+	if err := bc.applyPriceTable(cfg.PriceTable); err != nil {
+		return nil, fmt.Errorf("invalid price table: %w", err)
 	}
 
 	bc.stateRoot = stateroot.NewModule(bc, bc.log, bc.dao.Store)
 	bc.contracts.Designate.StateRootService = bc.stateRoot
+	if cfg.MemPoolNEP17Checks {
+		bc.memPool.SetTransferChecks(bc.getNativeNEP17Balance)
+	}
 
 	if err := bc.init(); err != nil {
 		return nil, err
@@ -507,6 +547,12 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 		if err != nil {
 			return err
 		}
+	} else if bc.config.VerifyBlocks {
+		// Header is already known, but it doesn't mean that this block
+		// is signed by the same validators.
+		if err := bc.verifyBlockWitness(block); err != nil {
+			return err
+		}
 	}
 	if bc.config.VerifyBlocks {
 		merkle := block.ComputeMerkleRoot()
@@ -535,6 +581,42 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 	return bc.storeBlock(block, mp)
 }
 
+// VerifyBlock performs all the checks done for the next block to be added
+// (header consistency and witness, Merkle root and transactions validity)
+// against the current chain state without adding the block, so nothing is
+// persisted. It returns the first failure found. Transactions are always
+// verified irrespective of VerifyBlocks and VerifyTransactions settings.
+func (bc *Blockchain) VerifyBlock(b *block.Block) error {
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+
+	expectedHeight := bc.BlockHeight() + 1
+	if expectedHeight != b.Index {
+		return fmt.Errorf("expected %d, got %d: %w", expectedHeight, b.Index, ErrInvalidBlockIndex)
+	}
+	if bc.config.StateRootInHeader != b.StateRootEnabled {
+		return fmt.Errorf("%w: %v != %v",
+			ErrHdrStateRootSetting, bc.config.StateRootInHeader, b.StateRootEnabled)
+	}
+	prevHeader, err := bc.GetHeader(b.PrevHash)
+	if err != nil {
+		return fmt.Errorf("previous header was not found: %w", err)
+	}
+	if err := bc.verifyHeader(&b.Header, prevHeader); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+	if merkle := b.ComputeMerkleRoot(); !b.MerkleRoot.Equals(merkle) {
+		return errors.New("invalid block: MerkleRoot mismatch")
+	}
+	mp := mempool.New(len(b.Transactions), 0, false)
+	for i, tx := range b.Transactions {
+		if err := bc.verifyAndPoolTx(tx, mp, bc); err != nil {
+			return fmt.Errorf("transaction #%d %s failed to verify: %w", i, tx.Hash().StringLE(), err)
+		}
+	}
+	return nil
+}
+
 // AddHeaders processes the given headers and add them to the
 // HeaderHashList. It expects headers to be sorted by index.
 func (bc *Blockchain) AddHeaders(headers ...*block.Header) error {
@@ -696,6 +778,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				Stack:          v.Estack().ToArray(),
 				Events:         systemInterop.Notifications,
 				FaultException: faultException,
+				Invocations:    invokedContracts(v, systemInterop.Notifications, hash.Hash160(tx.Script)),
 			},
 		}
 		appExecResults = append(appExecResults, aer)
@@ -742,17 +825,15 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	if bc.config.SaveStorageBatch {
 		bc.lastBatch = cache.DAO.GetBatch()
 	}
-	if bc.config.RemoveUntraceableBlocks {
-		if block.Index > bc.config.MaxTraceableBlocks {
-			index := block.Index - bc.config.MaxTraceableBlocks // is at least 1
-			err := cache.DeleteBlock(bc.headerHashes[index], writeBuf)
-			if err != nil {
-				bc.log.Warn("error while removing old block",
-					zap.Uint32("index", index),
-					zap.Error(err))
-			}
-			writeBuf.Reset()
+	if keep := bc.keepBlocks(); keep != 0 && block.Index > keep {
+		index := block.Index - keep // is at least 1
+		err := cache.DeleteBlock(bc.headerHashes[index], writeBuf)
+		if err != nil {
+			bc.log.Warn("error while removing old block",
+				zap.Uint32("index", index),
+				zap.Error(err))
 		}
+		writeBuf.Reset()
 	}
 	// Every persist cycle we also compact our in-memory MPT. It's flushed
 	// already in AddMPTBatch, so collapsing it is safe.
@@ -773,6 +854,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	bc.stateRoot.UpdateCurrentLocal(mpt, sr)
 	bc.topBlock.Store(block)
 	atomic.StoreUint32(&bc.blockHeight, block.Index)
+	bc.memPool.RemoveExpired(block.Index + 1)
 	bc.memPool.RemoveStale(func(tx *transaction.Transaction) bool { return bc.IsTxStillRelevant(tx, txpool, false) }, bc)
 	for _, f := range bc.postBlock {
 		f(bc, txpool, block)
@@ -863,10 +945,36 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.C
 			GasConsumed: v.GasConsumed(),
 			Stack:       v.Estack().ToArray(),
 			Events:      systemInterop.Notifications,
+			Invocations: invokedContracts(v, systemInterop.Notifications, hash.Hash160(script)),
 		},
 	}, nil
 }
 
+// invokedContracts returns a sorted list of contracts invoked during the script
+// execution. It's derived from invocation counters tracked by the VM and from
+// notification senders, entry script hash is not included.
+func invokedContracts(v *vm.VM, events []state.NotificationEvent, entry util.Uint160) []util.Uint160 {
+	seen := make(map[util.Uint160]bool, len(v.Invocations))
+	for h := range v.Invocations {
+		seen[h] = true
+	}
+	for i := range events {
+		seen[events[i].ScriptHash] = true
+	}
+	delete(seen, entry)
+	if len(seen) == 0 {
+		return nil
+	}
+	res := make([]util.Uint160, 0, len(seen))
+	for h := range seen {
+		res = append(res, h)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Less(res[j])
+	})
+	return res
+}
+
 func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.Cached, b *block.Block, h util.Uint256) {
 	if note.Name != "Transfer" {
 		return
@@ -1035,6 +1143,20 @@ func (bc *Blockchain) GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint
 	return &neo.Balance, neo.LastUpdatedBlock
 }
 
+// getNativeNEP17Balance returns account balance in the native NEO or GAS
+// token, nil is returned for other tokens.
+func (bc *Blockchain) getNativeNEP17Balance(token, acc util.Uint160) *big.Int {
+	switch {
+	case token.Equals(bc.contracts.GAS.Hash):
+		return bc.GetUtilityTokenBalance(acc)
+	case token.Equals(bc.contracts.NEO.Hash):
+		balance, _ := bc.GetGoverningTokenBalance(acc)
+		return balance
+	default:
+		return nil
+	}
+}
+
 // GetNotaryBalance returns Notary deposit amount for the specified account.
 func (bc *Blockchain) GetNotaryBalance(acc util.Uint160) *big.Int {
 	return bc.contracts.Notary.BalanceOf(bc.dao, acc)
@@ -1120,6 +1242,12 @@ func (bc *Blockchain) GetStorageItems(id int32) (map[string]state.StorageItem, e
 	return bc.dao.GetStorageItems(id)
 }
 
+// GetStorageItemsSorted returns all storage items for a given contract id
+// sorted by their keys.
+func (bc *Blockchain) GetStorageItemsSorted(id int32) ([]state.StorageItemWithKey, error) {
+	return bc.dao.GetStorageItemsSorted(id)
+}
+
 // GetBlock returns a Block by the given hash.
 func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	topBlock := bc.topBlock.Load()
@@ -1135,6 +1263,9 @@ func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 		return nil, err
 	}
 	if !block.MerkleRoot.Equals(util.Uint256{}) && len(block.Transactions) == 0 {
+		if block.Index <= bc.BlockHeight() {
+			return nil, fmt.Errorf("%w: %d", ErrBlockPruned, block.Index)
+		}
 		return nil, errors.New("only header is found")
 	}
 	for _, tx := range block.Transactions {
@@ -1147,6 +1278,18 @@ func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	return block, nil
 }
 
+// keepBlocks returns the number of the latest blocks to store completely, 0
+// means that all blocks are stored.
+func (bc *Blockchain) keepBlocks() uint32 {
+	if bc.config.KeepBlocks != 0 {
+		return bc.config.KeepBlocks
+	}
+	if bc.config.RemoveUntraceableBlocks {
+		return bc.config.MaxTraceableBlocks
+	}
+	return 0
+}
+
 // GetHeader returns data block header identified with the given hash value.
 func (bc *Blockchain) GetHeader(hash util.Uint256) (*block.Header, error) {
 	topBlock := bc.topBlock.Load()
@@ -1234,6 +1377,82 @@ func (bc *Blockchain) GetContractState(hash util.Uint160) *state.Contract {
 	return contract
 }
 
+// ListContracts returns all deployed (non-native) contracts sorted by ID.
+func (bc *Blockchain) ListContracts() ([]*state.Contract, error) {
+	return bc.contracts.Management.ListContracts(bc.dao)
+}
+
+// TraceTransaction re-executes the transaction with the given hash against
+// the state it was executed with and returns its execution result along with
+// up to maxSteps last executed instructions. Contract storage state is taken
+// from MPT of the previous block with all preceding transactions of the same
+// block applied, so it requires old MPT nodes to be kept (KeepOnlyLatestState
+// disabled). Block's OnPersist changes (like fee burning) are not applied and
+// native contracts caches reflect the current state, so the result can differ
+// from the original one in some edge cases. Execution is aborted when the
+// given context is done.
+func (bc *Blockchain) TraceTransaction(ctx context.Context, h util.Uint256, maxSteps int) (*state.AppExecResult, []vm.TraceStep, error) {
+	_, height, err := bc.dao.GetTransaction(h)
+	if err != nil {
+		return nil, nil, err
+	}
+	if height == 0 {
+		return nil, nil, ErrStateUnavailable
+	}
+	d, err := bc.GetStateAt(height - 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := bc.GetBlock(bc.GetHeaderHash(int(height)))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, btx := range b.Transactions {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		ic := interop.NewContext(trigger.Application, bc, d, bc.contracts.Management.GetContractFromDAO,
+			bc.contracts.Contracts, b, btx, bc.log)
+		ic.Functions = bc.interops
+		ic.Prices = bc.opcodePrices
+		ic.Container = btx
+		v := ic.SpawnVM()
+		v.LoadScriptWithFlags(btx.Script, callflag.All)
+		v.SetPriceGetter(ic.GetPrice)
+		v.LoadToken = contract.LoadToken(ic)
+		v.GasLimit = btx.SystemFee
+		if !btx.Hash().Equals(h) {
+			if v.RunWithContext(ctx) == nil {
+				if _, err := ic.DAO.Persist(); err != nil {
+					return nil, nil, fmt.Errorf("failed to persist %s invocation results: %w", btx.Hash().StringLE(), err)
+				}
+			}
+			continue
+		}
+		v.EnableTrace(maxSteps)
+		var faultException string
+		if err := v.RunWithContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, err
+			}
+			faultException = err.Error()
+		}
+		return &state.AppExecResult{
+			Container: h,
+			Execution: state.Execution{
+				Trigger:        trigger.Application,
+				VMState:        v.State(),
+				GasConsumed:    v.GasConsumed(),
+				Stack:          v.Estack().ToArray(),
+				Events:         ic.Notifications,
+				FaultException: faultException,
+				Invocations:    invokedContracts(v, ic.Notifications, hash.Hash160(btx.Script)),
+			},
+		}, v.Trace(), nil
+	}
+	return nil, nil, fmt.Errorf("transaction %s is not found in block %d", h.StringLE(), height)
+}
+
 // GetContractScriptHash returns contract script hash by its ID.
 func (bc *Blockchain) GetContractScriptHash(id int32) (util.Uint160, error) {
 	return bc.dao.GetContractScriptHash(id)
@@ -1403,12 +1622,27 @@ func (bc *Blockchain) verifyHeader(currHeader, prevHeader *block.Header) error {
 	return bc.verifyHeaderWitnesses(currHeader, prevHeader)
 }
 
+// verifyBlockWitness checks block witness against the NextConsensus of the
+// previous block. It's used for blocks whose headers are already in the
+// header list.
+func (bc *Blockchain) verifyBlockWitness(b *block.Block) error {
+	prevHeader, err := bc.GetHeader(b.PrevHash)
+	if err != nil {
+		return fmt.Errorf("previous header was not found: %w", err)
+	}
+	if prevHeader.Index+1 != b.Index {
+		return ErrHdrIndexMismatch
+	}
+	return bc.verifyHeaderWitnesses(&b.Header, prevHeader)
+}
+
 // Various errors that could be returned upon verification.
 var (
 	ErrTxExpired         = errors.New("transaction has expired")
 	ErrInsufficientFunds = errors.New("insufficient funds")
 	ErrTxSmallNetworkFee = errors.New("too small network fee")
 	ErrTxTooBig          = errors.New("too big transaction")
+	ErrTxTooBigSysFee    = errors.New("too big system fee")
 	ErrMemPoolConflict   = errors.New("invalid transaction due to conflicts with the memory pool")
 	ErrInvalidScript     = errors.New("invalid script")
 	ErrInvalidAttribute  = errors.New("invalid attribute")
@@ -1438,7 +1672,10 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	if size > transaction.MaxTransactionSize {
 		return fmt.Errorf("%w: (%d > MaxTransactionSize %d)", ErrTxTooBig, size, transaction.MaxTransactionSize)
 	}
-	needNetworkFee := int64(size) * bc.FeePerByte()
+	if t.SystemFee > bc.config.MaxBlockSystemFee {
+		return fmt.Errorf("%w: (%d > MaxBlockSystemFee %d)", ErrTxTooBigSysFee, t.SystemFee, bc.config.MaxBlockSystemFee)
+	}
+	needNetworkFee := int64(size)*bc.FeePerByte() + transaction.AttributesFee(bc.config.AttributeFeeBase, t.Attributes)
 	if bc.P2PSigExtensionsEnabled() {
 		attrs := t.GetAttributes(transaction.NotaryAssistedT)
 		if len(attrs) != 0 {
@@ -1675,6 +1912,11 @@ func (bc *Blockchain) GetEnrollments() ([]state.Validator, error) {
 	return bc.contracts.NEO.GetCandidates(bc.dao)
 }
 
+// GetVoters returns all NEO holders that have voted for some candidate.
+func (bc *Blockchain) GetVoters() ([]state.Voter, error) {
+	return bc.contracts.NEO.GetVoters(bc.dao)
+}
+
 // GetTestVM returns a VM and a Store setup for a test run of some sort of code.
 func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM {
 	d := bc.dao.GetWrapped().(*dao.Simple)
@@ -1791,7 +2033,7 @@ func (bc *Blockchain) verifyHashAgainstScript(hash util.Uint160, witness *transa
 // Golang implementation of VerifyWitnesses method in C# (https://github.com/neo-project/neo/blob/master/neo/SmartContract/Helper.cs#L87).
 func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block.Block, isPartialTx bool) error {
 	interopCtx := bc.newInteropContext(trigger.Verification, bc.dao, block, t)
-	gasLimit := t.NetworkFee - int64(t.Size())*bc.FeePerByte()
+	gasLimit := t.NetworkFee - int64(t.Size())*bc.FeePerByte() - transaction.AttributesFee(bc.config.AttributeFeeBase, t.Attributes)
 	if bc.P2PSigExtensionsEnabled() {
 		attrs := t.GetAttributes(transaction.NotaryAssistedT)
 		if len(attrs) != 0 {
@@ -1846,7 +2088,8 @@ func hashAndIndexToBytes(h util.Uint256, index uint32) []byte {
 
 func (bc *Blockchain) newInteropContext(trigger trigger.Type, d dao.DAO, block *block.Block, tx *transaction.Transaction) *interop.Context {
 	ic := interop.NewContext(trigger, bc, d, bc.contracts.Management.GetContract, bc.contracts.Contracts, block, tx, bc.log)
-	ic.Functions = systemInterops
+	ic.Functions = bc.interops
+	ic.Prices = bc.opcodePrices
 	switch {
 	case tx != nil:
 		ic.Container = tx
@@ -1856,6 +2099,70 @@ func (bc *Blockchain) newInteropContext(trigger trigger.Type, d dao.DAO, block *
 	return ic
 }
 
+// applyPriceTable initializes syscall list and opcode prices and overrides
+// native method prices using the given price table.
+func (bc *Blockchain) applyPriceTable(pt config.PriceTable) error {
+	bc.interops = systemInterops
+	if len(pt.Syscalls) != 0 {
+		bc.interops = make([]interop.Function, len(systemInterops))
+		copy(bc.interops, systemInterops)
+		for name, price := range pt.Syscalls {
+			if price < 0 {
+				return fmt.Errorf("negative price for syscall %s", name)
+			}
+			var found bool
+			for i := range bc.interops {
+				if bc.interops[i].Name == name {
+					bc.interops[i].Price = price
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("unknown syscall %s", name)
+			}
+		}
+	}
+	for name, price := range pt.Opcodes {
+		op, err := opcode.FromString(name)
+		if err != nil {
+			return err
+		}
+		if price < 0 {
+			return fmt.Errorf("negative price for opcode %s", name)
+		}
+		if bc.opcodePrices == nil {
+			bc.opcodePrices = make(fee.Table, len(pt.Opcodes))
+		}
+		bc.opcodePrices[op] = price
+	}
+	for name, price := range pt.NativeMethods {
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return fmt.Errorf("invalid native method name %s", name)
+		}
+		if price < 0 {
+			return fmt.Errorf("negative price for native method %s", name)
+		}
+		c := bc.contracts.ByName(name[:i])
+		if c == nil {
+			return fmt.Errorf("unknown native contract %s", name[:i])
+		}
+		md := c.Metadata()
+		var found bool
+		for j := range md.Methods {
+			if md.Methods[j].MD.Name == name[i+1:] {
+				md.Methods[j].CPUFee = price
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown native method %s", name)
+		}
+	}
+	return nil
+}
+
 // P2PSigExtensionsEnabled defines whether P2P signature extensions are enabled.
 func (bc *Blockchain) P2PSigExtensionsEnabled() bool {
 	return bc.config.P2PSigExtensions
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -21,6 +24,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativeprices"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -41,6 +45,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestVerifyHeader(t *testing.T) {
@@ -130,6 +135,106 @@ func TestAddBlock(t *testing.T) {
 	assert.Equal(t, lastBlock.Hash(), bc.CurrentHeaderHash())
 }
 
+func TestAddBlockWitness(t *testing.T) {
+	bc := newTestChain(t)
+	prev := bc.topBlock.Load().(*block.Block)
+
+	// signOther signs the block by some other validator.
+	signOther := func(t *testing.T, b *block.Block) {
+		pk, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		b.Script = transaction.NewSignatureWitness(pk.SignHashable(uint32(testchain.Network()), b), pk.PublicKey())
+	}
+	// signWrong uses proper verification script with wrong signatures.
+	signWrong := func(t *testing.T, b *block.Block) {
+		b.Script.InvocationScript = testchain.Sign(newBlock(bc.config, 2, prev.Hash()))
+	}
+
+	t.Run("unknown header", func(t *testing.T) {
+		b := newBlock(bc.config, 1, prev.Hash())
+		signOther(t, b)
+		err := bc.AddBlock(b)
+		require.True(t, errors.Is(err, ErrWitnessHashMismatch), err)
+		require.Equal(t, uint32(0), bc.HeaderHeight())
+	})
+	t.Run("known header", func(t *testing.T) {
+		b := newBlock(bc.config, 1, prev.Hash())
+		require.NoError(t, bc.AddHeaders(&b.Header))
+
+		bad := *b
+		signOther(t, &bad)
+		require.Equal(t, b.Hash(), bad.Hash())
+		err := bc.AddBlock(&bad)
+		require.True(t, errors.Is(err, ErrWitnessHashMismatch), err)
+
+		bad = *b
+		signWrong(t, &bad)
+		err = bc.AddBlock(&bad)
+		require.True(t, errors.Is(err, ErrVerificationFailed), err)
+		require.Equal(t, uint32(0), bc.BlockHeight())
+
+		require.NoError(t, bc.AddBlock(b))
+		require.Equal(t, uint32(1), bc.BlockHeight())
+	})
+}
+
+func TestVerifyBlock(t *testing.T) {
+	bc := newTestChain(t)
+
+	newTx := func(t *testing.T) *transaction.Transaction {
+		tx := newNEP17Transfer(bc.contracts.NEO.Hash, neoOwner, util.Uint160{1, 2, 3}, 1)
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		addSigners(neoOwner, tx)
+		require.NoError(t, testchain.SignTx(bc, tx))
+		return tx
+	}
+	// resign makes a copy of the block, changes it and signs again.
+	resign := func(t *testing.T, b *block.Block, f func(b *block.Block)) *block.Block {
+		data, err := testserdes.EncodeBinary(b)
+		require.NoError(t, err)
+		nb := block.New(false)
+		require.NoError(t, testserdes.DecodeBinary(data, nb))
+		f(nb)
+		nb.Script.InvocationScript = testchain.Sign(nb)
+		return nb
+	}
+
+	tx := newTx(t)
+	b := bc.newBlock(tx)
+	height := bc.BlockHeight()
+	require.NoError(t, bc.VerifyBlock(b))
+	require.Equal(t, height, bc.BlockHeight())
+
+	t.Run("bad index", func(t *testing.T) {
+		bad := resign(t, b, func(b *block.Block) { b.Index++ })
+		require.True(t, errors.Is(bc.VerifyBlock(bad), ErrInvalidBlockIndex))
+	})
+	t.Run("bad Merkle root", func(t *testing.T) {
+		bad := resign(t, b, func(b *block.Block) { b.MerkleRoot = util.Uint256{1, 2, 3} })
+		require.Error(t, bc.VerifyBlock(bad))
+	})
+	t.Run("bad block witness", func(t *testing.T) {
+		bad := resign(t, b, func(*block.Block) {})
+		bad.Script.InvocationScript = testchain.Sign(bc.newBlock())
+		require.True(t, errors.Is(bc.VerifyBlock(bad), ErrVerificationFailed))
+	})
+	t.Run("bad transaction witness", func(t *testing.T) {
+		tx := newTx(t)
+		tx.Scripts[0].InvocationScript = newTx(t).Scripts[0].InvocationScript
+		bad := bc.newBlock(tx)
+		require.True(t, errors.Is(bc.VerifyBlock(bad), ErrVerificationFailed))
+	})
+	t.Run("expired transaction", func(t *testing.T) {
+		tx := newTx(t)
+		tx.ValidUntilBlock = bc.BlockHeight()
+		require.NoError(t, testchain.SignTx(bc, tx))
+		require.True(t, errors.Is(bc.VerifyBlock(bc.newBlock(tx)), ErrTxExpired))
+	})
+
+	require.NoError(t, bc.AddBlock(b))
+	require.Error(t, bc.VerifyBlock(b))
+}
+
 func TestAddBlockStateRoot(t *testing.T) {
 	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
 		c.ProtocolConfiguration.StateRootInHeader = true
@@ -1077,6 +1182,143 @@ func TestVerifyTx(t *testing.T) {
 	})
 }
 
+func TestPoolTx_NEP17Checks(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.MemPoolNEP17Checks = true
+	})
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	h := acc.Contract.ScriptHash()
+	transferTokenFromMultisigAccountCheckOK(t, bc, h, bc.contracts.NEO.Hash, 100)
+	transferTokenFromMultisigAccountCheckOK(t, bc, h, bc.contracts.GAS.Hash, 100_0000_0000)
+
+	newTx := func(nonce uint32, token util.Uint160, amount int64) *transaction.Transaction {
+		tx := newNEP17Transfer(token, h, util.Uint160{1, 2, 3}, amount)
+		tx.Nonce = nonce
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		signTxWithAccounts(bc, tx, acc)
+		return tx
+	}
+	tx1 := newTx(1, bc.contracts.NEO.Hash, 60)
+	require.NoError(t, bc.PoolTx(tx1))
+
+	err = bc.PoolTx(newTx(2, bc.contracts.NEO.Hash, 101))
+	require.True(t, errors.Is(err, ErrInsufficientFunds), err)
+	err = bc.PoolTx(newTx(3, bc.contracts.NEO.Hash, 41))
+	require.True(t, errors.Is(err, ErrMemPoolConflict), err)
+	err = bc.PoolTx(newTx(4, bc.contracts.GAS.Hash, 200_0000_0000))
+	require.True(t, errors.Is(err, ErrInsufficientFunds), err)
+	// Other tokens are not checked.
+	require.NoError(t, bc.PoolTx(newTx(5, util.Uint160{4, 5, 6}, 1000)))
+
+	require.NoError(t, bc.AddBlock(bc.newBlock(tx1)))
+	err = bc.PoolTx(newTx(6, bc.contracts.NEO.Hash, 41))
+	require.True(t, errors.Is(err, ErrInsufficientFunds), err)
+	require.NoError(t, bc.PoolTx(newTx(7, bc.contracts.NEO.Hash, 40)))
+}
+
+func TestPoolTx_SenderOrdering(t *testing.T) {
+	check := func(t *testing.T, ordering bool) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+			c.ProtocolConfiguration.MemPoolSenderOrdering = ordering
+		})
+		acc, err := wallet.NewAccount()
+		require.NoError(t, err)
+		transferTokenFromMultisigAccountCheckOK(t, bc, acc.Contract.ScriptHash(), bc.contracts.GAS.Hash, 100_0000_0000)
+
+		newTx := func(nonce uint32, extraFee int64) *transaction.Transaction {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			tx.Nonce = nonce
+			tx.ValidUntilBlock = bc.BlockHeight() + 1
+			tx.NetworkFee = extraFee
+			signTxWithAccounts(bc, tx, acc)
+			return tx
+		}
+		tx1 := newTx(1, 0)
+		tx2 := newTx(2, 1000)
+		require.NoError(t, bc.PoolTx(tx1))
+		require.NoError(t, bc.PoolTx(tx2))
+
+		expected := []*transaction.Transaction{tx2, tx1}
+		if ordering {
+			expected = []*transaction.Transaction{tx1, tx2}
+		}
+		require.Equal(t, expected, bc.GetMemPool().GetVerifiedTransactions())
+	}
+	t.Run("disabled", func(t *testing.T) { check(t, false) })
+	t.Run("enabled", func(t *testing.T) { check(t, true) })
+}
+
+func TestVerifyTx_AttributesFee(t *testing.T) {
+	const base = 1_000_000
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.ReservedAttributes = true
+		c.ProtocolConfiguration.AttributeFeeBase = base
+	})
+
+	newTx := func(n int) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		tx.Signers = []transaction.Signer{{Account: neoOwner}}
+		for i := 0; i < n; i++ {
+			tx.Attributes = append(tx.Attributes, transaction.Attribute{
+				Type:  transaction.ReservedLowerBound + 3 + transaction.AttrType(i),
+				Value: &transaction.Reserved{Value: []byte{1}},
+			})
+		}
+		return tx
+	}
+	for _, n := range []int{1, 2, 5} {
+		tx := newTx(n)
+		require.NoError(t, testchain.SignTx(bc, tx))
+		require.NoError(t, bc.VerifyTx(tx))
+
+		tx = newTx(n)
+		require.NoError(t, testchain.SignTx(bc, tx))
+		tx.NetworkFee -= transaction.AttributesFee(base, tx.Attributes)
+		tx.Scripts[0].InvocationScript = testchain.Sign(tx)
+		require.Error(t, bc.VerifyTx(tx))
+
+		tx = newTx(n)
+		require.NoError(t, testchain.SignTx(bc, tx))
+		tx.NetworkFee = int64(io.GetVarSize(tx))*bc.FeePerByte() + transaction.AttributesFee(base, tx.Attributes) - 1
+		tx.Scripts[0].InvocationScript = testchain.Sign(tx)
+		err := bc.VerifyTx(tx)
+		require.True(t, errors.Is(err, ErrTxSmallNetworkFee), err)
+	}
+
+	t.Run("invalid base", func(t *testing.T) {
+		for _, b := range []int64{-1, transaction.MaxAttributesFeeBase + 1} {
+			cfg := bc.GetConfig()
+			cfg.AttributeFeeBase = b
+			_, err := NewBlockchain(storage.NewMemoryStore(), cfg, zaptest.NewLogger(t))
+			require.Error(t, err, b)
+		}
+	})
+}
+
+func TestVerifyTx_MaxSystemFee(t *testing.T) {
+	const maxSysFee = 1_0000_0000
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.MaxBlockSystemFee = maxSysFee
+	})
+
+	newTx := func(sysFee int64) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, sysFee)
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		tx.Signers = []transaction.Signer{{Account: neoOwner}}
+		require.NoError(t, testchain.SignTx(bc, tx))
+		return tx
+	}
+	t.Run("AtLimit", func(t *testing.T) {
+		require.NoError(t, bc.VerifyTx(newTx(maxSysFee)))
+	})
+	t.Run("AboveLimit", func(t *testing.T) {
+		err := bc.VerifyTx(newTx(maxSysFee + 1))
+		require.True(t, errors.Is(err, ErrTxTooBigSysFee), err)
+	})
+}
+
 func TestVerifyHashAgainstScript(t *testing.T) {
 	bc := newTestChain(t)
 
@@ -1551,6 +1793,45 @@ func TestDumpAndRestore(t *testing.T) {
 	})
 }
 
+func TestExportImportStore(t *testing.T) {
+	st := storage.NewMemoryStore()
+	bc := newTestChainWithCustomCfgAndStore(t, st, nil)
+	initBasicChain(t, bc)
+	require.True(t, bc.BlockHeight() > 5) // ensure that test is valid
+	require.NoError(t, bc.persist())
+
+	w := io.NewBufBinWriter()
+	require.NoError(t, chaindump.ExportStore(st, w.BinWriter))
+	buf := w.Bytes()
+
+	t.Run("not empty", func(t *testing.T) {
+		_, err := chaindump.ImportStore(st, io.NewBinReaderFromBuf(buf))
+		require.Error(t, err)
+	})
+	t.Run("bad magic", func(t *testing.T) {
+		bad := append([]byte{}, buf...)
+		bad[0]++
+		_, err := chaindump.ImportStore(storage.NewMemoryStore(), io.NewBinReaderFromBuf(bad))
+		require.Error(t, err)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		_, err := chaindump.ImportStore(storage.NewMemoryStore(), io.NewBinReaderFromBuf(buf[:len(buf)-1]))
+		require.Error(t, err)
+	})
+	t.Run("good", func(t *testing.T) {
+		st2 := storage.NewMemoryStore()
+		ver, err := chaindump.ImportStore(st2, io.NewBinReaderFromBuf(buf))
+		require.NoError(t, err)
+		require.Equal(t, version, ver)
+
+		bc2 := newTestChainWithCustomCfgAndStore(t, st2, nil)
+		require.Equal(t, bc.BlockHeight(), bc2.BlockHeight())
+		require.Equal(t, bc.CurrentBlockHash(), bc2.CurrentBlockHash())
+		acc := testchain.PrivateKeyByID(0).GetScriptHash()
+		require.Equal(t, bc.GetUtilityTokenBalance(acc), bc2.GetUtilityTokenBalance(acc))
+	})
+}
+
 func TestRemoveUntraceable(t *testing.T) {
 	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
 		c.ProtocolConfiguration.MaxTraceableBlocks = 2
@@ -1583,6 +1864,59 @@ func TestRemoveUntraceable(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestKeepBlocks(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.KeepBlocks = 2
+		c.ProtocolConfiguration.MaxTraceableBlocks = 2
+	})
+	acc := util.Uint160{1, 2, 3}
+
+	newTx := func(t *testing.T, amount int64) *transaction.Transaction {
+		tx, err := testchain.NewTransferFromOwner(bc, bc.contracts.NEO.Hash, acc, amount, 0, bc.BlockHeight()+1)
+		require.NoError(t, err)
+		return tx
+	}
+	b1 := bc.newBlock(newTx(t, 1), newTx(t, 2))
+	require.NoError(t, bc.AddBlock(b1))
+	b2 := bc.newBlock(newTx(t, 1))
+	require.NoError(t, bc.AddBlock(b2))
+
+	_, err := bc.GetBlock(b1.Hash())
+	require.NoError(t, err)
+
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+
+	_, err = bc.GetBlock(b1.Hash())
+	require.True(t, errors.Is(err, ErrBlockPruned))
+	for _, tx := range b1.Transactions {
+		_, _, err = bc.GetTransaction(tx.Hash())
+		require.Error(t, err)
+		_, err = bc.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.Error(t, err)
+	}
+	h, err := bc.GetHeader(b1.Hash())
+	require.NoError(t, err)
+	require.Equal(t, b1.Index, h.Index)
+	require.Equal(t, b1.Hash(), bc.GetHeaderHash(int(b1.Index)))
+
+	_, err = bc.GetBlock(b2.Hash())
+	require.NoError(t, err)
+	_, err = bc.GetBlock(bc.GetHeaderHash(0))
+	require.NoError(t, err)
+
+	// State is not affected by pruning.
+	require.NotNil(t, bc.GetStorageItem(bc.contracts.NEO.ID, append([]byte{20}, acc.BytesBE()...)))
+	balance, _ := bc.GetGoverningTokenBalance(acc)
+	require.Equal(t, int64(4), balance.Int64())
+
+	t.Run("lower than MaxTraceableBlocks", func(t *testing.T) {
+		cfg := bc.GetConfig()
+		cfg.MaxTraceableBlocks = cfg.KeepBlocks + 1
+		_, err := NewBlockchain(storage.NewMemoryStore(), cfg, zaptest.NewLogger(t))
+		require.Error(t, err)
+	})
+}
+
 func TestInvalidNotification(t *testing.T) {
 	bc := newTestChain(t)
 
@@ -1617,7 +1951,7 @@ func TestConfigNativeUpdateHistory(t *testing.T) {
 		cfgPath := path.Join(prefixPath, fmt.Sprintf("protocol.%s.yml", cfgFileSuffix))
 		cfg, err := config.LoadFile(cfgPath)
 		require.NoError(t, err, fmt.Errorf("failed to load %s", cfgPath))
-		natives := native.NewContracts(cfg.ProtocolConfiguration.P2PSigExtensions, map[string][]uint32{})
+		natives := native.NewContracts(cfg.ProtocolConfiguration.P2PSigExtensions, map[string][]uint32{}, 0)
 		assert.Equal(t, len(natives.Contracts),
 			len(cfg.ProtocolConfiguration.NativeUpdateHistories),
 			fmt.Errorf("protocol configuration file %s: extra or missing NativeUpdateHistory in NativeActivations section", cfgPath))
@@ -1643,3 +1977,174 @@ func TestConfigNativeUpdateHistory(t *testing.T) {
 		check(t, tc)
 	}
 }
+
+func TestPriceTable(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.Opcodes(w.BinWriter, opcode.PUSH1, opcode.PUSH2, opcode.ADD, opcode.DROP)
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetTrigger)
+	emit.Opcodes(w.BinWriter, opcode.DROP)
+	require.NoError(t, w.Err)
+	opScript := w.Bytes()
+
+	run := func(t *testing.T, bc *Blockchain, script []byte) int64 {
+		v := bc.GetTestVM(trigger.Application, nil, nil)
+		v.LoadScriptWithFlags(script, callflag.All)
+		require.NoError(t, v.Run())
+		return v.GasConsumed()
+	}
+
+	def := newTestChain(t)
+	custom := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.PriceTable = config.PriceTable{
+			Opcodes:       map[string]int64{"ADD": 1 << 10},
+			Syscalls:      map[string]int64{interopnames.SystemRuntimeGetTrigger: 1 << 12},
+			NativeMethods: map[string]int64{nativenames.Policy + ".getFeePerByte": 1 << 17},
+		}
+	})
+	baseFee := def.GetPolicer().GetBaseExecFee()
+
+	w = io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, def.contracts.Policy.Hash, "getFeePerByte", callflag.All)
+	require.NoError(t, w.Err)
+	nativeScript := w.Bytes()
+
+	opDef, opCustom := run(t, def, opScript), run(t, custom, opScript)
+	expectedDiff := (1<<10-fee.Opcode(1, opcode.ADD))*baseFee + (1<<12-1<<3)*baseFee
+	require.Equal(t, expectedDiff, opCustom-opDef)
+
+	natDef, natCustom := run(t, def, nativeScript), run(t, custom, nativeScript)
+	require.Equal(t, (1<<17-1<<15)*baseFee, natCustom-natDef)
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg, err := config.Load("../../config", testchain.Network())
+		require.NoError(t, err)
+		tables := []config.PriceTable{
+			{Opcodes: map[string]int64{"NOSUCHOP": 1}},
+			{Opcodes: map[string]int64{"ADD": -1}},
+			{Syscalls: map[string]int64{"System.No.Such": 1}},
+			{Syscalls: map[string]int64{interopnames.SystemRuntimeGetTrigger: -1}},
+			{NativeMethods: map[string]int64{"getFeePerByte": 1}},
+			{NativeMethods: map[string]int64{"NoSuchContract.getFeePerByte": 1}},
+			{NativeMethods: map[string]int64{nativenames.Policy + ".noSuchMethod": 1}},
+			{NativeMethods: map[string]int64{nativenames.Policy + ".getFeePerByte": -1}},
+		}
+		for _, pt := range tables {
+			cfg.ProtocolConfiguration.PriceTable = pt
+			_, err := NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, zaptest.NewLogger(t))
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestTraceTransaction(t *testing.T) {
+	bc := newTestChain(t)
+	acc := random.Uint160()
+	neoHash := bc.contracts.NEO.Hash
+
+	newTx := func(script []byte) *transaction.Transaction {
+		tx := transaction.New(script, 100000000)
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		addSigners(neoOwner, tx)
+		require.NoError(t, testchain.SignTx(bc, tx))
+		return tx
+	}
+	transfer := func(amount int64) *transaction.Transaction {
+		tx := newNEP17Transfer(neoHash, neoOwner, acc, amount)
+		return newTx(tx.Script)
+	}
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, neoHash, "balanceOf", callflag.ReadStates, acc)
+	require.NoError(t, w.Err)
+	balanceTx := newTx(w.Bytes())
+	require.NoError(t, bc.AddBlock(bc.newBlock(transfer(10), balanceTx)))
+
+	// Current state differs from the one balanceTx was executed with.
+	failTx := transfer(1 << 40)
+	require.NoError(t, bc.AddBlock(bc.newBlock(transfer(5), failTx)))
+	checkBalanceOf := func(t *testing.T, expected int64, aer *state.AppExecResult) {
+		require.Equal(t, vm.HaltState, aer.VMState, aer.FaultException)
+		require.Equal(t, 1, len(aer.Stack))
+		require.Equal(t, big.NewInt(expected), aer.Stack[0].Value())
+	}
+	res, err := invokeContractMethod(bc, 100000000, neoHash, "balanceOf", acc)
+	require.NoError(t, err)
+	checkBalanceOf(t, 15, res)
+
+	t.Run("previous block", func(t *testing.T) {
+		aer, steps, err := bc.TraceTransaction(context.Background(), balanceTx.Hash(), 100)
+		require.NoError(t, err)
+		checkBalanceOf(t, 10, aer)
+
+		aers, err := bc.GetAppExecResults(balanceTx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		require.Equal(t, aers[0], *aer)
+
+		require.NotEmpty(t, steps)
+		require.Equal(t, hash.Hash160(balanceTx.Script), steps[0].ScriptHash)
+		require.Equal(t, 0, steps[0].IP)
+	})
+	t.Run("FAULT", func(t *testing.T) {
+		aer, steps, err := bc.TraceTransaction(context.Background(), failTx.Hash(), 3)
+		require.NoError(t, err)
+		require.Equal(t, vm.FaultState, aer.VMState)
+
+		aers, err := bc.GetAppExecResults(failTx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		require.Equal(t, aers[0].FaultException, aer.FaultException)
+		require.Equal(t, aers[0].GasConsumed, aer.GasConsumed)
+
+		require.Equal(t, 3, len(steps))
+		require.Equal(t, opcode.ASSERT, steps[2].Opcode)
+	})
+	t.Run("unknown transaction", func(t *testing.T) {
+		_, _, err := bc.TraceTransaction(context.Background(), util.Uint256{1, 2, 3}, 100)
+		require.Error(t, err)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err := bc.TraceTransaction(ctx, balanceTx.Hash(), 100)
+		require.True(t, errors.Is(err, context.Canceled), err)
+	})
+	t.Run("only latest state", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+			c.ProtocolConfiguration.KeepOnlyLatestState = true
+		})
+		tx := transferTokenFromMultisigAccount(t, bc, acc, bc.contracts.GAS.Hash, 1)
+		_, _, err := bc.TraceTransaction(context.Background(), tx.Hash(), 100)
+		require.True(t, errors.Is(err, ErrStateUnavailable), err)
+	})
+}
+
+func TestBlockchain_CustomStandbyCommittee(t *testing.T) {
+	const committeeSize, validatorsCount = 4, 1
+
+	committee := make(keys.PublicKeys, committeeSize)
+	cfgKeys := make([]string, committeeSize)
+	for i := range committee {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		committee[i] = priv.PublicKey()
+		cfgKeys[i] = hex.EncodeToString(committee[i].Bytes())
+	}
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.StandbyCommittee = cfgKeys
+		c.ProtocolConfiguration.ValidatorsCount = validatorsCount
+	})
+
+	require.Equal(t, committee, bc.GetStandByCommittee())
+	require.Equal(t, committee[:validatorsCount], bc.GetStandByValidators())
+
+	nextConsensus, err := getNextConsensusAddress(committee[:validatorsCount])
+	require.NoError(t, err)
+	genesis, err := bc.GetBlock(bc.GetHeaderHash(0))
+	require.NoError(t, err)
+	require.Equal(t, nextConsensus, genesis.NextConsensus)
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg := bc.GetConfig()
+		cfg.ValidatorsCount = committeeSize + 1
+		_, err := NewBlockchain(storage.NewMemoryStore(), cfg, zaptest.NewLogger(t))
+		require.Error(t, err)
+	})
+}
@@ -1,6 +1,7 @@
 package blockchainer
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -42,6 +43,7 @@ type Blockchainer interface {
 	HasBlock(util.Uint256) bool
 	HasTransaction(util.Uint256) bool
 	IsExtensibleAllowed(util.Uint160) bool
+	ListContracts() ([]*state.Contract, error)
 	GetAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
 	GetNotaryDepositExpiration(acc util.Uint160) uint32
 	GetNativeContractScriptHash(string) (util.Uint160, error)
@@ -52,14 +54,17 @@ type Blockchainer interface {
 	GetNotaryBalance(acc util.Uint160) *big.Int
 	GetPolicer() Policer
 	GetValidators() ([]*keys.PublicKey, error)
+	GetVoters() ([]state.Voter, error)
 	GetStandByCommittee() keys.PublicKeys
 	GetStandByValidators() keys.PublicKeys
 	GetStateModule() StateRoot
 	GetStorageItem(id int32, key []byte) state.StorageItem
 	GetStorageItems(id int32) (map[string]state.StorageItem, error)
+	GetStorageItemsSorted(id int32) ([]state.StorageItemWithKey, error)
 	GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM
 	GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
 	SetOracle(service services.Oracle)
+	TraceTransaction(ctx context.Context, h util.Uint256, maxSteps int) (*state.AppExecResult, []vm.TraceStep, error)
 	mempool.Feer // fee interface
 	ManagementContractHash() util.Uint160
 	PoolTx(t *transaction.Transaction, pools ...*mempool.Pool) error
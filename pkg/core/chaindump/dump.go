@@ -1,13 +1,29 @@
 package chaindump
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 )
 
+const (
+	// SnapshotVersion is the version of storage snapshot format produced by
+	// ExportStore.
+	SnapshotVersion = 1
+
+	// snapshotMagic is written at the beginning of every storage snapshot.
+	snapshotMagic = "NGSS"
+
+	// importBatchSize is the number of key-value pairs put into the store
+	// in a single batch by ImportStore.
+	importBatchSize = 10000
+)
+
 // Dump writes count blocks from start to the provided writer.
 // Note: header needs to be written separately by client.
 func Dump(bc blockchainer.Blockchainer, w *io.BinWriter, start, count uint32) error {
@@ -74,3 +90,79 @@ func Restore(bc blockchainer.Blockchainer, r *io.BinReader, skip, count uint32,
 	}
 	return nil
 }
+
+// ExportStore writes all key-value pairs of the given store to the provided
+// writer prefixed with a header containing snapshot format and DB versions.
+// The store shouldn't be modified during export, so it's not supposed to be
+// used by a running Blockchain.
+func ExportStore(s storage.Store, w *io.BinWriter) error {
+	ver, err := s.Get(storage.SYSVersion.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to get DB version: %w", err)
+	}
+	w.WriteBytes([]byte(snapshotMagic))
+	w.WriteU32LE(SnapshotVersion)
+	w.WriteVarBytes(ver)
+	s.Seek(nil, func(k, v []byte) {
+		w.WriteVarBytes(k)
+		w.WriteVarBytes(v)
+	})
+	// Keys can't be empty, so an empty one marks the end of snapshot.
+	w.WriteVarBytes(nil)
+	return w.Err
+}
+
+// ImportStore reads a snapshot made by ExportStore from the provided reader
+// and puts all of its contents into the given store that must be empty. It
+// returns DB version of the snapshot.
+func ImportStore(s storage.Store, r *io.BinReader) (string, error) {
+	_, err := s.Get(storage.SYSVersion.Bytes())
+	if err == nil {
+		return "", errors.New("store is not empty")
+	} else if !errors.Is(err, storage.ErrKeyNotFound) {
+		return "", fmt.Errorf("failed to check store: %w", err)
+	}
+	magic := make([]byte, len(snapshotMagic))
+	r.ReadBytes(magic)
+	version := r.ReadU32LE()
+	dbVersion := r.ReadVarBytes()
+	if r.Err != nil {
+		return "", fmt.Errorf("failed to read snapshot header: %w", r.Err)
+	}
+	if !bytes.Equal(magic, []byte(snapshotMagic)) {
+		return "", errors.New("not a storage snapshot")
+	}
+	if version != SnapshotVersion {
+		return "", fmt.Errorf("unsupported snapshot version %d", version)
+	}
+	var (
+		batch = s.Batch()
+		count int
+	)
+	for {
+		k := r.ReadVarBytes()
+		if r.Err != nil {
+			return "", fmt.Errorf("failed to read key: %w", r.Err)
+		}
+		if len(k) == 0 {
+			break
+		}
+		v := r.ReadVarBytes()
+		if r.Err != nil {
+			return "", fmt.Errorf("failed to read value for key %x: %w", k, r.Err)
+		}
+		batch.Put(k, v)
+		count++
+		if count == importBatchSize {
+			if err := s.PutBatch(batch); err != nil {
+				return "", err
+			}
+			batch = s.Batch()
+			count = 0
+		}
+	}
+	if err := s.PutBatch(batch); err != nil {
+		return "", err
+	}
+	return string(dbVersion), nil
+}
@@ -45,6 +45,7 @@ type DAO interface {
 	GetNEP17TransferLog(acc util.Uint160, index uint32) (*state.NEP17TransferLog, error)
 	GetStorageItem(id int32, key []byte) state.StorageItem
 	GetStorageItems(id int32) (map[string]state.StorageItem, error)
+	GetStorageItemsSorted(id int32) ([]state.StorageItemWithKey, error)
 	GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error)
 	GetTransaction(hash util.Uint256) (*transaction.Transaction, uint32, error)
 	GetVersion() (string, error)
@@ -309,6 +310,25 @@ func (dao *Simple) GetStorageItems(id int32) (map[string]state.StorageItem, erro
 	return dao.GetStorageItemsWithPrefix(id, nil)
 }
 
+// GetStorageItemsSorted returns all storage items for a given id sorted by
+// their keys, so that the result is deterministic (unlike GetStorageItems).
+func (dao *Simple) GetStorageItemsSorted(id int32) ([]state.StorageItemWithKey, error) {
+	var res []state.StorageItemWithKey
+
+	dao.Seek(id, nil, func(k, v []byte) {
+		// Must copy here, #1468.
+		key := make([]byte, len(k))
+		copy(key, k)
+		si := make(state.StorageItem, len(v))
+		copy(si, v)
+		res = append(res, state.StorageItemWithKey{Key: key, Item: si})
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].Key, res[j].Key) < 0
+	})
+	return res, nil
+}
+
 // GetStorageItemsWithPrefix returns all storage items with given id for a
 // given scripthash.
 func (dao *Simple) GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error) {
@@ -543,8 +563,8 @@ func (dao *Simple) DeleteBlock(h util.Uint256, w *io.BufBinWriter) error {
 	}
 	batch.Put(key, w.Bytes())
 
-	key[0] = byte(storage.DataTransaction)
 	for _, tx := range b.Transactions {
+		key[0] = byte(storage.DataTransaction)
 		copy(key[1:], tx.Hash().BytesBE())
 		batch.Delete(key)
 		key[0] = byte(storage.STNotification)
@@ -1,7 +1,9 @@
 package dao
 
 import (
+	"bytes"
 	"encoding/binary"
+	"sort"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -83,6 +85,34 @@ func TestDeleteStorageItem(t *testing.T) {
 	require.Nil(t, gotStorageItem)
 }
 
+func TestGetStorageItemsSorted(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	id := int32(random.Int(0, 1024))
+	keys := [][]byte{{3}, {1, 2}, {}, {0xff}, {1}, {1, 0}}
+	for i, key := range keys {
+		require.NoError(t, dao.PutStorageItem(id, key, state.StorageItem{byte(i)}))
+	}
+	// Items of other contract must not be returned.
+	require.NoError(t, dao.PutStorageItem(id+1, []byte{2}, state.StorageItem{42}))
+
+	items, err := dao.GetStorageItemsSorted(id)
+	require.NoError(t, err)
+	require.Equal(t, len(keys), len(items))
+	require.True(t, sort.SliceIsSorted(items, func(i, j int) bool {
+		return bytes.Compare(items[i].Key, items[j].Key) < 0
+	}))
+	for _, item := range items {
+		var found bool
+		for i, key := range keys {
+			if bytes.Equal(key, item.Key) {
+				require.Equal(t, state.StorageItem{byte(i)}, item.Item)
+				found = true
+			}
+		}
+		require.True(t, found)
+	}
+}
+
 func TestGetBlock_NotExists(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	hash := random.Uint256()
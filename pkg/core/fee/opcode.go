@@ -13,6 +13,24 @@ func Opcode(base int64, opcodes ...opcode.Opcode) int64 {
 	return result * base
 }
 
+// Table is a set of opcode price coefficients overriding default ones. Opcodes
+// missing from the table (or all of them for nil table) have default prices.
+type Table map[opcode.Opcode]int64
+
+// Opcode returns the deployment coefficients of specified opcodes using
+// overridden prices where applicable.
+func (t Table) Opcode(base int64, opcodes ...opcode.Opcode) int64 {
+	var result int64
+	for _, op := range opcodes {
+		if c, ok := t[op]; ok {
+			result += c
+		} else {
+			result += coefficients[op]
+		}
+	}
+	return result * base
+}
+
 var coefficients = map[opcode.Opcode]int64{
 	opcode.PUSHINT8:     1 << 0,
 	opcode.PUSHINT16:    1 << 0,
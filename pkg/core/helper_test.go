@@ -510,58 +510,12 @@ func addNetworkFee(bc *Blockchain, tx *transaction.Transaction, sender *wallet.A
 // In the first case `true` means sign by committee, `false` means sign by validators.
 func prepareContractMethodInvokeGeneric(chain *Blockchain, sysfee int64,
 	hash util.Uint160, method string, signer interface{}, args ...interface{}) (*transaction.Transaction, error) {
-	w := io.NewBufBinWriter()
-	emit.AppCall(w.BinWriter, hash, method, callflag.All, args...)
-	if w.Err != nil {
-		return nil, w.Err
-	}
-	script := w.Bytes()
-	tx := transaction.New(script, sysfee)
-	tx.ValidUntilBlock = chain.blockHeight + 1
-	var err error
-	switch s := signer.(type) {
-	case bool:
-		if s {
-			addSigners(testchain.CommitteeScriptHash(), tx)
-			err = testchain.SignTxCommittee(chain, tx)
-		} else {
-			addSigners(neoOwner, tx)
-			err = testchain.SignTx(chain, tx)
-		}
-	case *wallet.Account:
-		signTxWithAccounts(chain, tx, s)
-	case []*wallet.Account:
-		signTxWithAccounts(chain, tx, s...)
-	default:
-		panic("invalid signer")
-	}
-	if err != nil {
-		return nil, err
-	}
-	return tx, nil
+	return testchain.NewInvocationTx(chain, sysfee, signer, hash, method, args...)
 }
 
 func signTxWithAccounts(chain *Blockchain, tx *transaction.Transaction, accs ...*wallet.Account) {
-	scope := transaction.CalledByEntry
-	for _, acc := range accs {
-		tx.Signers = append(tx.Signers, transaction.Signer{
-			Account: acc.PrivateKey().GetScriptHash(),
-			Scopes:  scope,
-		})
-		scope = transaction.Global
-	}
-	size := io.GetVarSize(tx)
-	for _, acc := range accs {
-		netFee, sizeDelta := fee.Calculate(chain.GetBaseExecFee(), acc.Contract.Script)
-		size += sizeDelta
-		tx.NetworkFee += netFee
-	}
-	tx.NetworkFee += int64(size) * chain.FeePerByte()
-
-	for _, acc := range accs {
-		if err := acc.SignTx(testchain.Network(), tx); err != nil {
-			panic(err)
-		}
+	if err := testchain.SignTxWithAccounts(chain, tx, accs...); err != nil {
+		panic(err)
 	}
 }
 
@@ -572,21 +526,7 @@ func prepareContractMethodInvoke(chain *Blockchain, sysfee int64,
 }
 
 func persistBlock(chain *Blockchain, txs ...*transaction.Transaction) ([]*state.AppExecResult, error) {
-	b := chain.newBlock(txs...)
-	err := chain.AddBlock(b)
-	if err != nil {
-		return nil, err
-	}
-
-	aers := make([]*state.AppExecResult, len(txs))
-	for i, tx := range txs {
-		res, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
-		if err != nil {
-			return nil, err
-		}
-		aers[i] = &res[0]
-	}
-	return aers, nil
+	return testchain.PersistBlock(chain, txs...)
 }
 
 func invokeContractMethod(chain *Blockchain, sysfee int64, hash util.Uint160, method string, args ...interface{}) (*state.AppExecResult, error) {
@@ -595,16 +535,7 @@ func invokeContractMethod(chain *Blockchain, sysfee int64, hash util.Uint160, me
 
 func invokeContractMethodGeneric(chain *Blockchain, sysfee int64, hash util.Uint160, method string,
 	signer interface{}, args ...interface{}) (*state.AppExecResult, error) {
-	tx, err := prepareContractMethodInvokeGeneric(chain, sysfee, hash,
-		method, signer, args...)
-	if err != nil {
-		return nil, err
-	}
-	aers, err := persistBlock(chain, tx)
-	if err != nil {
-		return nil, err
-	}
-	return aers[0], nil
+	return testchain.InvokeMethodWithFee(chain, sysfee, signer, hash, method, args...)
 }
 
 func invokeContractMethodBy(t *testing.T, chain *Blockchain, signer *wallet.Account, hash util.Uint160, method string, args ...interface{}) (*state.AppExecResult, error) {
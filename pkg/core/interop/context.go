@@ -9,6 +9,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -42,10 +43,21 @@ type Context struct {
 	Tx            *transaction.Transaction
 	DAO           *dao.Cached
 	Notifications []state.NotificationEvent
-	Log           *zap.Logger
-	VM            *vm.VM
-	Functions     []Function
-	getContract   func(dao.DAO, util.Uint160) (*state.Contract, error)
+	// MaxNotifications limits the number of notifications emitted by
+	// contracts (including native ones), 0 means no limit.
+	MaxNotifications int
+	// MaxCallDepth limits the depth of nested contract calls, 0 means no
+	// limit (except for the VM invocation stack size).
+	MaxCallDepth int
+	Log          *zap.Logger
+	VM           *vm.VM
+	Functions    []Function
+	// Prices contains opcode price overrides, nil means default prices.
+	Prices      fee.Table
+	getContract func(dao.DAO, util.Uint160) (*state.Contract, error)
+	// callStack contains invocation stack sizes at the moments of
+	// currently active contract calls.
+	callStack []int
 }
 
 // NewContext returns new interop context.
@@ -54,16 +66,19 @@ func NewContext(trigger trigger.Type, bc blockchainer.Blockchainer, d dao.DAO,
 	block *block.Block, tx *transaction.Transaction, log *zap.Logger) *Context {
 	dao := dao.NewCached(d)
 	nes := make([]state.NotificationEvent, 0)
+	cfg := bc.GetConfig()
 	return &Context{
-		Chain:         bc,
-		Network:       uint32(bc.GetConfig().Magic),
-		Natives:       natives,
-		Trigger:       trigger,
-		Block:         block,
-		Tx:            tx,
-		DAO:           dao,
-		Notifications: nes,
-		Log:           log,
+		Chain:            bc,
+		Network:          uint32(cfg.Magic),
+		MaxNotifications: cfg.MaxNotifications,
+		MaxCallDepth:     cfg.MaxCallDepth,
+		Natives:          natives,
+		Trigger:          trigger,
+		Block:            block,
+		Tx:               tx,
+		DAO:              dao,
+		Notifications:    nes,
+		Log:              log,
 		// Functions is a slice of interops sorted by ID.
 		Functions:   []Function{},
 		getContract: getContract,
@@ -262,11 +277,44 @@ func (ic *Context) SyscallHandler(_ *vm.VM, id uint32) error {
 	return f.Func(ic)
 }
 
+// AddNotification adds notification to the list of emitted ones, it returns
+// an error if MaxNotifications limit is exceeded. OnPersist and PostPersist
+// executions are not limited, the number of native contract notifications
+// there depends on the block contents (and genesis block emits quite a lot of
+// them).
+func (ic *Context) AddNotification(ne state.NotificationEvent) error {
+	if ic.MaxNotifications > 0 && ic.Trigger&(trigger.OnPersist|trigger.PostPersist) == 0 &&
+		len(ic.Notifications) >= ic.MaxNotifications {
+		return fmt.Errorf("number of notifications shouldn't exceed %d", ic.MaxNotifications)
+	}
+	ic.Notifications = append(ic.Notifications, ne)
+	return nil
+}
+
 // SpawnVM spawns new VM with the specified gas limit and set context.VM field.
 func (ic *Context) SpawnVM() *vm.VM {
 	v := vm.NewWithTrigger(ic.Trigger)
 	v.GasLimit = -1
 	v.SyscallHandler = ic.SyscallHandler
 	ic.VM = v
+	ic.callStack = nil
 	return v
 }
+
+// EnterContractCall registers a new contract call made from the current VM
+// context, it returns an error if the call exceeds MaxCallDepth. It must be
+// called before the new contract context is loaded.
+func (ic *Context) EnterContractCall() error {
+	istackSize := ic.VM.Istack().Len()
+	// Drop calls that have already returned (or were unwound by exception).
+	n := len(ic.callStack)
+	for n > 0 && ic.callStack[n-1] >= istackSize {
+		n--
+	}
+	ic.callStack = ic.callStack[:n]
+	if ic.MaxCallDepth > 0 && n >= ic.MaxCallDepth {
+		return fmt.Errorf("contract call depth exceeds %d", ic.MaxCallDepth)
+	}
+	ic.callStack = append(ic.callStack, istackSize)
+	return nil
+}
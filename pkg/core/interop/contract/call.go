@@ -82,6 +82,9 @@ func callInternal(ic *interop.Context, cs *state.Contract, name string, f callfl
 			}
 		}
 	}
+	if err := ic.EnterContractCall(); err != nil {
+		return err
+	}
 	return callExFromNative(ic, ic.VM.GetCurrentScriptHash(), cs, name, args, f, hasReturn)
 }
 
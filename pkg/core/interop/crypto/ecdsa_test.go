@@ -3,6 +3,7 @@ package crypto
 import (
 	"encoding/binary"
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
@@ -227,3 +228,59 @@ func TestCheckSig(t *testing.T) {
 		runCase(t, true, false, sign, pub)
 	})
 }
+
+func TestWitnessHelpers(t *testing.T) {
+	tx := transaction.New([]byte{0, 1, 2}, 1)
+	verify := func(t *testing.T, w transaction.Witness) bool {
+		ic := &interop.Context{
+			Network:   uint32(netmode.UnitTestNet),
+			Trigger:   trigger.Verification,
+			Container: tx,
+			Functions: Interops,
+		}
+		v := ic.SpawnVM()
+		v.LoadScript(w.VerificationScript)
+		v.LoadScript(w.InvocationScript)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.Estack().Len())
+		return v.Estack().Pop().Bool()
+	}
+
+	privs := make([]*keys.PrivateKey, 4)
+	pubs := make(keys.PublicKeys, len(privs))
+	for i := range privs {
+		var err error
+		privs[i], err = keys.NewPrivateKey()
+		require.NoError(t, err)
+		pubs[i] = privs[i].PublicKey()
+	}
+
+	t.Run("signature", func(t *testing.T) {
+		sig := privs[0].SignHashable(uint32(netmode.UnitTestNet), tx)
+		w := transaction.NewSignatureWitness(sig, pubs[0])
+		require.Equal(t, pubs[0].GetScriptHash(), w.ScriptHash())
+		require.True(t, verify(t, w))
+
+		w = transaction.NewSignatureWitness(sig, pubs[1])
+		require.False(t, verify(t, w))
+	})
+	t.Run("multisig", func(t *testing.T) {
+		sorted := make([]*keys.PrivateKey, len(privs))
+		copy(sorted, privs)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].PublicKey().Cmp(sorted[j].PublicKey()) == -1
+		})
+		sigs := make([][]byte, 3)
+		for i := range sigs {
+			sigs[i] = sorted[i+1].SignHashable(uint32(netmode.UnitTestNet), tx)
+		}
+		w, err := transaction.NewMultisigWitness(sigs, 3, pubs)
+		require.NoError(t, err)
+		require.True(t, verify(t, w))
+
+		sigs[0], sigs[1] = sigs[1], sigs[0]
+		w, err = transaction.NewMultisigWitness(sigs, 3, pubs)
+		require.NoError(t, err)
+		require.False(t, verify(t, w))
+	})
+}
@@ -19,6 +19,7 @@ const (
 	SystemIteratorValue                 = "System.Iterator.Value"
 	SystemRuntimeBurnGas                = "System.Runtime.BurnGas"
 	SystemRuntimeCheckWitness           = "System.Runtime.CheckWitness"
+	SystemRuntimeCurrentSigners         = "System.Runtime.CurrentSigners"
 	SystemRuntimeGasLeft                = "System.Runtime.GasLeft"
 	SystemRuntimeGetCallingScriptHash   = "System.Runtime.GetCallingScriptHash"
 	SystemRuntimeGetEntryScriptHash     = "System.Runtime.GetEntryScriptHash"
@@ -56,6 +57,7 @@ var names = []string{
 	SystemIteratorValue,
 	SystemRuntimeBurnGas,
 	SystemRuntimeCheckWitness,
+	SystemRuntimeCurrentSigners,
 	SystemRuntimeGasLeft,
 	SystemRuntimeGetCallingScriptHash,
 	SystemRuntimeGetEntryScriptHash,
@@ -73,8 +73,7 @@ func Notify(ic *interop.Context) error {
 		Name:       name,
 		Item:       stackitem.DeepCopy(stackitem.NewArray(args)).(*stackitem.Array),
 	}
-	ic.Notifications = append(ic.Notifications, ne)
-	return nil
+	return ic.AddNotification(ne)
 }
 
 // Log logs the message passed.
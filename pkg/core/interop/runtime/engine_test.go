@@ -9,6 +9,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -155,6 +156,12 @@ func TestNotify(t *testing.T) {
 		ic := newIC("event", arr)
 		require.Error(t, Notify(ic))
 	})
+	t.Run("too many notifications", func(t *testing.T) {
+		ic := newIC("event", stackitem.NewArray([]stackitem.Item{stackitem.Make(42)}))
+		ic.MaxNotifications = 1
+		ic.Notifications = []state.NotificationEvent{{ScriptHash: h, Name: "event"}}
+		require.Error(t, Notify(ic))
+	})
 	t.Run("good", func(t *testing.T) {
 		arr := stackitem.NewArray([]stackitem.Item{stackitem.Make(42)})
 		ic := newIC("good event", arr)
@@ -4,6 +4,7 @@ import (
 	"crypto/elliptic"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -11,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
 // CheckHashedWitness checks given hash against current list of script hashes
@@ -104,3 +106,33 @@ func CheckWitness(ic *interop.Context) error {
 	ic.VM.Estack().PushVal(res)
 	return nil
 }
+
+// CurrentSigners pushes signers of the script container onto the stack as an
+// array of [account, scopes, allowed contracts, allowed groups] arrays. Null
+// is pushed if the container is not a transaction.
+func CurrentSigners(ic *interop.Context) error {
+	tx, ok := ic.Container.(*transaction.Transaction)
+	if !ok {
+		ic.VM.Estack().PushVal(stackitem.Null{})
+		return nil
+	}
+	signers := make([]stackitem.Item, len(tx.Signers))
+	for i, s := range tx.Signers {
+		contracts := make([]stackitem.Item, len(s.AllowedContracts))
+		for j := range s.AllowedContracts {
+			contracts[j] = stackitem.NewByteArray(s.AllowedContracts[j].BytesBE())
+		}
+		groups := make([]stackitem.Item, len(s.AllowedGroups))
+		for j := range s.AllowedGroups {
+			groups[j] = stackitem.NewByteArray(s.AllowedGroups[j].Bytes())
+		}
+		signers[i] = stackitem.NewArray([]stackitem.Item{
+			stackitem.NewByteArray(s.Account.BytesBE()),
+			stackitem.NewBigInteger(big.NewInt(int64(s.Scopes))),
+			stackitem.NewArray(contracts),
+			stackitem.NewArray(groups),
+		})
+	}
+	ic.VM.Estack().PushVal(stackitem.NewArray(signers))
+	return nil
+}
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -168,6 +171,110 @@ func TestRuntimeGetInvocationCounter(t *testing.T) {
 	})
 }
 
+func TestRuntimeNotifyLimit(t *testing.T) {
+	_, ic, bc := createVM(t)
+	require.Equal(t, bc.GetConfig().MaxNotifications, ic.MaxNotifications)
+	ic.MaxNotifications = 3
+
+	emitEvents := func(n int) []byte {
+		w := io.NewBufBinWriter()
+		for i := 0; i < n; i++ {
+			emit.Opcodes(w.BinWriter, opcode.NEWARRAY0)
+			emit.String(w.BinWriter, "event")
+			emit.Syscall(w.BinWriter, interopnames.SystemRuntimeNotify)
+		}
+		require.NoError(t, w.Err)
+		return w.Bytes()
+	}
+
+	loadScriptWithHashAndFlags(ic, emitEvents(3), util.Uint160{1}, callflag.All)
+	require.NoError(t, ic.VM.Run())
+	require.Equal(t, 3, len(ic.Notifications))
+
+	ic.Notifications = ic.Notifications[:0]
+	loadScriptWithHashAndFlags(ic, emitEvents(4), util.Uint160{1}, callflag.All)
+	require.Error(t, ic.VM.Run())
+	require.True(t, ic.VM.HasFailed())
+	require.Equal(t, 3, len(ic.Notifications))
+}
+
+func TestNativeNotifyLimit(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.MaxNotifications = 1
+	})
+	to := util.Uint160{1, 2, 3}
+	newTx := func(n int) *transaction.Transaction {
+		var script []byte
+		for i := 0; i < n; i++ {
+			script = append(script, newNEP17Transfer(bc.contracts.GAS.Hash, testchain.MultisigScriptHash(), to, 1).Script...)
+		}
+		tx := transaction.New(script, 1_0000_0000)
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		addSigners(neoOwner, tx)
+		require.NoError(t, testchain.SignTx(bc, tx))
+		return tx
+	}
+
+	// Block persisting is not limited, so the chain works with such a setting.
+	ok, fail := newTx(1), newTx(2)
+	require.NoError(t, bc.AddBlock(bc.newBlock(ok, fail)))
+
+	aer, err := bc.GetAppExecResults(ok.Hash(), trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vm.HaltState, aer[0].VMState, aer[0].FaultException)
+	require.Equal(t, 1, len(aer[0].Events))
+
+	aer, err = bc.GetAppExecResults(fail.Hash(), trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vm.FaultState, aer[0].VMState)
+	require.True(t, strings.Contains(aer[0].FaultException, "number of notifications"), aer[0].FaultException)
+}
+
+func TestRuntimeCurrentSigners(t *testing.T) {
+	t.Run("no transaction", func(t *testing.T) {
+		v, ic, _ := createVM(t)
+		require.NoError(t, runtime.CurrentSigners(ic))
+		require.Equal(t, stackitem.Null{}, v.Estack().Pop().Item())
+	})
+
+	_, tx, ic, _ := createVMAndTX(t)
+	tx.Signers[0].Scopes = transaction.CustomContracts
+	tx.Signers[0].AllowedContracts = []util.Uint160{{5, 6, 7}}
+	tx.Signers = append(tx.Signers, transaction.Signer{Account: util.Uint160{8, 9}, Scopes: transaction.Global})
+
+	t.Run("signers", func(t *testing.T) {
+		loadScript(ic, []byte{byte(opcode.RET)})
+		require.NoError(t, runtime.CurrentSigners(ic))
+		require.Equal(t, stackitem.NewArray([]stackitem.Item{
+			stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray(util.Uint160{1, 2, 3, 4}.BytesBE()),
+				stackitem.Make(int64(transaction.CustomContracts)),
+				stackitem.NewArray([]stackitem.Item{stackitem.NewByteArray(util.Uint160{5, 6, 7}.BytesBE())}),
+				stackitem.NewArray([]stackitem.Item{}),
+			}),
+			stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray(util.Uint160{8, 9}.BytesBE()),
+				stackitem.Make(int64(transaction.Global)),
+				stackitem.NewArray([]stackitem.Item{}),
+				stackitem.NewArray([]stackitem.Item{}),
+			}),
+		}), ic.VM.Estack().Pop().Item())
+	})
+	t.Run("sender matches", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Syscall(w.BinWriter, interopnames.SystemRuntimeCurrentSigners)
+		emit.Opcodes(w.BinWriter, opcode.PUSH0, opcode.PICKITEM, opcode.PUSH0, opcode.PICKITEM)
+		emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetScriptContainer)
+		emit.Opcodes(w.BinWriter, opcode.PUSH3, opcode.PICKITEM, opcode.EQUAL)
+		require.NoError(t, w.Err)
+
+		loadScript(ic, w.Bytes())
+		require.NoError(t, ic.VM.Run())
+		require.Equal(t, 1, ic.VM.Estack().Len())
+		require.True(t, ic.VM.Estack().Pop().Bool())
+	})
+}
+
 func TestStoragePut(t *testing.T) {
 	_, cs, ic, bc := createVMAndContractState(t)
 
@@ -978,6 +1085,66 @@ func TestContractCall(t *testing.T) {
 	})
 }
 
+func TestContractCallDepth(t *testing.T) {
+	_, ic, bc := createVM(t)
+	require.Equal(t, vm.MaxInvocationStackSize, ic.MaxCallDepth)
+
+	// recurse(n) calls itself with n-1 until n is 0.
+	h := util.Uint160{1, 2, 3}
+	w := io.NewBufBinWriter()
+	emit.Opcodes(w.BinWriter, opcode.INITSLOT, 0, 1, opcode.LDARG0, opcode.JMPIF, 3, opcode.RET)
+	emit.Opcodes(w.BinWriter, opcode.LDARG0, opcode.DEC, opcode.PUSH1, opcode.PACK)
+	emit.Int(w.BinWriter, int64(callflag.All))
+	emit.String(w.BinWriter, "recurse")
+	emit.Bytes(w.BinWriter, h.BytesBE())
+	emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+	emit.Opcodes(w.BinWriter, opcode.DROP, opcode.RET)
+	require.NoError(t, w.Err)
+
+	m := manifest.DefaultManifest("Recursive")
+	m.ABI.Methods = []manifest.Method{{
+		Name:       "recurse",
+		Parameters: []manifest.Parameter{manifest.NewParameter("n", smartcontract.IntegerType)},
+		ReturnType: smartcontract.VoidType,
+	}}
+	ne, err := nef.NewFile(w.Bytes())
+	require.NoError(t, err)
+	cs := &state.Contract{ContractBase: state.ContractBase{Hash: h, NEF: *ne, Manifest: *m}}
+	require.NoError(t, bc.contracts.Management.PutContractState(ic.DAO, cs))
+
+	const maxDepth = 10
+	run := func(t *testing.T, n int64) error {
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, h, "recurse", callflag.All, n)
+		require.NoError(t, w.Err)
+		loadScriptWithHashAndFlags(ic, w.Bytes(), util.Uint160{}, callflag.All)
+		ic.MaxCallDepth = maxDepth
+		return ic.VM.Run()
+	}
+	t.Run("at limit", func(t *testing.T) {
+		require.NoError(t, run(t, maxDepth-1))
+		require.Equal(t, vm.HaltState, ic.VM.State())
+	})
+	t.Run("above limit", func(t *testing.T) {
+		err := run(t, maxDepth)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "contract call depth exceeds")
+		require.Equal(t, vm.FaultState, ic.VM.State())
+	})
+	t.Run("sequential calls", func(t *testing.T) {
+		// Returned calls don't count.
+		w := io.NewBufBinWriter()
+		for i := 0; i < 2*maxDepth; i++ {
+			emit.AppCall(w.BinWriter, h, "recurse", callflag.All, int64(maxDepth-1))
+			emit.Opcodes(w.BinWriter, opcode.DROP)
+		}
+		require.NoError(t, w.Err)
+		loadScriptWithHashAndFlags(ic, w.Bytes(), util.Uint160{}, callflag.All)
+		ic.MaxCallDepth = maxDepth
+		require.NoError(t, ic.VM.Run())
+	})
+}
+
 func TestContractGetCallFlags(t *testing.T) {
 	v, ic, _ := createVM(t)
 
@@ -1189,6 +1356,8 @@ func TestLoadToken(t *testing.T) {
 		require.NoError(t, err)
 		realBalance, _ := bc.GetGoverningTokenBalance(neoOwner)
 		checkResult(t, aer, stackitem.Make(realBalance.Int64()+1))
+		require.Contains(t, aer.Invocations, cs.Hash)
+		require.Contains(t, aer.Invocations, bc.contracts.NEO.Hash)
 	})
 	t.Run("invalid param count", func(t *testing.T) {
 		aer, err := invokeContractMethod(bc, 1_00000000, cs.Hash, "callT2")
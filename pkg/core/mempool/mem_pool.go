@@ -41,6 +41,13 @@ type item struct {
 	txn        *transaction.Transaction
 	blockStamp uint32
 	data       interface{}
+	// prio is a transaction which priority is used to order this item, it's
+	// only set for sender-ordered items that can't be more prioritized than
+	// the previous transaction of the same sender.
+	prio *transaction.Transaction
+	// transfers are NEP17 transfers made by the transaction, they're only
+	// set when transfer checks are enabled.
+	transfers []nep17Transfer
 }
 
 // items is a slice of item.
@@ -63,14 +70,26 @@ type Pool struct {
 	conflicts map[util.Uint256][]util.Uint256
 	// oracleResp contains ids of oracle responses for tx in pool.
 	oracleResp map[uint64]util.Uint256
+	// getBalance enables NEP17 transfer checks when set, transfers contains
+	// token balances and pooled transfers of the accounts.
+	getBalance BalanceGetter
+	transfers  map[transferKey]balanceAndTransfers
 
 	capacity   int
 	feePerByte int64
 	payerIndex int
+	// senderOrdering enables keeping transactions of the same sender in the
+	// order they were added.
+	senderOrdering bool
 
 	resendThreshold uint32
 	resendFunc      func(*transaction.Transaction, interface{})
 
+	// verificationWorkers is the number of goroutines used to check
+	// transactions in RemoveStale, they're checked sequentially if it's
+	// less than 2.
+	verificationWorkers int
+
 	// subscriptions for mempool events
 	subscriptionsEnabled bool
 	subscriptionsOn      atomic.Bool
@@ -89,8 +108,22 @@ func (p items) Less(i, j int) bool { return p[i].CompareTo(p[j]) < 0 }
 // difference = 0 implies p = otherP.
 // difference > 0 implies p > otherP.
 func (p item) CompareTo(otherP item) int {
-	pHigh := p.txn.HasAttribute(transaction.HighPriority)
-	otherHigh := otherP.txn.HasAttribute(transaction.HighPriority)
+	return compareTxPriority(p.priorityTx(), otherP.priorityTx())
+}
+
+// priorityTx returns transaction which priority is used to order the item.
+func (p item) priorityTx() *transaction.Transaction {
+	if p.prio != nil {
+		return p.prio
+	}
+	return p.txn
+}
+
+// compareTxPriority compares priorities of two transactions, the result is
+// interpreted the same way as CompareTo result.
+func compareTxPriority(tx, otherTx *transaction.Transaction) int {
+	pHigh := tx.HasAttribute(transaction.HighPriority)
+	otherHigh := otherTx.HasAttribute(transaction.HighPriority)
 	if pHigh && !otherHigh {
 		return 1
 	} else if !pHigh && otherHigh {
@@ -98,11 +131,11 @@ func (p item) CompareTo(otherP item) int {
 	}
 
 	// Fees sorted ascending.
-	if ret := int(p.txn.FeePerByte() - otherP.txn.FeePerByte()); ret != 0 {
+	if ret := int(tx.FeePerByte() - otherTx.FeePerByte()); ret != 0 {
 		return ret
 	}
 
-	return int(p.txn.NetworkFee - otherP.txn.NetworkFee)
+	return int(tx.NetworkFee - otherTx.NetworkFee)
 }
 
 // Count returns the total number of uncofirm transactions.
@@ -212,6 +245,13 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 		mp.lock.Unlock()
 		return err
 	}
+	if mp.getBalance != nil {
+		pItem.transfers = parseNEP17Transfers(t.Script)
+		if err := mp.checkTransfers(pItem.transfers, conflictsToBeRemoved); err != nil {
+			mp.lock.Unlock()
+			return err
+		}
+	}
 	if attrs := t.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 		id := attrs[0].Value.(*transaction.OracleResponse).ID
 		h, ok := mp.oracleResp[id]
@@ -231,6 +271,9 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 			mp.removeInternal(conflictingTx.Hash(), fee)
 		}
 	}
+	if mp.senderOrdering {
+		mp.limitBySender(&pItem)
+	}
 	// Insert into sorted array (from max to min, that could also be done
 	// using sort.Sort(sort.Reverse()), but it incurs more overhead. Notice
 	// also that we're searching for position that is strictly more
@@ -251,6 +294,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 		// Ditch the last one.
 		unlucky := mp.verifiedTxes[len(mp.verifiedTxes)-1]
 		delete(mp.verifiedMap, unlucky.txn.Hash())
+		mp.updateTransfers(unlucky.transfers, true)
 		if fee.P2PSigExtensionsEnabled() {
 			mp.removeConflictsOf(unlucky.txn)
 		}
@@ -282,6 +326,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 	}
 	// we already checked balance in checkTxConflicts, so don't need to check again
 	mp.tryAddSendersFee(pItem.txn, fee, false)
+	mp.updateTransfers(pItem.transfers, false)
 
 	updateMempoolMetrics(len(mp.verifiedTxes))
 	mp.lock.Unlock()
@@ -296,6 +341,22 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 	return nil
 }
 
+// limitBySender makes sure given item is not more prioritized than the last
+// (least prioritized) item of the same sender, so that it's placed after it.
+func (mp *Pool) limitBySender(itm *item) {
+	payer := itm.txn.Signers[mp.payerIndex].Account
+	for i := len(mp.verifiedTxes) - 1; i >= 0; i-- {
+		prev := mp.verifiedTxes[i]
+		if !prev.txn.Signers[mp.payerIndex].Account.Equals(payer) {
+			continue
+		}
+		if itm.CompareTo(prev) > 0 {
+			itm.prio = prev.priorityTx()
+		}
+		return
+	}
+}
+
 // Remove removes an item from the mempool, if it exists there (and does
 // nothing if it doesn't).
 func (mp *Pool) Remove(hash util.Uint256, feer Feer) {
@@ -306,9 +367,8 @@ func (mp *Pool) Remove(hash util.Uint256, feer Feer) {
 
 // removeInternal is an internal unlocked representation of Remove.
 func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
-	if tx, ok := mp.verifiedMap[hash]; ok {
+	if _, ok := mp.verifiedMap[hash]; ok {
 		var num int
-		delete(mp.verifiedMap, hash)
 		for num = range mp.verifiedTxes {
 			if hash.Equals(mp.verifiedTxes[num].txn.Hash()) {
 				break
@@ -320,26 +380,58 @@ func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
 		} else if num == len(mp.verifiedTxes)-1 {
 			mp.verifiedTxes = mp.verifiedTxes[:num]
 		}
-		payer := itm.txn.Signers[mp.payerIndex].Account
-		senderFee := mp.fees[payer]
-		senderFee.feeSum.Sub(senderFee.feeSum, big.NewInt(tx.SystemFee+tx.NetworkFee))
-		mp.fees[payer] = senderFee
-		if feer.P2PSigExtensionsEnabled() {
-			// remove all conflicting hashes from mp.conflicts list
-			mp.removeConflictsOf(tx)
-		}
-		if attrs := tx.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
-			delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
+		mp.dropItem(itm, feer.P2PSigExtensionsEnabled())
+	}
+	updateMempoolMetrics(len(mp.verifiedTxes))
+}
+
+// dropItem removes all the data related to the item (except for its
+// verifiedTxes entry) from the pool, it's supposed to be called with
+// the lock held.
+func (mp *Pool) dropItem(itm item, removeConflicts bool) {
+	tx := itm.txn
+	delete(mp.verifiedMap, tx.Hash())
+	payer := tx.Signers[mp.payerIndex].Account
+	senderFee := mp.fees[payer]
+	senderFee.feeSum.Sub(senderFee.feeSum, big.NewInt(tx.SystemFee+tx.NetworkFee))
+	mp.fees[payer] = senderFee
+	mp.updateTransfers(itm.transfers, true)
+	if removeConflicts {
+		// remove all conflicting hashes from mp.conflicts list
+		mp.removeConflictsOf(tx)
+	}
+	if attrs := tx.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
+		delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
+	}
+	if mp.subscriptionsOn.Load() {
+		mp.events <- Event{
+			Type: TransactionRemoved,
+			Tx:   itm.txn,
+			Data: itm.data,
 		}
-		if mp.subscriptionsOn.Load() {
-			mp.events <- Event{
-				Type: TransactionRemoved,
-				Tx:   itm.txn,
-				Data: itm.data,
-			}
+	}
+}
+
+// RemoveExpired removes transactions with ValidUntilBlock lower than the given
+// height (which is the height of the next block to be accepted) from the pool.
+// It's much cheaper than RemoveStale because it doesn't recheck anything, so
+// it's used to drop transactions that can't be included into blocks anymore
+// before doing more expensive checks.
+func (mp *Pool) RemoveExpired(currentHeight uint32) {
+	mp.lock.Lock()
+	// We can reuse already allocated slice
+	// because items are iterated one-by-one in increasing order.
+	newVerifiedTxes := mp.verifiedTxes[:0]
+	for _, itm := range mp.verifiedTxes {
+		if itm.txn.ValidUntilBlock >= currentHeight {
+			newVerifiedTxes = append(newVerifiedTxes, itm)
+			continue
 		}
+		mp.dropItem(itm, true)
 	}
+	mp.verifiedTxes = newVerifiedTxes
 	updateMempoolMetrics(len(mp.verifiedTxes))
+	mp.lock.Unlock()
 }
 
 // RemoveStale filters verified transactions through the given function keeping
@@ -355,13 +447,29 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 	if feer.P2PSigExtensionsEnabled() {
 		mp.conflicts = make(map[util.Uint256][]util.Uint256)
 	}
+	if mp.getBalance != nil {
+		// Balances could have been changed by the block.
+		mp.transfers = make(map[transferKey]balanceAndTransfers)
+	}
 	height := feer.BlockHeight()
 	var (
 		staleItems []item
+		checked    []bool
 	)
-	for _, itm := range mp.verifiedTxes {
-		if isOK(itm.txn) && mp.checkPolicy(itm.txn, policyChanged) && mp.tryAddSendersFee(itm.txn, feer, true) {
+	if mp.verificationWorkers > 1 {
+		checked = mp.checkConcurrently(isOK)
+	}
+	for i, itm := range mp.verifiedTxes {
+		var ok bool
+		if checked != nil {
+			ok = checked[i]
+		} else {
+			ok = isOK(itm.txn)
+		}
+		if ok && mp.checkPolicy(itm.txn, policyChanged) &&
+			mp.checkTransfers(itm.transfers, nil) == nil && mp.tryAddSendersFee(itm.txn, feer, true) {
 			newVerifiedTxes = append(newVerifiedTxes, itm)
+			mp.updateTransfers(itm.transfers, false)
 			if feer.P2PSigExtensionsEnabled() {
 				for _, attr := range itm.txn.GetAttributes(transaction.ConflictsT) {
 					hash := attr.Value.(*transaction.Conflicts).Hash
@@ -397,6 +505,31 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 	mp.lock.Unlock()
 }
 
+// checkConcurrently runs isOK for all verified transactions using
+// verificationWorkers goroutines and returns the results in the same order
+// transactions are stored in.
+func (mp *Pool) checkConcurrently(isOK func(*transaction.Transaction) bool) []bool {
+	var (
+		res     = make([]bool, len(mp.verifiedTxes))
+		workers = mp.verificationWorkers
+		wg      sync.WaitGroup
+	)
+	if workers > len(res) {
+		workers = len(res)
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(res); i += workers {
+				res[i] = isOK(mp.verifiedTxes[i].txn)
+			}
+		}(w)
+	}
+	wg.Wait()
+	return res
+}
+
 // loadPolicy updates feePerByte field and returns whether policy has been
 // changed.
 func (mp *Pool) loadPolicy(feer Feer) bool {
@@ -436,6 +569,27 @@ func New(capacity int, payerIndex int, enableSubscriptions bool) *Pool {
 	return mp
 }
 
+// NewWithSenderOrdering returns a new Pool that keeps transactions of the same
+// sender in the order they were added to it, so that the one added later is
+// never included into block before the one added earlier (even if it has
+// higher priority).
+func NewWithSenderOrdering(capacity int, payerIndex int, enableSubscriptions bool) *Pool {
+	mp := New(capacity, payerIndex, enableSubscriptions)
+	mp.senderOrdering = true
+	return mp
+}
+
+// SetVerificationWorkers sets the number of goroutines RemoveStale uses to
+// check pooled transactions with the given function. By default (and for
+// values less than 2) transactions are checked sequentially, with it enabled
+// the function passed to RemoveStale must be safe for concurrent use. Other
+// checks and the order of transactions are not affected.
+func (mp *Pool) SetVerificationWorkers(n int) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.verificationWorkers = n
+}
+
 // SetResendThreshold sets threshold after which transaction will be considered stale
 // and returned for retransmission by `GetStaleTransactions`.
 func (mp *Pool) SetResendThreshold(h uint32, f func(*transaction.Transaction, interface{})) {
@@ -476,7 +630,9 @@ func (mp *Pool) TryGetData(hash util.Uint256) (interface{}, bool) {
 				if mp.verifiedTxes[i].txn.Hash() == hash {
 					return mp.verifiedTxes[i].data, ok
 				}
-				if itm.CompareTo(mp.verifiedTxes[i]) != 0 {
+				// Sender-ordered items can be less prioritized than
+				// their transactions.
+				if !mp.senderOrdering && itm.CompareTo(mp.verifiedTxes[i]) != 0 {
 					break
 				}
 			}
@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"sort"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -248,6 +250,97 @@ func TestRemoveStale(t *testing.T) {
 	}
 }
 
+func newRemoveStalePools(tb testing.TB, n int, workers int) (*Pool, *Pool) {
+	var fs = &FeerStub{balance: 1_000_000_000}
+	serial := New(n, 0, false)
+	concurrent := New(n, 0, false)
+	concurrent.SetVerificationWorkers(workers)
+	for i := 0; i < n; i++ {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = uint32(i)
+		tx.NetworkFee = int64(i % 7)
+		tx.Signers = []transaction.Signer{{Account: random.Uint160()}}
+		require.NoError(tb, serial.Add(tx, fs))
+		require.NoError(tb, concurrent.Add(tx, fs))
+	}
+	return serial, concurrent
+}
+
+func TestRemoveStaleConcurrent(t *testing.T) {
+	const mempoolSize = 1000
+	isOK := func(tx *transaction.Transaction) bool {
+		return tx.Nonce%3 != 0
+	}
+	for _, workers := range []int{2, 4, 7, mempoolSize * 2} {
+		serial, concurrent := newRemoveStalePools(t, mempoolSize, workers)
+		serial.RemoveStale(isOK, &FeerStub{balance: 1_000_000_000})
+		concurrent.RemoveStale(isOK, &FeerStub{balance: 1_000_000_000})
+
+		expected := serial.GetVerifiedTransactions()
+		require.Equal(t, mempoolSize-(mempoolSize+2)/3, len(expected))
+		require.Equal(t, expected, concurrent.GetVerifiedTransactions())
+		for _, tx := range expected {
+			require.True(t, concurrent.ContainsKey(tx.Hash()))
+		}
+	}
+}
+
+func BenchmarkRemoveStale(b *testing.B) {
+	const mempoolSize = 10000
+	// isOK emulates some real verification work.
+	isOK := func(tx *transaction.Transaction) bool {
+		h := tx.Hash()
+		for i := 0; i < 100; i++ {
+			h = hash.Sha256(h[:])
+		}
+		return h[0] != 0
+	}
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				mp, _ := newRemoveStalePools(b, mempoolSize, 0)
+				mp.SetVerificationWorkers(workers)
+				b.StartTimer()
+				mp.RemoveStale(isOK, &FeerStub{balance: 1_000_000_000})
+			}
+		})
+	}
+}
+
+func TestRemoveExpired(t *testing.T) {
+	var fs = &FeerStub{balance: 100}
+	const mempoolSize = 10
+	mp := New(mempoolSize, 0, false)
+
+	sender := util.Uint160{1, 2, 3}
+	txes := make([]*transaction.Transaction, mempoolSize)
+	for i := range txes {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = uint32(i)
+		tx.ValidUntilBlock = uint32(10 + i)
+		tx.NetworkFee = 1
+		tx.Signers = []transaction.Signer{{Account: sender}}
+		txes[i] = tx
+		require.NoError(t, mp.Add(tx, fs))
+	}
+
+	mp.RemoveExpired(10)
+	require.Equal(t, mempoolSize, mp.Count())
+
+	// Transactions valid until 10..13 are expired for block 14.
+	mp.RemoveExpired(14)
+	require.Equal(t, mempoolSize-4, mp.Count())
+	for i, tx := range txes {
+		require.Equal(t, i >= 4, mp.ContainsKey(tx.Hash()))
+	}
+	require.Equal(t, int64(mempoolSize-4), mp.fees[sender].feeSum.Int64())
+
+	mp.RemoveExpired(100)
+	require.Equal(t, 0, mp.Count())
+	require.Equal(t, int64(0), mp.fees[sender].feeSum.Int64())
+}
+
 func TestMemPoolFees(t *testing.T) {
 	mp := New(10, 0, false)
 	fs := &FeerStub{balance: 10000000}
@@ -353,6 +446,68 @@ func TestMempoolItemsOrder(t *testing.T) {
 	require.True(t, item4.CompareTo(item3) < 0)
 }
 
+func TestMempoolSenderOrdering(t *testing.T) {
+	sender0 := util.Uint160{1, 2, 3}
+	sender1 := util.Uint160{3, 2, 1}
+	fs := &FeerStub{balance: 10000}
+	nonce := uint32(0)
+	newTx := func(sender util.Uint160, netFee int64) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.NetworkFee = netFee
+		tx.Nonce = nonce
+		nonce++
+		tx.Signers = []transaction.Signer{{Account: sender}}
+		return tx
+	}
+	check := func(t *testing.T, mp *Pool, expected ...*transaction.Transaction) {
+		require.Equal(t, expected, mp.GetVerifiedTransactions())
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		mp := New(10, 0, false)
+		tx1 := newTx(sender0, 10)
+		tx2 := newTx(sender0, 20)
+		require.NoError(t, mp.Add(tx1, fs))
+		require.NoError(t, mp.Add(tx2, fs))
+		check(t, mp, tx2, tx1)
+	})
+	t.Run("enabled", func(t *testing.T) {
+		mp := NewWithSenderOrdering(10, 0, false)
+		tx1 := newTx(sender0, 10)
+		tx2 := newTx(sender0, 20)
+		require.NoError(t, mp.Add(tx1, fs))
+		require.NoError(t, mp.Add(tx2, fs))
+		check(t, mp, tx1, tx2)
+
+		// Other senders are not affected.
+		tx3 := newTx(sender1, 15)
+		require.NoError(t, mp.Add(tx3, fs))
+		check(t, mp, tx3, tx1, tx2)
+
+		// Less prioritized transaction is just added after the others.
+		tx4 := newTx(sender0, 5)
+		require.NoError(t, mp.Add(tx4, fs))
+		check(t, mp, tx3, tx1, tx2, tx4)
+
+		data, ok := mp.TryGetData(tx2.Hash())
+		require.True(t, ok)
+		require.Nil(t, data)
+
+		mp.Remove(tx1.Hash(), fs)
+		check(t, mp, tx3, tx2, tx4)
+	})
+	t.Run("capacity", func(t *testing.T) {
+		mp := NewWithSenderOrdering(2, 0, false)
+		tx1 := newTx(sender0, 10)
+		tx2 := newTx(sender1, 20)
+		require.NoError(t, mp.Add(tx1, fs))
+		require.NoError(t, mp.Add(tx2, fs))
+		// Can't evict previous transaction of the same sender.
+		require.True(t, errors.Is(mp.Add(newTx(sender0, 30), fs), ErrOOM))
+		check(t, mp, tx2, tx1)
+	})
+}
+
 func TestMempoolAddRemoveOracleResponse(t *testing.T) {
 	mp := New(3, 0, false)
 	nonce := uint32(0)
@@ -0,0 +1,228 @@
+package mempool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// BalanceGetter returns the balance of the account in the NEP17 token, nil
+// is returned for tokens which transfers are not to be checked.
+type BalanceGetter func(token, acc util.Uint160) *big.Int
+
+// nep17Transfer is a NEP17 transfer made by the transaction script.
+type nep17Transfer struct {
+	token  util.Uint160
+	from   util.Uint160
+	amount *big.Int
+}
+
+// transferKey identifies the account balance in the token.
+type transferKey struct {
+	token util.Uint160
+	from  util.Uint160
+}
+
+// balanceAndTransfers stores account's token balance and overall amount
+// transferred by account's transactions which are currently in mempool.
+type balanceAndTransfers struct {
+	balance *big.Int
+	sum     *big.Int
+}
+
+var contractCallID = interopnames.ToID([]byte(interopnames.SystemContractCall))
+
+// SetTransferChecks enables or disables (if nil is passed) NEP17 transfer
+// checks. When enabled, standard `transfer` calls (the ones emitted by
+// emit.AppCall with constant arguments) are extracted from transaction
+// scripts and transactions that would overdraw sender's token balance given
+// the transfers already pooled are rejected. Transactions that are already in
+// the pool are not rechecked, but their transfers are taken into account.
+func (mp *Pool) SetTransferChecks(getBalance BalanceGetter) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.getBalance = getBalance
+	mp.transfers = make(map[transferKey]balanceAndTransfers)
+	for i := range mp.verifiedTxes {
+		mp.verifiedTxes[i].transfers = nil
+		if getBalance != nil {
+			mp.verifiedTxes[i].transfers = parseNEP17Transfers(mp.verifiedTxes[i].txn.Script)
+			mp.updateTransfers(mp.verifiedTxes[i].transfers, false)
+		}
+	}
+}
+
+// parseNEP17Transfers returns NEP17 transfers made by the script. It's not a
+// complete simulation, only constant values pushed right before the contract
+// call are tracked which is enough for regular transfer transactions.
+func parseNEP17Transfers(script []byte) []nep17Transfer {
+	var (
+		res   []nep17Transfer
+		stack []stackitem.Item
+		ctx   = vm.NewContext(script)
+	)
+	for {
+		instr, param, err := ctx.Next()
+		if err != nil {
+			return res
+		}
+		switch {
+		case instr == opcode.PUSHNULL:
+			stack = append(stack, stackitem.Null{})
+		case instr == opcode.PUSHT || instr == opcode.PUSHF:
+			stack = append(stack, stackitem.NewBool(instr == opcode.PUSHT))
+		case opcode.PUSHM1 <= instr && instr <= opcode.PUSH16:
+			stack = append(stack, stackitem.NewBigInteger(big.NewInt(int64(instr)-int64(opcode.PUSH0))))
+		case instr <= opcode.PUSHINT256:
+			stack = append(stack, stackitem.NewBigInteger(bigint.FromBytes(param)))
+		case opcode.PUSHDATA1 <= instr && instr <= opcode.PUSHDATA4:
+			stack = append(stack, stackitem.NewByteArray(param))
+		case instr == opcode.PACK:
+			stack = pack(stack)
+		case instr == opcode.SYSCALL && len(param) == 4 && vm.GetInteropID(param) == contractCallID:
+			if t, ok := getTransfer(stack); ok {
+				res = append(res, t)
+			}
+			stack = nil
+		case instr == opcode.RET && ctx.IP() >= len(script):
+			return res
+		default:
+			stack = nil
+		}
+	}
+}
+
+// pack emulates PACK opcode, it drops the whole stack if arguments are
+// invalid.
+func pack(stack []stackitem.Item) []stackitem.Item {
+	if len(stack) == 0 {
+		return nil
+	}
+	n, err := stack[len(stack)-1].TryInteger()
+	if err != nil || !n.IsInt64() || n.Int64() < 0 || n.Int64() > int64(len(stack)-1) {
+		return nil
+	}
+	stack = stack[:len(stack)-1]
+	items := make([]stackitem.Item, n.Int64())
+	for i := range items {
+		items[i] = stack[len(stack)-1-i]
+	}
+	stack = stack[:len(stack)-len(items)]
+	return append(stack, stackitem.NewArray(items))
+}
+
+// getTransfer checks System.Contract.Call arguments on the stack and returns
+// a transfer if it's a NEP17 `transfer` call.
+func getTransfer(stack []stackitem.Item) (nep17Transfer, bool) {
+	var t nep17Transfer
+	if len(stack) < 4 {
+		return t, false
+	}
+	stack = stack[len(stack)-4:]
+	hash, err := stack[3].TryBytes()
+	if err != nil {
+		return t, false
+	}
+	t.token, err = util.Uint160DecodeBytesBE(hash)
+	if err != nil {
+		return t, false
+	}
+	method, err := stack[2].TryBytes()
+	if err != nil || string(method) != "transfer" {
+		return t, false
+	}
+	args, ok := stack[0].Value().([]stackitem.Item)
+	if !ok || len(args) != 4 {
+		return t, false
+	}
+	from, err := args[0].TryBytes()
+	if err != nil {
+		return t, false
+	}
+	t.from, err = util.Uint160DecodeBytesBE(from)
+	if err != nil {
+		return t, false
+	}
+	t.amount, err = args[2].TryInteger()
+	if err != nil || t.amount.Sign() < 0 {
+		return t, false
+	}
+	return t, true
+}
+
+// getTransferBalance returns pooled transfers state for the given key, it's
+// supposed to be called with the lock held.
+func (mp *Pool) getTransferBalance(k transferKey) balanceAndTransfers {
+	b, ok := mp.transfers[k]
+	if !ok {
+		b.balance = mp.getBalance(k.token, k.from)
+		b.sum = big.NewInt(0)
+		mp.transfers[k] = b
+	}
+	return b
+}
+
+// checkTransfers returns an error if transfers can't be made given the
+// transfers already pooled, transfers of excluded transactions are not taken
+// into account.
+func (mp *Pool) checkTransfers(transfers []nep17Transfer, excluded []*transaction.Transaction) error {
+	if mp.getBalance == nil || len(transfers) == 0 {
+		return nil
+	}
+	own := make(map[transferKey]*big.Int)
+	for _, t := range transfers {
+		k := transferKey{token: t.token, from: t.from}
+		if own[k] == nil {
+			own[k] = new(big.Int)
+		}
+		own[k].Add(own[k], t.amount)
+	}
+	pooled := make(map[transferKey]*big.Int, len(own))
+	for k := range own {
+		pooled[k] = new(big.Int).Set(mp.getTransferBalance(k).sum)
+	}
+	for _, tx := range excluded {
+		for _, t := range parseNEP17Transfers(tx.Script) {
+			k := transferKey{token: t.token, from: t.from}
+			if s, ok := pooled[k]; ok {
+				s.Sub(s, t.amount)
+			}
+		}
+	}
+	for k, amount := range own {
+		balance := mp.transfers[k].balance
+		if balance == nil { // Not checked.
+			continue
+		}
+		if balance.Cmp(amount) < 0 {
+			return fmt.Errorf("%w: %s transfer from %s", ErrInsufficientFunds, k.token.StringLE(), k.from.StringLE())
+		}
+		if balance.Cmp(amount.Add(amount, pooled[k])) < 0 {
+			return fmt.Errorf("%w: %s transfer from %s", ErrConflict, k.token.StringLE(), k.from.StringLE())
+		}
+	}
+	return nil
+}
+
+// updateTransfers adds transfers to (or removes them from) the pooled amounts,
+// it's supposed to be called with the lock held.
+func (mp *Pool) updateTransfers(transfers []nep17Transfer, remove bool) {
+	if mp.getBalance == nil {
+		return
+	}
+	for _, t := range transfers {
+		b := mp.getTransferBalance(transferKey{token: t.token, from: t.from})
+		if remove {
+			b.sum.Sub(b.sum, t.amount)
+		} else {
+			b.sum.Add(b.sum, t.amount)
+		}
+	}
+}
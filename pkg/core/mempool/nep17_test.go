@@ -0,0 +1,102 @@
+package mempool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransferTx(t *testing.T, nonce uint32, token, from util.Uint160, amounts ...int64) *transaction.Transaction {
+	w := io.NewBufBinWriter()
+	for _, amount := range amounts {
+		emit.AppCall(w.BinWriter, token, "transfer", callflag.All, from, util.Uint160{9}, amount, nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	require.NoError(t, w.Err)
+	tx := transaction.New(w.Bytes(), 0)
+	tx.Nonce = nonce
+	tx.Signers = []transaction.Signer{{Account: from}}
+	return tx
+}
+
+func TestParseNEP17Transfers(t *testing.T) {
+	token, from := util.Uint160{1}, util.Uint160{2}
+	tx := newTransferTx(t, 0, token, from, 10, 100500)
+	require.Equal(t, []nep17Transfer{
+		{token: token, from: from, amount: big.NewInt(10)},
+		{token: token, from: from, amount: big.NewInt(100500)},
+	}, parseNEP17Transfers(tx.Script))
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, token, "balanceOf", callflag.All, from)
+	emit.AppCall(w.BinWriter, token, "transfer", callflag.All, from, util.Uint160{9}, int64(-1), nil)
+	emit.AppCall(w.BinWriter, token, "transfer", callflag.All, []byte{1, 2, 3}, util.Uint160{9}, int64(1), nil)
+	emit.AppCall(w.BinWriter, token, "transfer", callflag.All, from, util.Uint160{9}, int64(1))
+	require.NoError(t, w.Err)
+	require.Nil(t, parseNEP17Transfers(w.Bytes()))
+	require.Nil(t, parseNEP17Transfers([]byte{byte(opcode.PUSH1), 0xff}))
+}
+
+func TestMempoolTransferChecks(t *testing.T) {
+	fs := &FeerStub{balance: 100}
+	token, sender := util.Uint160{1}, util.Uint160{2}
+	balances := map[util.Uint160]int64{sender: 100}
+	mp := New(10, 0, false)
+	mp.SetTransferChecks(func(tk, acc util.Uint160) *big.Int {
+		if tk.Equals(util.Uint160{4}) {
+			return nil
+		}
+		if !tk.Equals(token) {
+			return big.NewInt(0)
+		}
+		return big.NewInt(balances[acc])
+	})
+
+	tx1 := newTransferTx(t, 1, token, sender, 60)
+	require.NoError(t, mp.Add(tx1, fs))
+
+	t.Run("single transaction overdraft", func(t *testing.T) {
+		err := mp.Add(newTransferTx(t, 2, token, sender, 50, 51), fs)
+		require.True(t, errors.Is(err, ErrInsufficientFunds))
+	})
+	t.Run("double spend", func(t *testing.T) {
+		err := mp.Add(newTransferTx(t, 3, token, sender, 41), fs)
+		require.True(t, errors.Is(err, ErrConflict))
+	})
+	t.Run("other token", func(t *testing.T) {
+		err := mp.Add(newTransferTx(t, 4, util.Uint160{3}, sender, 1), fs)
+		require.True(t, errors.Is(err, ErrInsufficientFunds))
+	})
+	t.Run("unchecked token", func(t *testing.T) {
+		tx := newTransferTx(t, 8, util.Uint160{4}, sender, 1000)
+		require.NoError(t, mp.Add(tx, fs))
+		mp.Remove(tx.Hash(), fs)
+	})
+
+	tx2 := newTransferTx(t, 5, token, sender, 40)
+	require.NoError(t, mp.Add(tx2, fs))
+	require.Equal(t, 2, mp.Count())
+
+	mp.Remove(tx1.Hash(), fs)
+	tx3 := newTransferTx(t, 6, token, sender, 60)
+	require.NoError(t, mp.Add(tx3, fs))
+
+	t.Run("balance change", func(t *testing.T) {
+		balances[sender] = 70
+		mp.RemoveStale(func(*transaction.Transaction) bool { return true }, fs)
+		require.Equal(t, 1, mp.Count())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		mp.SetTransferChecks(nil)
+		require.NoError(t, mp.Add(newTransferTx(t, 7, token, sender, 1000), fs))
+	})
+}
@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	nio "github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// Saved mempool format markers.
+const (
+	savedPlain     byte = 0x00
+	savedEncrypted byte = 0x01
+)
+
+// ErrWrongKey is returned from Load when saved transactions can't be decrypted
+// with the given key (or when the key is missing).
+var ErrWrongKey = errors.New("can't decrypt saved mempool")
+
+// Save writes all verified transactions from the pool to w. If key is not
+// nil transactions are encrypted with AES-GCM using it (key must be 16, 24 or
+// 32 bytes long), otherwise they're stored as is.
+func (mp *Pool) Save(w io.Writer, key []byte) error {
+	buf := nio.NewBufBinWriter()
+	buf.WriteArray(mp.GetVerifiedTransactions())
+	if buf.Err != nil {
+		return buf.Err
+	}
+	data := buf.Bytes()
+	format := savedPlain
+	if key != nil {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return fmt.Errorf("can't generate nonce: %w", err)
+		}
+		data = aead.Seal(nonce, nonce, data, nil)
+		format = savedEncrypted
+	}
+	if _, err := w.Write([]byte{format}); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// Load reads transactions saved by Save from r using the same key. It doesn't
+// add them to any pool, they're to be verified and pooled again by the caller
+// (like Blockchain.PoolTx does).
+func Load(r io.Reader, key []byte) ([]*transaction.Transaction, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("no data")
+	}
+	format, data := data[0], data[1:]
+	switch format {
+	case savedPlain:
+	case savedEncrypted:
+		if key == nil {
+			return nil, fmt.Errorf("%w: no key", ErrWrongKey)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) < aead.NonceSize() {
+			return nil, errors.New("invalid encrypted data")
+		}
+		nonce := data[:aead.NonceSize()]
+		data, err = aead.Open(nil, nonce, data[aead.NonceSize():], nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrWrongKey, err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %d", format)
+	}
+	var txes []*transaction.Transaction
+	br := nio.NewBinReaderFromBuf(data)
+	br.ReadArray(&txes)
+	if br.Err != nil {
+		return nil, br.Err
+	}
+	return txes, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
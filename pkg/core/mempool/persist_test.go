@@ -0,0 +1,75 @@
+package mempool
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad(t *testing.T) {
+	fs := &FeerStub{balance: 10000000}
+	mp := New(10, 0, false)
+	for i := 0; i < 5; i++ {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = uint32(i)
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		tx.Scripts = []transaction.Witness{{}}
+		require.NoError(t, mp.Add(tx, fs))
+	}
+	check := func(t *testing.T, txes []*transaction.Transaction) {
+		require.Equal(t, mp.Count(), len(txes))
+		for _, tx := range txes {
+			require.True(t, mp.ContainsKey(tx.Hash()))
+		}
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, mp.Save(buf, nil))
+		txes, err := Load(bytes.NewReader(buf.Bytes()), nil)
+		require.NoError(t, err)
+		check(t, txes)
+
+		// Key is ignored for plaintext data.
+		txes, err = Load(bytes.NewReader(buf.Bytes()), make([]byte, 32))
+		require.NoError(t, err)
+		check(t, txes)
+	})
+	t.Run("encrypted", func(t *testing.T) {
+		key := bytes.Repeat([]byte{42}, 32)
+		plain, encrypted := new(bytes.Buffer), new(bytes.Buffer)
+		require.NoError(t, mp.Save(plain, nil))
+		require.NoError(t, mp.Save(encrypted, key))
+		require.False(t, bytes.Contains(encrypted.Bytes(), plain.Bytes()[1:]))
+
+		txes, err := Load(bytes.NewReader(encrypted.Bytes()), key)
+		require.NoError(t, err)
+		check(t, txes)
+
+		t.Run("wrong key", func(t *testing.T) {
+			wrong := bytes.Repeat([]byte{43}, 32)
+			_, err := Load(bytes.NewReader(encrypted.Bytes()), wrong)
+			require.True(t, errors.Is(err, ErrWrongKey), err)
+		})
+		t.Run("no key", func(t *testing.T) {
+			_, err := Load(bytes.NewReader(encrypted.Bytes()), nil)
+			require.True(t, errors.Is(err, ErrWrongKey), err)
+		})
+		t.Run("bad key length", func(t *testing.T) {
+			require.Error(t, mp.Save(new(bytes.Buffer), []byte{1, 2, 3}))
+		})
+	})
+	t.Run("bad data", func(t *testing.T) {
+		_, err := Load(bytes.NewReader(nil), nil)
+		require.Error(t, err)
+		_, err = Load(bytes.NewReader([]byte{0xff, 0}), nil)
+		require.Error(t, err)
+		_, err = Load(bytes.NewReader([]byte{savedEncrypted, 1, 2}), make([]byte, 16))
+		require.Error(t, err)
+	})
+}
@@ -57,6 +57,59 @@ func (t *Trie) Get(key []byte) ([]byte, error) {
 	return bs, nil
 }
 
+// Find returns all key-value pairs with keys starting with the given prefix
+// sorted by key.
+func (t *Trie) Find(prefix []byte) ([]storage.KeyValue, error) {
+	var res []storage.KeyValue
+	err := t.find(t.root, toNibbles(prefix), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// find collects all values from the subtrie rooting in curr with paths
+// starting with the provided one, passed is the path from the root to curr.
+func (t *Trie) find(curr Node, path, passed []byte, res *[]storage.KeyValue) error {
+	switch n := curr.(type) {
+	case *LeafNode:
+		if len(path) == 0 {
+			*res = append(*res, storage.KeyValue{Key: fromNibbles(passed), Value: copySlice(n.value)})
+		}
+	case *BranchNode:
+		if len(path) != 0 {
+			return t.find(n.Children[path[0]], path[1:], append(passed, path[0]), res)
+		}
+		if err := t.find(n.Children[lastChild], nil, passed, res); err != nil {
+			return err
+		}
+		for i := byte(0); i < lastChild; i++ {
+			if err := t.find(n.Children[i], nil, append(passed, i), res); err != nil {
+				return err
+			}
+		}
+	case *HashNode:
+		if n.IsEmpty() {
+			return nil
+		}
+		r, err := t.getFromStore(n.hash)
+		if err != nil {
+			return err
+		}
+		return t.find(r, path, passed, res)
+	case *ExtensionNode:
+		switch {
+		case len(path) <= len(n.key) && bytes.HasPrefix(n.key, path):
+			return t.find(n.next, nil, append(passed, n.key...), res)
+		case bytes.HasPrefix(path, n.key):
+			return t.find(n.next, path[len(n.key):], append(passed, n.key...), res)
+		}
+	default:
+		panic("invalid MPT node type")
+	}
+	return nil
+}
+
 // getWithPath returns value the provided path in a subtrie rooting in curr.
 // It also returns a current node with all hash nodes along the path
 // replaced to their "unhashed" counterparts.
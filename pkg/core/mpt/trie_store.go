@@ -0,0 +1,118 @@
+package mpt
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// TrieStore is a read-only storage.Store serving contract storage items
+// (storage.STStorage prefix) from MPT with the given state root, so that it
+// represents contract storage state at the corresponding height. All other
+// keys are served by the backend store. It's not thread-safe and it's
+// supposed to be wrapped into storage.MemCachedStore for writes.
+type TrieStore struct {
+	trie    *Trie
+	backend storage.Store
+}
+
+// ErrReadOnly is returned from TrieStore modification methods.
+var ErrReadOnly = errors.New("TrieStore is read-only")
+
+var _ storage.Store = (*TrieStore)(nil)
+
+// NewTrieStore returns new TrieStore for the given state root. MPT nodes are
+// read from the backend, so they should be present there (which is not the
+// case for old roots if only the latest state is kept).
+func NewTrieStore(root util.Uint256, backend storage.Store) *TrieStore {
+	tr := NewTrie(NewHashNode(root), false, storage.NewMemCachedStore(backend))
+	return &TrieStore{trie: tr, backend: backend}
+}
+
+// Batch implements storage.Store interface, it returns a batch that can't be
+// applied to TrieStore.
+func (s *TrieStore) Batch() storage.Batch {
+	return s.backend.Batch()
+}
+
+// Delete implements storage.Store interface, it always returns ErrReadOnly.
+func (s *TrieStore) Delete(k []byte) error {
+	return ErrReadOnly
+}
+
+// Get implements storage.Store interface.
+func (s *TrieStore) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 || key[0] != byte(storage.STStorage) {
+		return s.backend.Get(key)
+	}
+	res, err := s.trie.Get(key[1:])
+	if errors.Is(err, ErrNotFound) {
+		// Mimic the real storage behaviour.
+		return nil, storage.ErrKeyNotFound
+	}
+	return res, err
+}
+
+// Put implements storage.Store interface, it always returns ErrReadOnly.
+func (s *TrieStore) Put(k, v []byte) error {
+	return ErrReadOnly
+}
+
+// PutBatch implements storage.Store interface, it always returns ErrReadOnly.
+func (s *TrieStore) PutBatch(storage.Batch) error {
+	return ErrReadOnly
+}
+
+// Seek implements storage.Store interface. Contract storage items are
+// iterated in ascending key order. Errors (like missing MPT nodes) can't be
+// returned from Seek, so they stop the iteration.
+func (s *TrieStore) Seek(key []byte, f func(k, v []byte)) {
+	if !isStorageSeek(key) {
+		s.backend.Seek(key, f)
+		return
+	}
+	kvs, _ := s.find(key)
+	for _, kv := range kvs {
+		f(kv.Key, kv.Value)
+	}
+}
+
+// SeekReverse implements storage.Store interface.
+func (s *TrieStore) SeekReverse(key []byte, f func(k, v []byte) bool) {
+	if !isStorageSeek(key) {
+		s.backend.SeekReverse(key, f)
+		return
+	}
+	kvs, _ := s.find(key)
+	for i := len(kvs) - 1; i >= 0; i-- {
+		if !f(kvs[i].Key, kvs[i].Value) {
+			return
+		}
+	}
+}
+
+// find returns contract storage items with the given prefixed key.
+func (s *TrieStore) find(key []byte) ([]storage.KeyValue, error) {
+	kvs, err := s.trie.Find(key[1:])
+	for i := range kvs {
+		kvs[i].Key = append([]byte{byte(storage.STStorage)}, kvs[i].Key...)
+	}
+	return kvs, err
+}
+
+// isStorageSeek checks whether the given seek prefix is for contract storage
+// items.
+func isStorageSeek(key []byte) bool {
+	return len(key) != 0 && key[0] == byte(storage.STStorage)
+}
+
+// Compact implements storage.Store interface.
+func (s *TrieStore) Compact() error {
+	return storage.ErrCompactionNotSupported
+}
+
+// Close implements storage.Store interface, it doesn't close the backend.
+func (s *TrieStore) Close() error {
+	return nil
+}
@@ -0,0 +1,101 @@
+package mpt
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrie_Find(t *testing.T) {
+	tr := newTestTrie(t)
+	single := NewTrie(NewHashNode(tr.root.Hash()), false, tr.Store)
+
+	check := func(t *testing.T, prefix []byte, expected ...storage.KeyValue) {
+		res, err := single.Find(prefix)
+		require.NoError(t, err)
+		require.Equal(t, expected, res)
+	}
+	t.Run("all", func(t *testing.T) {
+		check(t, nil,
+			storage.KeyValue{Key: []byte{0xAC, 0x01}, Value: []byte{0xAB, 0xCD}},
+			storage.KeyValue{Key: []byte{0xAC, 0x99}, Value: []byte{0x22, 0x22}},
+			storage.KeyValue{Key: []byte{0xAC, 0xAE}, Value: []byte("hello")})
+	})
+	t.Run("prefix", func(t *testing.T) {
+		check(t, []byte{0xAC, 0x99},
+			storage.KeyValue{Key: []byte{0xAC, 0x99}, Value: []byte{0x22, 0x22}})
+	})
+	t.Run("missing", func(t *testing.T) {
+		check(t, []byte{0xAC, 0x98})
+		check(t, []byte{0xAD})
+	})
+	t.Run("value in branch", func(t *testing.T) {
+		tr := NewTrie(nil, false, newTestStore())
+		require.NoError(t, tr.Put([]byte{0x01}, []byte{1}))
+		require.NoError(t, tr.Put([]byte{0x01, 0x02}, []byte{2}))
+		require.NoError(t, tr.Put([]byte{0x01, 0x03}, []byte{3}))
+		require.NoError(t, tr.Put([]byte{0x02}, []byte{4}))
+		res, err := tr.Find([]byte{0x01})
+		require.NoError(t, err)
+		require.Equal(t, []storage.KeyValue{
+			{Key: []byte{0x01}, Value: []byte{1}},
+			{Key: []byte{0x01, 0x02}, Value: []byte{2}},
+			{Key: []byte{0x01, 0x03}, Value: []byte{3}},
+		}, res)
+	})
+}
+
+func TestTrieStore(t *testing.T) {
+	backend := storage.NewMemoryStore()
+	tr := NewTrie(nil, false, storage.NewMemCachedStore(backend))
+	require.NoError(t, tr.Put([]byte{1, 2}, []byte{3}))
+	require.NoError(t, tr.Put([]byte{1, 3}, []byte{4}))
+	tr.Flush()
+	_, err := tr.Store.Persist()
+	require.NoError(t, err)
+	root := tr.StateRoot()
+
+	// Newer state must not be visible.
+	tr = NewTrie(NewHashNode(root), false, storage.NewMemCachedStore(backend))
+	require.NoError(t, tr.Put([]byte{1, 4}, []byte{5}))
+	tr.Flush()
+	_, err = tr.Store.Persist()
+	require.NoError(t, err)
+	require.NoError(t, backend.Put([]byte{byte(storage.STStorage), 1, 2}, []byte{42}))
+	require.NoError(t, backend.Put([]byte{byte(storage.DataBlock), 1}, []byte{43}))
+
+	s := NewTrieStore(root, backend)
+	stKey := func(k ...byte) []byte { return append([]byte{byte(storage.STStorage)}, k...) }
+
+	v, err := s.Get(stKey(1, 2))
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, v)
+	_, err = s.Get(stKey(1, 4))
+	require.Equal(t, storage.ErrKeyNotFound, err)
+	v, err = s.Get([]byte{byte(storage.DataBlock), 1})
+	require.NoError(t, err)
+	require.Equal(t, []byte{43}, v)
+
+	var keys [][]byte
+	s.Seek(stKey(1), func(k, v []byte) { keys = append(keys, k) })
+	require.Equal(t, [][]byte{stKey(1, 2), stKey(1, 3)}, keys)
+
+	keys = keys[:0]
+	s.SeekReverse(stKey(1), func(k, v []byte) bool {
+		keys = append(keys, k)
+		return false
+	})
+	require.Equal(t, [][]byte{stKey(1, 3)}, keys)
+
+	require.Equal(t, ErrReadOnly, s.Put(stKey(1, 2), []byte{1}))
+	require.Equal(t, ErrReadOnly, s.Delete(stKey(1, 2)))
+
+	t.Run("cached writes", func(t *testing.T) {
+		c := storage.NewMemCachedStore(s)
+		require.NoError(t, c.Put(stKey(1, 5), []byte{6}))
+		var keys [][]byte
+		c.Seek(stKey(1), func(k, v []byte) { keys = append(keys, k) })
+		require.ElementsMatch(t, [][]byte{stKey(1, 2), stKey(1, 3), stKey(1, 5)}, keys)
+	})
+}
@@ -56,7 +56,7 @@ func (cs *Contracts) ByName(name string) interop.Contract {
 
 // NewContracts returns new set of native contracts with new GAS, NEO, Policy, Oracle,
 // Designate and (optional) Notary contracts.
-func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string][]uint32) *Contracts {
+func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string][]uint32, policyMaxChangePercent uint32) *Contracts {
 	cs := new(Contracts)
 
 	mgmt := newManagement()
@@ -86,7 +86,7 @@ func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string
 	cs.Contracts = append(cs.Contracts, neo)
 	cs.Contracts = append(cs.Contracts, gas)
 
-	policy := newPolicy()
+	policy := newPolicy(policyMaxChangePercent)
 	policy.NEO = neo
 	cs.Policy = policy
 	cs.Contracts = append(cs.Contracts, policy)
@@ -16,7 +16,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
-	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -178,7 +177,7 @@ func (s *Designate) hashFromNodes(r noderoles.Role, nodes keys.PublicKeys) util.
 	default:
 		script, _ = smartcontract.CreateDefaultMultiSigRedeemScript(nodes.Copy())
 	}
-	return hash.Hash160(script)
+	return smartcontract.ScriptHash(script)
 }
 
 func (s *Designate) updateCachedRoleData(v *atomic.Value, d dao.DAO, r noderoles.Role) error {
@@ -332,7 +331,7 @@ func (s *Designate) DesignateAsRole(ic *interop.Context, r noderoles.Role, pubs
 		return err
 	}
 
-	ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+	return ic.AddNotification(state.NotificationEvent{
 		ScriptHash: s.Hash,
 		Name:       DesignationEventName,
 		Item: stackitem.NewArray([]stackitem.Item{
@@ -340,7 +339,6 @@ func (s *Designate) DesignateAsRole(ic *interop.Context, r noderoles.Role, pubs
 			stackitem.NewBigInteger(big.NewInt(int64(ic.Block.Index))),
 		}),
 	})
-	return nil
 }
 
 func (s *Designate) getRole(item stackitem.Item) (noderoles.Role, bool) {
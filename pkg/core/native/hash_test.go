@@ -0,0 +1,31 @@
+package native
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+// Native contract hashes are script hashes of the deployment script built
+// from zero sender, zero NEF checksum and contract name.
+func TestNativeScriptHash(t *testing.T) {
+	cs := NewContracts(true, map[string][]uint32{}, 0)
+	for _, name := range []string{nativenames.Neo, nativenames.Gas} {
+		md := cs.ByName(name).Metadata()
+
+		w := io.NewBufBinWriter()
+		emit.Opcodes(w.BinWriter, opcode.ABORT)
+		emit.Bytes(w.BinWriter, util.Uint160{}.BytesBE())
+		emit.Int(w.BinWriter, 0)
+		emit.String(w.BinWriter, md.Name)
+		require.NoError(t, w.Err)
+
+		require.Equal(t, md.Hash, smartcontract.ScriptHash(w.Bytes()), name)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"sync"
 	"unicode/utf8"
 
@@ -149,10 +150,12 @@ func (m *Management) GetContract(d dao.DAO, hash util.Uint160) (*state.Contract,
 	} else if cs != nil {
 		return cs, nil
 	}
-	return m.getContractFromDAO(d, hash)
+	return m.GetContractFromDAO(d, hash)
 }
 
-func (m *Management) getContractFromDAO(d dao.DAO, hash util.Uint160) (*state.Contract, error) {
+// GetContractFromDAO returns contract state from the given DAO bypassing
+// contract cache, so it can be used with historical state.
+func (m *Management) GetContractFromDAO(d dao.DAO, hash util.Uint160) (*state.Contract, error) {
 	contract := new(state.Contract)
 	key := makeContractKey(hash)
 	err := getSerializableFromDAO(m.ID, d, key, contract)
@@ -162,6 +165,39 @@ func (m *Management) getContractFromDAO(d dao.DAO, hash util.Uint160) (*state.Co
 	return contract, nil
 }
 
+// ListContracts returns all deployed (non-native) contracts from the given
+// DAO sorted by ID. Contracts are taken from the cache, so only the ones
+// updated in the current block are read from the DAO.
+func (m *Management) ListContracts(d dao.DAO) ([]*state.Contract, error) {
+	var updated []util.Uint160
+
+	m.mtx.RLock()
+	res := make([]*state.Contract, 0, len(m.contracts))
+	for h, cs := range m.contracts {
+		if cs == nil {
+			updated = append(updated, h)
+		} else if cs.ID >= 0 { // Native contracts are stored here too.
+			res = append(res, cs)
+		}
+	}
+	m.mtx.RUnlock()
+
+	for _, h := range updated {
+		cs, err := m.GetContractFromDAO(d, h)
+		if err != nil {
+			if errors.Is(err, storage.ErrKeyNotFound) { // Destroyed.
+				continue
+			}
+			return nil, err
+		}
+		if cs.ID >= 0 {
+			res = append(res, cs)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
 func getLimitedSlice(arg stackitem.Item, max int) ([]byte, error) {
 	_, isNull := arg.(stackitem.Null)
 	if isNull {
@@ -452,7 +488,19 @@ func (m *Management) OnPersist(ic *interop.Context) error {
 	for _, native := range ic.Natives {
 		md := native.Metadata()
 		history := md.UpdateHistory
-		if len(history) == 0 || history[0] != ic.Block.Index {
+		if len(history) == 0 {
+			continue
+		}
+		if history[0] != ic.Block.Index {
+			// Storage layout can only be changed on contract updates.
+			for _, h := range history[1:] {
+				if h == ic.Block.Index {
+					if err := m.migrate(ic.DAO, native); err != nil {
+						return err
+					}
+					break
+				}
+			}
 			continue
 		}
 
@@ -466,6 +514,9 @@ func (m *Management) OnPersist(ic *interop.Context) error {
 		if err := native.Initialize(ic); err != nil {
 			return fmt.Errorf("initializing %s native contract: %w", md.Name, err)
 		}
+		if err := m.initSchemaVersion(ic.DAO, native); err != nil {
+			return err
+		}
 		m.mtx.Lock()
 		m.contracts[md.Hash] = cs
 		m.mtx.Unlock()
@@ -502,7 +553,7 @@ func (m *Management) PostPersist(ic *interop.Context) error {
 		if cs != nil {
 			continue
 		}
-		newCs, err := m.getContractFromDAO(ic.DAO, h)
+		newCs, err := m.GetContractFromDAO(ic.DAO, h)
 		if err != nil {
 			// Contract was destroyed.
 			delete(m.contracts, h)
@@ -553,7 +604,9 @@ func (m *Management) emitNotification(ic *interop.Context, name string, hash uti
 		Name:       name,
 		Item:       stackitem.NewArray([]stackitem.Item{addrToStackItem(&hash)}),
 	}
-	ic.Notifications = append(ic.Notifications, ne)
+	if err := ic.AddNotification(ne); err != nil {
+		panic(err)
+	}
 }
 
 func checkScriptAndMethods(script []byte, methods []manifest.Method) error {
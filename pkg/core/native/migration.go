@@ -0,0 +1,71 @@
+package native
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+)
+
+// prefixSchemaVersion is a Management contract storage prefix used to store
+// native contracts storage schema versions.
+const prefixSchemaVersion = 16
+
+// Migration transforms native contract storage from one schema version to
+// the next one.
+type Migration func(d dao.DAO) error
+
+// Migrator is implemented by native contracts changing their storage layout
+// between versions.
+type Migrator interface {
+	// Migrations returns the list of storage migrations, i-th element
+	// upgrades storage from version i to version i+1, so the number of
+	// migrations is the current schema version.
+	Migrations() []Migration
+}
+
+// getSchemaVersion returns storage schema version of the given native
+// contract, contracts initialized without a version have version 0.
+func (m *Management) getSchemaVersion(d dao.DAO, c interop.Contract) uint32 {
+	si := d.GetStorageItem(m.ID, makeUint160Key(prefixSchemaVersion, c.Metadata().Hash))
+	if si == nil {
+		return 0
+	}
+	return uint32(bigint.FromBytes(si).Int64())
+}
+
+func (m *Management) putSchemaVersion(d dao.DAO, c interop.Contract, version uint32) error {
+	key := makeUint160Key(prefixSchemaVersion, c.Metadata().Hash)
+	return d.PutStorageItem(m.ID, key, bigint.ToBytes(big.NewInt(int64(version))))
+}
+
+// initSchemaVersion stores the latest schema version for just initialized
+// native contract, its storage doesn't need to be migrated.
+func (m *Management) initSchemaVersion(d dao.DAO, c interop.Contract) error {
+	mg, ok := c.(Migrator)
+	if !ok {
+		return nil
+	}
+	return m.putSchemaVersion(d, c, uint32(len(mg.Migrations())))
+}
+
+// migrate applies all pending storage migrations of the given native contract.
+func (m *Management) migrate(d dao.DAO, c interop.Contract) error {
+	mg, ok := c.(Migrator)
+	if !ok {
+		return nil
+	}
+	migrations := mg.Migrations()
+	version := m.getSchemaVersion(d, c)
+	if int(version) >= len(migrations) {
+		return nil
+	}
+	for ; int(version) < len(migrations); version++ {
+		if err := migrations[version](d); err != nil {
+			return fmt.Errorf("migrating %s storage from version %d: %w", c.Metadata().Name, version, err)
+		}
+	}
+	return m.putSchemaVersion(d, c, version)
+}
@@ -0,0 +1,95 @@
+package native
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+type testMigrator struct {
+	interop.ContractMD
+	migrations []Migration
+}
+
+func (c *testMigrator) Metadata() *interop.ContractMD      { return &c.ContractMD }
+func (c *testMigrator) OnPersist(*interop.Context) error   { return nil }
+func (c *testMigrator) PostPersist(*interop.Context) error { return nil }
+func (c *testMigrator) Migrations() []Migration            { return c.migrations }
+func (c *testMigrator) Initialize(ic *interop.Context) error {
+	// Initial (version 0) layout stores value without prefix.
+	return ic.DAO.PutStorageItem(c.ID, []byte("key"), []byte("value"))
+}
+
+func TestManagement_Migrate(t *testing.T) {
+	mgmt := newManagement()
+	d := dao.NewCached(dao.NewSimple(storage.NewMemoryStore(), false))
+	require.NoError(t, mgmt.Initialize(&interop.Context{DAO: d}))
+
+	c := &testMigrator{ContractMD: *interop.NewContractMD("Test", -100)}
+	c.UpdateHistory = []uint32{0, 5}
+
+	persist := func(t *testing.T, index uint32) error {
+		return mgmt.OnPersist(&interop.Context{
+			DAO:     d,
+			Block:   &block.Block{Header: block.Header{Index: index}},
+			Natives: []interop.Contract{c},
+		})
+	}
+
+	require.NoError(t, persist(t, 0))
+	require.Equal(t, uint32(0), mgmt.getSchemaVersion(d, c))
+	require.Equal(t, []byte("value"), []byte(d.GetStorageItem(c.ID, []byte("key"))))
+
+	var calls int
+	c.migrations = []Migration{func(d dao.DAO) error {
+		calls++
+		v := d.GetStorageItem(c.ID, []byte("key"))
+		if v == nil {
+			return errors.New("no value")
+		}
+		if err := d.DeleteStorageItem(c.ID, []byte("key")); err != nil {
+			return err
+		}
+		return d.PutStorageItem(c.ID, append([]byte{1}, "key"...), v)
+	}}
+
+	// Migrations are applied only on contract updates.
+	require.NoError(t, persist(t, 1))
+	require.Equal(t, 0, calls)
+
+	require.NoError(t, persist(t, 5))
+	require.Equal(t, 1, calls)
+	require.Equal(t, uint32(1), mgmt.getSchemaVersion(d, c))
+	require.Nil(t, d.GetStorageItem(c.ID, []byte("key")))
+	require.Equal(t, []byte("value"), []byte(d.GetStorageItem(c.ID, append([]byte{1}, "key"...))))
+
+	t.Run("already migrated", func(t *testing.T) {
+		require.NoError(t, persist(t, 5))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c.migrations = append(c.migrations, func(dao.DAO) error { return errors.New("bad") })
+		require.Error(t, persist(t, 5))
+		require.Equal(t, uint32(1), mgmt.getSchemaVersion(d, c))
+	})
+
+	t.Run("fresh contract", func(t *testing.T) {
+		fresh := &testMigrator{
+			ContractMD: *interop.NewContractMD("Fresh", -101),
+			migrations: c.migrations,
+		}
+		fresh.UpdateHistory = []uint32{7}
+		require.NoError(t, mgmt.OnPersist(&interop.Context{
+			DAO:     d,
+			Block:   &block.Block{Header: block.Header{Index: 7}},
+			Natives: []interop.Contract{fresh},
+		}))
+		require.Equal(t, uint32(2), mgmt.getSchemaVersion(d, fresh))
+	})
+}
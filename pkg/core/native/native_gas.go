@@ -9,7 +9,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
-	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -142,5 +141,5 @@ func getStandbyValidatorsHash(ic *interop.Context) (util.Uint160, error) {
 	if err != nil {
 		return util.Uint160{}, err
 	}
-	return hash.Hash160(s), nil
+	return smartcontract.ScriptHash(s), nil
 }
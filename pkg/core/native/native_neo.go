@@ -17,7 +17,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
-	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -254,7 +253,7 @@ func (n *NEO) updateCache(cvs keysWithVotes, bc blockchainer.Blockchainer) error
 	if err != nil {
 		return err
 	}
-	n.committeeHash.Store(hash.Hash160(script))
+	n.committeeHash.Store(smartcontract.ScriptHash(script))
 
 	nextVals := committee[:bc.GetConfig().ValidatorsCount].Copy()
 	sort.Sort(nextVals)
@@ -848,6 +847,35 @@ func (n *NEO) GetCandidates(d dao.DAO) ([]state.Validator, error) {
 	return arr, nil
 }
 
+// GetVoters returns all NEO holders that have voted for some candidate.
+func (n *NEO) GetVoters(d dao.DAO) ([]state.Voter, error) {
+	var (
+		res []state.Voter
+		err error
+	)
+	d.Seek(n.ID, []byte{prefixAccount}, func(k, v []byte) {
+		if err != nil {
+			return
+		}
+		var acc *state.NEOBalanceState
+		acc, err = state.NEOBalanceStateFromBytes(v)
+		if err != nil || acc.VoteTo == nil || acc.Balance.Sign() == 0 {
+			return
+		}
+		var h util.Uint160
+		h, err = util.Uint160DecodeBytesBE(k)
+		if err != nil {
+			return
+		}
+		res = append(res, state.Voter{Account: h, Candidate: acc.VoteTo, Votes: &acc.Balance})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Account.Less(res[j].Account) })
+	return res, nil
+}
+
 func (n *NEO) getCandidatesCall(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
 	validators, err := n.getCandidates(ic.DAO, true)
 	if err != nil {
@@ -160,7 +160,9 @@ func (c *nep17TokenNative) emitTransfer(ic *interop.Context, from, to *util.Uint
 			stackitem.NewBigInteger(amount),
 		}),
 	}
-	ic.Notifications = append(ic.Notifications, ne)
+	if err := ic.AddNotification(ne); err != nil {
+		panic(err)
+	}
 }
 
 func (c *nep17TokenNative) updateAccBalance(ic *interop.Context, acc util.Uint160, amount *big.Int) error {
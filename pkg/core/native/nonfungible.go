@@ -281,7 +281,9 @@ func (n *nonfungible) postTransfer(ic *interop.Context, from, to *util.Uint160,
 			stackitem.NewByteArray(tokenID),
 		}),
 	}
-	ic.Notifications = append(ic.Notifications, ne)
+	if err := ic.AddNotification(ne); err != nil {
+		panic(err)
+	}
 	if to == nil {
 		return
 	}
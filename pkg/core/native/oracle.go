@@ -268,7 +268,7 @@ func (o *Oracle) FinishInternal(ic *interop.Context) error {
 		return ErrRequestNotFound
 	}
 
-	ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+	err = ic.AddNotification(state.NotificationEvent{
 		ScriptHash: o.Hash,
 		Name:       "OracleResponse",
 		Item: stackitem.NewArray([]stackitem.Item{
@@ -276,6 +276,9 @@ func (o *Oracle) FinishInternal(ic *interop.Context) error {
 			stackitem.Make(req.OriginalTxID.BytesBE()),
 		}),
 	})
+	if err != nil {
+		return err
+	}
 
 	r := io.NewBinReaderFromBuf(req.UserData)
 	userData := stackitem.DecodeBinaryStackItem(r)
@@ -373,7 +376,7 @@ func (o *Oracle) RequestInternal(ic *interop.Context, url string, filter *string
 	} else {
 		filterNotif = stackitem.Null{}
 	}
-	ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+	err = ic.AddNotification(state.NotificationEvent{
 		ScriptHash: o.Hash,
 		Name:       "OracleRequest",
 		Item: stackitem.NewArray([]stackitem.Item{
@@ -383,6 +386,9 @@ func (o *Oracle) RequestInternal(ic *interop.Context, url string, filter *string
 			filterNotif,
 		}),
 	})
+	if err != nil {
+		return err
+	}
 	req := &state.OracleRequest{
 		OriginalTxID:     o.getOriginalTxID(ic.DAO, ic.Tx),
 		GasForResponse:   gas.Uint64(),
@@ -62,13 +62,21 @@ type Policy struct {
 	maxVerificationGas int64
 	storagePrice       uint32
 	blockedAccounts    []util.Uint160
+	// maxChangePercent limits a single-step change of policy values
+	// (in percents of the current value), 0 means no limit.
+	maxChangePercent uint32
 }
 
 var _ interop.Contract = (*Policy)(nil)
 
-// newPolicy returns Policy native contract.
-func newPolicy() *Policy {
-	p := &Policy{ContractMD: *interop.NewContractMD(nativenames.Policy, policyContractID)}
+// newPolicy returns Policy native contract. If maxChangePercent is not 0,
+// setters reject changes exceeding the given percent of the current value
+// unless called with an additional `force` parameter.
+func newPolicy(maxChangePercent uint32) *Policy {
+	p := &Policy{
+		ContractMD:       *interop.NewContractMD(nativenames.Policy, policyContractID),
+		maxChangePercent: maxChangePercent,
+	}
 	defer p.UpdateHash()
 
 	desc := newDescriptor("getFeePerByte", smartcontract.IntegerType)
@@ -113,6 +121,20 @@ func newPolicy() *Policy {
 	md = newMethodAndPrice(p.unblockAccount, 1<<15, callflag.States)
 	p.AddMethod(md, desc)
 
+	if maxChangePercent != 0 {
+		for name, f := range map[string]interop.Method{
+			"setExecFeeFactor": p.setExecFeeFactor,
+			"setStoragePrice":  p.setStoragePrice,
+			"setFeePerByte":    p.setFeePerByte,
+		} {
+			desc = newDescriptor(name, smartcontract.VoidType,
+				manifest.NewParameter("value", smartcontract.IntegerType),
+				manifest.NewParameter("force", smartcontract.BoolType))
+			md = newMethodAndPrice(f, 1<<15, callflag.States)
+			p.AddMethod(md, desc)
+		}
+	}
+
 	return p
 }
 
@@ -227,6 +249,7 @@ func (p *Policy) setExecFeeFactor(ic *interop.Context, args []stackitem.Item) st
 	if !p.NEO.checkCommittee(ic) {
 		panic("invalid committee signature")
 	}
+	p.checkChange("ExecFeeFactor", p.GetExecFeeFactorInternal(ic.DAO), int64(value), args)
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	err := setIntWithKey(p.ID, ic.DAO, execFeeFactorKey, int64(value))
@@ -283,6 +306,7 @@ func (p *Policy) setStoragePrice(ic *interop.Context, args []stackitem.Item) sta
 	if !p.NEO.checkCommittee(ic) {
 		panic("invalid committee signature")
 	}
+	p.checkChange("StoragePrice", p.GetStoragePriceInternal(ic.DAO), int64(value), args)
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	err := setIntWithKey(p.ID, ic.DAO, storagePriceKey, int64(value))
@@ -302,6 +326,7 @@ func (p *Policy) setFeePerByte(ic *interop.Context, args []stackitem.Item) stack
 	if !p.NEO.checkCommittee(ic) {
 		panic("invalid committee signature")
 	}
+	p.checkChange("FeePerByte", p.GetFeePerByteInternal(ic.DAO), value, args)
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	err := setIntWithKey(p.ID, ic.DAO, feePerByteKey, value)
@@ -312,6 +337,31 @@ func (p *Policy) setFeePerByte(ic *interop.Context, args []stackitem.Item) stack
 	return stackitem.Null{}
 }
 
+// checkChange panics if the change from old to value exceeds maxChangePercent
+// of the old value. The check is skipped if there is no limit or if `force`
+// argument is passed and set to true.
+func (p *Policy) checkChange(name string, old, value int64, args []stackitem.Item) {
+	if p.maxChangePercent == 0 {
+		return
+	}
+	if len(args) > 1 {
+		force, err := args[1].TryBool()
+		if err != nil {
+			panic(err)
+		}
+		if force {
+			return
+		}
+	}
+	diff := new(big.Int).Sub(big.NewInt(value), big.NewInt(old))
+	diff.Abs(diff).Mul(diff, big.NewInt(100))
+	limit := new(big.Int).Mul(big.NewInt(old), big.NewInt(int64(p.maxChangePercent)))
+	if diff.Cmp(limit) > 0 {
+		panic(fmt.Errorf("%s can't be changed by more than %d%% of the current value (%d) at once",
+			name, p.maxChangePercent, old))
+	}
+}
+
 // blockAccount is Policy contract method and adds given account hash to the list
 // of blocked accounts.
 func (p *Policy) blockAccount(ic *interop.Context, args []stackitem.Item) stackitem.Item {
@@ -545,6 +545,27 @@ func TestGetContract(t *testing.T) {
 	})
 }
 
+func TestListContracts(t *testing.T) {
+	bc := newTestChain(t)
+
+	css, err := bc.ListContracts()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(css))
+
+	cs1, cs2 := getTestContractState(bc)
+	require.NoError(t, bc.contracts.Management.PutContractState(bc.dao, cs2))
+	require.NoError(t, bc.contracts.Management.PutContractState(bc.dao, cs1))
+
+	css, err = bc.ListContracts()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(css))
+	for i, cs := range []*state.Contract{cs1, cs2} {
+		require.Equal(t, cs.ID, css[i].ID)
+		require.Equal(t, cs.Hash, css[i].Hash)
+		require.Equal(t, cs.NEF, css[i].NEF)
+	}
+}
+
 func TestContractDestroy(t *testing.T) {
 	bc := newTestChain(t)
 
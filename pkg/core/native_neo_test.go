@@ -124,6 +124,22 @@ func TestNEO_Vote(t *testing.T) {
 
 	_, err = ic.DAO.Persist()
 	require.NoError(t, err)
+
+	voters, err := neo.GetVoters(bc.dao)
+	require.NoError(t, err)
+	require.Equal(t, sz, len(voters))
+	for i := range accs {
+		var found bool
+		for _, v := range voters {
+			if v.Account.Equals(accs[i].Contract.ScriptHash()) {
+				require.Equal(t, candidates[i], v.Candidate)
+				require.Equal(t, int64(sz-i)*1000000, v.Votes.Int64())
+				found = true
+			}
+		}
+		require.True(t, found)
+	}
+
 	advanceChain(t)
 	pubs, err = neo.ComputeNextBlockValidators(bc, ic.DAO)
 	require.NoError(t, err)
@@ -1,14 +1,19 @@
 package core
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
@@ -89,6 +94,27 @@ func TestFeePerByte(t *testing.T) {
 	testGetSet(t, chain, chain.contracts.Policy.Hash, "FeePerByte", 1000, 0, 100_000_000)
 }
 
+func TestInvokeMethod(t *testing.T) {
+	bc := newTestChain(t)
+
+	aer, err := testchain.InvokeMethod(bc, false, bc.contracts.Policy.Hash, "getFeePerByte")
+	require.NoError(t, err)
+	checkResult(t, aer, stackitem.Make(bc.FeePerByte()))
+
+	t.Run("committee", func(t *testing.T) {
+		transferFundsToCommittee(t, bc)
+		aer, err := testchain.InvokeMethod(bc, true, bc.contracts.Policy.Hash, "setFeePerByte", int64(2000))
+		require.NoError(t, err)
+		require.Equal(t, vm.HaltState, aer.VMState)
+		require.Equal(t, int64(2000), bc.FeePerByte())
+	})
+	t.Run("not a committee", func(t *testing.T) {
+		aer, err := testchain.InvokeMethod(bc, false, bc.contracts.Policy.Hash, "setFeePerByte", int64(3000))
+		require.NoError(t, err)
+		checkFAULTState(t, aer)
+	})
+}
+
 func TestExecFeeFactor(t *testing.T) {
 	chain := newTestChain(t)
 
@@ -111,6 +137,55 @@ func TestStoragePrice(t *testing.T) {
 	testGetSet(t, chain, chain.contracts.Policy.Hash, "StoragePrice", native.DefaultStoragePrice, 1, 10000000)
 }
 
+func TestPolicyMaxChangePercent(t *testing.T) {
+	chain := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.MaxPolicyChangePercent = 10
+	})
+	policyHash := chain.contracts.Policy.Hash
+	transferFundsToCommittee(t, chain)
+
+	checkFeePerByte := func(t *testing.T, expected int64) {
+		res, err := invokeContractMethod(chain, 100000000, policyHash, "getFeePerByte")
+		require.NoError(t, err)
+		checkResult(t, res, stackitem.Make(expected))
+	}
+
+	t.Run("within allowed step", func(t *testing.T) {
+		res, err := invokeContractMethodGeneric(chain, 100000000, policyHash, "setFeePerByte", true, int64(1100))
+		require.NoError(t, err)
+		checkResult(t, res, stackitem.Null{})
+		checkFeePerByte(t, 1100)
+	})
+	t.Run("beyond allowed step", func(t *testing.T) {
+		res, err := invokeContractMethodGeneric(chain, 100000000, policyHash, "setFeePerByte", true, int64(1300))
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+
+		res, err = invokeContractMethodGeneric(chain, 100000000, policyHash, "setStoragePrice", true, int64(native.DefaultStoragePrice/2))
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+		checkFeePerByte(t, 1100)
+	})
+	t.Run("beyond allowed step, no force", func(t *testing.T) {
+		res, err := invokeContractMethodGeneric(chain, 100000000, policyHash, "setFeePerByte", true, int64(1300), false)
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+	})
+	t.Run("beyond allowed step, force", func(t *testing.T) {
+		res, err := invokeContractMethodGeneric(chain, 100000000, policyHash, "setFeePerByte", true, int64(1300), true)
+		require.NoError(t, err)
+		checkResult(t, res, stackitem.Null{})
+		checkFeePerByte(t, 1300)
+	})
+	t.Run("force, not signed by committee", func(t *testing.T) {
+		signer, err := wallet.NewAccount()
+		require.NoError(t, err)
+		res, err := invokeContractMethodBy(t, chain, signer, policyHash, "setFeePerByte", int64(1300), true)
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+	})
+}
+
 func TestBlockedAccounts(t *testing.T) {
 	chain := newTestChain(t)
 	account := util.Uint160{1, 2, 3}
@@ -187,3 +262,46 @@ func TestBlockedAccounts(t *testing.T) {
 		checkFAULTState(t, invokeRes)
 	})
 }
+
+func TestBlockedAccountsPoolTx(t *testing.T) {
+	chain := newTestChain(t)
+	policyHash := chain.contracts.Policy.Metadata().Hash
+
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	transferTokenFromMultisigAccountCheckOK(t, chain, acc.Contract.ScriptHash(),
+		chain.contracts.GAS.Hash, 100_00000000)
+	transferFundsToCommittee(t, chain)
+
+	newTx := func(t *testing.T) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 1
+		signTxWithAccounts(chain, tx, acc)
+		return tx
+	}
+
+	res, err := invokeContractMethodGeneric(chain, 100000000, policyHash, "blockAccount", true, acc.Contract.ScriptHash().BytesBE())
+	require.NoError(t, err)
+	checkResult(t, res, stackitem.NewBool(true))
+
+	t.Run("sender", func(t *testing.T) {
+		err := chain.PoolTx(newTx(t))
+		require.True(t, errors.Is(err, ErrPolicy), err)
+	})
+	t.Run("cosigner", func(t *testing.T) {
+		other, err := wallet.NewAccount()
+		require.NoError(t, err)
+		transferTokenFromMultisigAccountCheckOK(t, chain, other.Contract.ScriptHash(),
+			chain.contracts.GAS.Hash, 100_00000000)
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 1
+		signTxWithAccounts(chain, tx, other, acc)
+		err = chain.PoolTx(tx)
+		require.True(t, errors.Is(err, ErrPolicy), err)
+	})
+
+	res, err = invokeContractMethodGeneric(chain, 100000000, policyHash, "unblockAccount", true, acc.Contract.ScriptHash().BytesBE())
+	require.NoError(t, err)
+	checkResult(t, res, stackitem.NewBool(true))
+	require.NoError(t, chain.PoolTx(newTx(t)))
+}
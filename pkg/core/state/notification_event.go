@@ -63,6 +63,7 @@ func (aer *AppExecResult) EncodeBinary(w *io.BinWriter) {
 	}
 	w.WriteArray(aer.Events)
 	w.WriteVarBytes([]byte(aer.FaultException))
+	w.WriteArray(aer.Invocations)
 }
 
 // DecodeBinary implements the Serializable interface.
@@ -88,6 +89,11 @@ func (aer *AppExecResult) DecodeBinary(r *io.BinReader) {
 	aer.Stack = arr
 	r.ReadArray(&aer.Events)
 	aer.FaultException = r.ReadString()
+	var invocations []util.Uint160
+	r.ReadArray(&invocations)
+	if len(invocations) != 0 {
+		aer.Invocations = invocations
+	}
 }
 
 // notificationEventAux is an auxiliary struct for NotificationEvent JSON marshalling.
@@ -177,6 +183,9 @@ type Execution struct {
 	Stack          []stackitem.Item
 	Events         []NotificationEvent
 	FaultException string
+	// Invocations is a list of contracts invoked during the execution
+	// (directly or via other contracts), sorted by hash. It's optional.
+	Invocations []util.Uint160
 }
 
 // executionAux represents an auxiliary struct for Execution JSON marshalling.
@@ -187,6 +196,7 @@ type executionAux struct {
 	Stack          json.RawMessage     `json:"stack"`
 	Events         []NotificationEvent `json:"notifications"`
 	FaultException string              `json:"exception,omitempty"`
+	Invocations    []util.Uint160      `json:"invocations,omitempty"`
 }
 
 // MarshalJSON implements implements json.Marshaler interface.
@@ -215,6 +225,7 @@ func (e Execution) MarshalJSON() ([]byte, error) {
 		Stack:          st,
 		Events:         e.Events,
 		FaultException: e.FaultException,
+		Invocations:    e.Invocations,
 	})
 }
 
@@ -254,5 +265,6 @@ func (e *Execution) UnmarshalJSON(data []byte) error {
 	e.Events = aux.Events
 	e.GasConsumed = aux.GasConsumed
 	e.FaultException = aux.FaultException
+	e.Invocations = aux.Invocations
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -46,6 +47,11 @@ func TestEncodeDecodeAppExecResult(t *testing.T) {
 		appExecResult.VMState = vm.FaultState
 		testserdes.EncodeDecodeBinary(t, appExecResult, new(AppExecResult))
 	})
+	t.Run("with invocations", func(t *testing.T) {
+		appExecResult := newAer()
+		appExecResult.Invocations = []util.Uint160{random.Uint160(), random.Uint160()}
+		testserdes.EncodeDecodeBinary(t, appExecResult, new(AppExecResult))
+	})
 	t.Run("with interop", func(t *testing.T) {
 		appExecResult := newAer()
 		appExecResult.Stack = []stackitem.Item{stackitem.NewInterop(nil)}
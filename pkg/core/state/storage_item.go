@@ -2,3 +2,9 @@ package state
 
 // StorageItem is the value to be stored with read-only flag.
 type StorageItem []byte
+
+// StorageItemWithKey is a storage item along with its key.
+type StorageItemWithKey struct {
+	Key  []byte
+	Item StorageItem
+}
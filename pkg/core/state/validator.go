@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 // Validator holds the state of a validator (its key and votes balance).
@@ -11,3 +12,11 @@ type Validator struct {
 	Key   *keys.PublicKey
 	Votes *big.Int
 }
+
+// Voter is a NEO holder voting for some candidate, its votes are equal to its
+// NEO balance.
+type Voter struct {
+	Account   util.Uint160
+	Candidate *keys.PublicKey
+	Votes     *big.Int
+}
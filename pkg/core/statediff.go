@@ -0,0 +1,108 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// StorageDiff is a difference of a single storage item between two states.
+type StorageDiff struct {
+	// Key is a storage item key prefixed with contract ID (the same way it's
+	// stored in MPT).
+	Key []byte
+	// Left is an item value in the first state (nil if it's missing there).
+	Left []byte
+	// Right is an item value in the second state (nil if it's missing there).
+	Right []byte
+}
+
+// ComputeStateRoot calculates MPT root hash of all contract storage items of
+// the given DAO from scratch. For a DAO of a synchronized node it's the same
+// as the local state root at the current height, but it can be used to
+// compare arbitrary states not backed by state root module.
+func ComputeStateRoot(d *dao.Simple) (util.Uint256, error) {
+	var (
+		err error
+		tr  = mpt.NewTrie(nil, false, storage.NewMemCachedStore(storage.NewMemoryStore()))
+	)
+	d.Store.Seek([]byte{byte(storage.STStorage)}, func(k, v []byte) {
+		if err == nil {
+			err = tr.Put(k[1:], copyBytes(v))
+		}
+	})
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return tr.StateRoot(), nil
+}
+
+// GetStateAt returns read-only DAO with contract storage state as it was
+// after the block with the given index was persisted. It's backed by MPT, so
+// it requires old MPT nodes to be kept (KeepOnlyLatestState disabled).
+func (bc *Blockchain) GetStateAt(height uint32) (*dao.Simple, error) {
+	if bc.config.KeepOnlyLatestState {
+		return nil, ErrStateUnavailable
+	}
+	sr, err := bc.stateRoot.GetStateRoot(height)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrStateUnavailable, err)
+	}
+	return dao.NewSimple(mpt.NewTrieStore(sr.Root, bc.dao.Store), bc.config.StateRootInHeader), nil
+}
+
+// DiffStates compares contract storage of two DAOs and returns a list of
+// differing items sorted by key. Items with empty values are treated as
+// missing ones, the same way MPT does.
+func DiffStates(left, right *dao.Simple) []StorageDiff {
+	var (
+		items = getStorageItems(left)
+		res   []StorageDiff
+	)
+	right.Store.Seek([]byte{byte(storage.STStorage)}, func(k, v []byte) {
+		if len(v) == 0 {
+			return
+		}
+		key := string(k[1:])
+		l, ok := items[key]
+		delete(items, key)
+		if ok && bytes.Equal(l, v) {
+			return
+		}
+		res = append(res, StorageDiff{
+			Key:   []byte(key),
+			Left:  l,
+			Right: copyBytes(v),
+		})
+	})
+	for k, v := range items {
+		res = append(res, StorageDiff{Key: []byte(k), Left: v})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].Key, res[j].Key) < 0
+	})
+	return res
+}
+
+// getStorageItems returns all non-empty contract storage items of the DAO with
+// keys stripped of the storage prefix.
+func getStorageItems(d *dao.Simple) map[string][]byte {
+	items := make(map[string][]byte)
+	d.Store.Seek([]byte{byte(storage.STStorage)}, func(k, v []byte) {
+		if len(v) != 0 {
+			items[string(k[1:])] = copyBytes(v)
+		}
+	})
+	return items
+}
+
+func copyBytes(b []byte) []byte {
+	res := make([]byte, len(b))
+	copy(res, b)
+	return res
+}
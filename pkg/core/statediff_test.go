@@ -0,0 +1,136 @@
+package core
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStateRoot(t *testing.T) {
+	bc := newTestChain(t)
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+
+	root, err := ComputeStateRoot(bc.dao)
+	require.NoError(t, err)
+	require.Equal(t, bc.GetStateModule().CurrentLocalStateRoot(), root)
+
+	t.Run("LevelDB", func(t *testing.T) {
+		tmpDir, err := ioutil.TempDir("", "neogo.statediff")
+		require.NoError(t, err)
+		t.Cleanup(func() { os.RemoveAll(tmpDir) })
+
+		opts := storage.LevelDBOptions{DataDirectoryPath: path.Join(tmpDir, "db")}
+		store, err := storage.NewLevelDBStore(opts)
+		require.NoError(t, err)
+
+		// LevelDB iterator reuses value buffer between items, so values
+		// must be copied when building MPT.
+		mem := dao.NewSimple(storage.NewMemoryStore(), false)
+		ldb := dao.NewSimple(store, false)
+		for i := 0; i < 1000; i++ {
+			k := []byte{byte(i), byte(i >> 8)}
+			v := make(state.StorageItem, 100)
+			v[0], v[1] = byte(i), byte(i>>8)
+			require.NoError(t, mem.PutStorageItem(1, k, v))
+			require.NoError(t, ldb.PutStorageItem(1, k, v))
+		}
+		_, err = ldb.Persist()
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		store, err = storage.NewLevelDBStore(opts)
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Close() })
+
+		memRoot, err := ComputeStateRoot(mem)
+		require.NoError(t, err)
+		ldbRoot, err := ComputeStateRoot(dao.NewSimple(store, false))
+		require.NoError(t, err)
+		require.Equal(t, memRoot, ldbRoot)
+		require.Equal(t, 0, len(DiffStates(mem, dao.NewSimple(store, false))))
+	})
+}
+
+func TestGetStateAt(t *testing.T) {
+	bc := newTestChain(t)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, bc.AddBlock(bc.newBlock()))
+	}
+	for i := uint32(0); i <= bc.BlockHeight(); i++ {
+		d, err := bc.GetStateAt(i)
+		require.NoError(t, err)
+		root, err := ComputeStateRoot(d)
+		require.NoError(t, err)
+		sr, err := bc.GetStateModule().GetStateRoot(i)
+		require.NoError(t, err)
+		require.Equal(t, sr.Root, root)
+	}
+	first, err := bc.GetStateAt(0)
+	require.NoError(t, err)
+	last, err := bc.GetStateAt(bc.BlockHeight())
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(DiffStates(first, last)))
+	require.Equal(t, 0, len(DiffStates(last, bc.dao)))
+
+	_, err = bc.GetStateAt(bc.BlockHeight() + 1)
+	require.True(t, errors.Is(err, ErrStateUnavailable), err)
+
+	t.Run("KeepOnlyLatestState", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+			c.ProtocolConfiguration.KeepOnlyLatestState = true
+		})
+		_, err := bc.GetStateAt(0)
+		require.True(t, errors.Is(err, ErrStateUnavailable), err)
+	})
+}
+
+func TestDiffStates(t *testing.T) {
+	newDAO := func() *dao.Simple {
+		d := dao.NewSimple(storage.NewMemoryStore(), false)
+		require.NoError(t, d.PutStorageItem(1, []byte{1}, state.StorageItem{1, 2, 3}))
+		require.NoError(t, d.PutStorageItem(1, []byte{2}, state.StorageItem{4, 5, 6}))
+		require.NoError(t, d.PutStorageItem(2, []byte{1}, state.StorageItem{7}))
+		return d
+	}
+	getRoot := func(d *dao.Simple) string {
+		root, err := ComputeStateRoot(d)
+		require.NoError(t, err)
+		return root.StringLE()
+	}
+
+	left, right := newDAO(), newDAO()
+	require.Equal(t, getRoot(left), getRoot(right))
+	require.Equal(t, 0, len(DiffStates(left, right)))
+
+	t.Run("changed item", func(t *testing.T) {
+		left, right := newDAO(), newDAO()
+		require.NoError(t, right.PutStorageItem(1, []byte{2}, state.StorageItem{4, 5, 7}))
+		require.NotEqual(t, getRoot(left), getRoot(right))
+
+		diff := DiffStates(left, right)
+		require.Equal(t, []StorageDiff{{
+			Key:   []byte{1, 0, 0, 0, 2},
+			Left:  []byte{4, 5, 6},
+			Right: []byte{4, 5, 7},
+		}}, diff)
+	})
+	t.Run("missing items", func(t *testing.T) {
+		left, right := newDAO(), newDAO()
+		require.NoError(t, left.DeleteStorageItem(2, []byte{1}))
+		require.NoError(t, right.DeleteStorageItem(1, []byte{1}))
+
+		diff := DiffStates(left, right)
+		require.Equal(t, []StorageDiff{
+			{Key: []byte{1, 0, 0, 0, 1}, Left: []byte{1, 2, 3}},
+			{Key: []byte{2, 0, 0, 0, 1}, Right: []byte{7}},
+		}, diff)
+	})
+}
@@ -1,7 +1,6 @@
 package stateroot
 
 import (
-	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 )
@@ -9,7 +8,7 @@ import (
 // UpdateStateValidators updates list of state validator keys.
 func (s *Module) UpdateStateValidators(height uint32, pubs keys.PublicKeys) {
 	script, _ := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
-	h := hash.Hash160(script)
+	h := smartcontract.ScriptHash(script)
 
 	s.mtx.Lock()
 	if s.updateValidatorsCb != nil {
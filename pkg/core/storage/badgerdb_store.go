@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"bytes"
 	"os"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // BadgerDBOptions configuration for BadgerDB.
@@ -131,6 +133,47 @@ func (b *BadgerDBStore) Seek(key []byte, f func(k, v []byte)) {
 	}
 }
 
+// SeekReverse implements the Store interface.
+func (b *BadgerDBStore) SeekReverse(key []byte, f func(k, v []byte) bool) {
+	err := b.db.View(func(txn *badger.Txn) error {
+		// Prefix option can't be used here as reverse iterator starts
+		// from the prefix itself.
+		it := txn.NewIterator(badger.IteratorOptions{
+			PrefetchValues: true,
+			PrefetchSize:   100,
+			Reverse:        true,
+		})
+		defer it.Close()
+		// Reverse Seek finds the greatest key not greater than the
+		// given one, so limit itself is to be skipped.
+		limit := util.BytesPrefix(key).Limit
+		it.Seek(limit)
+		if limit != nil && it.Valid() && bytes.Equal(it.Item().Key(), limit) {
+			it.Next()
+		}
+		for ; it.ValidForPrefix(key); it.Next() {
+			item := it.Item()
+			v, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if !f(item.Key(), v) {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Compact implements the Store interface. It flattens LSM tree into a single
+// level.
+func (b *BadgerDBStore) Compact() error {
+	return b.db.Flatten(1)
+}
+
 // Close releases all db resources.
 func (b *BadgerDBStore) Close() error {
 	return b.db.Close()
@@ -118,12 +118,45 @@ func (s *BoltDBStore) Seek(key []byte, f func(k, v []byte)) {
 	}
 }
 
+// SeekReverse implements the Store interface.
+func (s *BoltDBStore) SeekReverse(key []byte, f func(k, v []byte) bool) {
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var (
+			c      = tx.Bucket(Bucket).Cursor()
+			prefix = util.BytesPrefix(key)
+			k, v   []byte
+		)
+		if prefix.Limit == nil {
+			k, v = c.Last()
+		} else if k, _ = c.Seek(prefix.Limit); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil && bytes.HasPrefix(k, key); k, v = c.Prev() {
+			if !f(k, v) {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
 // Batch implements the Batch interface and returns a boltdb
 // compatible Batch.
 func (s *BoltDBStore) Batch() Batch {
 	return newMemoryBatch()
 }
 
+// Compact implements the Store interface. BoltDB can only be compacted by
+// copying all data into a new file, so it's not supported.
+func (s *BoltDBStore) Compact() error {
+	return ErrCompactionNotSupported
+}
+
 // Close releases all db resources.
 func (s *BoltDBStore) Close() error {
 	return s.db.Close()
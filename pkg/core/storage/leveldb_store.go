@@ -70,12 +70,28 @@ func (s *LevelDBStore) Seek(key []byte, f func(k, v []byte)) {
 	iter.Release()
 }
 
+// SeekReverse implements the Store interface.
+func (s *LevelDBStore) SeekReverse(key []byte, f func(k, v []byte) bool) {
+	iter := s.db.NewIterator(util.BytesPrefix(key), nil)
+	for ok := iter.Last(); ok; ok = iter.Prev() {
+		if !f(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+	iter.Release()
+}
+
 // Batch implements the Batch interface and returns a leveldb
 // compatible Batch.
 func (s *LevelDBStore) Batch() Batch {
 	return new(leveldb.Batch)
 }
 
+// Compact implements the Store interface. It compacts the whole key range.
+func (s *LevelDBStore) Compact() error {
+	return s.db.CompactRange(util.Range{})
+}
+
 // Close implements the Store interface.
 func (s *LevelDBStore) Close() error {
 	return s.db.Close()
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LRUMemoryStore is a size-limited in-memory implementation of a Store. It
+// tracks the total size of stored keys and values and evicts least recently
+// used items when it exceeds the limit, so it's only suitable for caches
+// (like RPC read cache) that can lose data.
+type LRUMemoryStore struct {
+	mut     sync.Mutex
+	maxSize int
+	size    int
+	elems   map[string]*list.Element
+	// queue contains items ordered by their last usage, the most recently
+	// used item is in front.
+	queue *list.List
+}
+
+// lruItem is an item stored in LRUMemoryStore.
+type lruItem struct {
+	key   string
+	value []byte
+}
+
+// NewLRUMemoryStore creates a new LRUMemoryStore holding up to maxSize
+// bytes of keys and values.
+func NewLRUMemoryStore(maxSize int) *LRUMemoryStore {
+	return &LRUMemoryStore{
+		maxSize: maxSize,
+		elems:   make(map[string]*list.Element),
+		queue:   list.New(),
+	}
+}
+
+// Size returns the total size of stored keys and values.
+func (s *LRUMemoryStore) Size() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.size
+}
+
+// Get implements the Store interface, it marks the item as recently used.
+func (s *LRUMemoryStore) Get(key []byte) ([]byte, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	e, ok := s.elems[string(key)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	s.queue.MoveToFront(e)
+	return e.Value.(*lruItem).value, nil
+}
+
+// Put implements the Store interface. Never returns an error.
+func (s *LRUMemoryStore) Put(key, value []byte) error {
+	vcopy := make([]byte, len(value))
+	copy(vcopy, value)
+	s.mut.Lock()
+	s.put(string(key), vcopy)
+	s.evict()
+	s.mut.Unlock()
+	return nil
+}
+
+// put puts a key-value pair into the store, it's supposed to be called
+// with mutex locked.
+func (s *LRUMemoryStore) put(key string, value []byte) {
+	if e, ok := s.elems[key]; ok {
+		item := e.Value.(*lruItem)
+		s.size += len(value) - len(item.value)
+		item.value = value
+		s.queue.MoveToFront(e)
+		return
+	}
+	s.elems[key] = s.queue.PushFront(&lruItem{key: key, value: value})
+	s.size += len(key) + len(value)
+}
+
+// Delete implements the Store interface. Never returns an error.
+func (s *LRUMemoryStore) Delete(key []byte) error {
+	s.mut.Lock()
+	s.drop(string(key))
+	s.mut.Unlock()
+	return nil
+}
+
+// drop deletes a key-value pair from the store, it's supposed to be called
+// with mutex locked.
+func (s *LRUMemoryStore) drop(key string) {
+	e, ok := s.elems[key]
+	if !ok {
+		return
+	}
+	item := s.queue.Remove(e).(*lruItem)
+	delete(s.elems, key)
+	s.size -= len(item.key) + len(item.value)
+}
+
+// evict removes least recently used items until the store fits into the
+// limit, it's supposed to be called with mutex locked.
+func (s *LRUMemoryStore) evict() {
+	for s.size > s.maxSize {
+		s.drop(s.queue.Back().Value.(*lruItem).key)
+	}
+}
+
+// PutBatch implements the Store interface. Never returns an error.
+func (s *LRUMemoryStore) PutBatch(batch Batch) error {
+	b := batch.(*MemoryBatch)
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for k := range b.del {
+		s.drop(k)
+	}
+	for k, v := range b.mem {
+		s.put(k, v)
+	}
+	s.evict()
+	return nil
+}
+
+// Seek implements the Store interface. It doesn't change items' recency.
+func (s *LRUMemoryStore) Seek(key []byte, f func(k, v []byte)) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for k, e := range s.elems {
+		if strings.HasPrefix(k, string(key)) {
+			f([]byte(k), e.Value.(*lruItem).value)
+		}
+	}
+}
+
+// SeekReverse implements the Store interface. It doesn't change items'
+// recency.
+func (s *LRUMemoryStore) SeekReverse(key []byte, f func(k, v []byte) bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	var keys []string
+	for k := range s.elems {
+		if strings.HasPrefix(k, string(key)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	for _, k := range keys {
+		if !f([]byte(k), s.elems[k].Value.(*lruItem).value) {
+			return
+		}
+	}
+}
+
+// Batch implements the Store interface and returns a compatible Batch.
+func (s *LRUMemoryStore) Batch() Batch {
+	return newMemoryBatch()
+}
+
+// Compact implements the Store interface, it's not supported for
+// LRUMemoryStore.
+func (s *LRUMemoryStore) Compact() error {
+	return ErrCompactionNotSupported
+}
+
+// Close implements the Store interface and clears up memory. Never returns
+// an error.
+func (s *LRUMemoryStore) Close() error {
+	s.mut.Lock()
+	s.elems = make(map[string]*list.Element)
+	s.queue.Init()
+	s.size = 0
+	s.mut.Unlock()
+	return nil
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newLRUMemoryStoreForTesting(t *testing.T) Store {
+	return NewLRUMemoryStore(1 << 20)
+}
+
+func TestLRUMemoryStoreEviction(t *testing.T) {
+	// Every item is 4 bytes long.
+	s := NewLRUMemoryStore(12)
+	for _, k := range []string{"k1", "k2", "k3"} {
+		require.NoError(t, s.Put([]byte(k), []byte("v"+k[1:])))
+	}
+	require.Equal(t, 12, s.Size())
+
+	// Make k1 recently used, so k2 is to be evicted.
+	v, err := s.Get([]byte("k1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1"), v)
+
+	require.NoError(t, s.Put([]byte("k4"), []byte("v4")))
+	require.Equal(t, 12, s.Size())
+	_, err = s.Get([]byte("k2"))
+	require.True(t, errors.Is(err, ErrKeyNotFound))
+	for _, k := range []string{"k1", "k3", "k4"} {
+		_, err := s.Get([]byte(k))
+		require.NoError(t, err)
+	}
+
+	t.Run("update", func(t *testing.T) {
+		// k3 is the least recently used now, k1 update needs 2 more bytes.
+		require.NoError(t, s.Put([]byte("k1"), []byte("v1v1")))
+		require.Equal(t, 10, s.Size())
+		_, err := s.Get([]byte("k3"))
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		b := s.Batch()
+		b.Delete([]byte("k4"))
+		b.Put([]byte("k5"), []byte("v5"))
+		b.Put([]byte("k6"), []byte("v6"))
+		require.NoError(t, s.PutBatch(b))
+		require.True(t, s.Size() <= 12)
+		_, err := s.Get([]byte("k4"))
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+		_, err = s.Get([]byte("k1"))
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("too big item", func(t *testing.T) {
+		require.NoError(t, s.Put([]byte("big"), make([]byte, 20)))
+		require.Equal(t, 0, s.Size())
+		_, err := s.Get([]byte("big"))
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, s.Put([]byte("k7"), []byte("v7")))
+		require.Equal(t, 4, s.Size())
+		require.NoError(t, s.Delete([]byte("k7")))
+		require.Equal(t, 0, s.Size())
+	})
+}
@@ -90,6 +90,49 @@ func (s *MemCachedStore) Seek(key []byte, f func(k, v []byte)) {
 	})
 }
 
+// SeekReverse implements the Store interface. Cached and persistent items are
+// merged on the fly, so items are still returned in descending key order.
+func (s *MemCachedStore) SeekReverse(key []byte, f func(k, v []byte) bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	var (
+		mem  = s.MemoryStore.reverseKeys(key)
+		i    int
+		stop bool
+	)
+	// emitMem calls f for cached items greater than k (or for all remaining
+	// ones if k is nil).
+	emitMem := func(k []byte) bool {
+		for ; i < len(mem) && (k == nil || mem[i] > string(k)); i++ {
+			if !f([]byte(mem[i]), s.mem[mem[i]]) {
+				return false
+			}
+		}
+		return true
+	}
+	s.ps.SeekReverse(key, func(k, v []byte) bool {
+		if !emitMem(k) {
+			stop = true
+			return false
+		}
+		elem := string(k)
+		if _, present := s.mem[elem]; present {
+			return true
+		}
+		if s.del[elem] {
+			return true
+		}
+		if !f(k, v) {
+			stop = true
+			return false
+		}
+		return true
+	})
+	if !stop {
+		emitMem(nil)
+	}
+}
+
 // Persist flushes all the MemoryStore contents into the (supposedly) persistent
 // store ps.
 func (s *MemCachedStore) Persist() (int, error) {
@@ -138,6 +181,11 @@ func (s *MemCachedStore) Persist() (int, error) {
 	return keys, err
 }
 
+// Compact implements Store interface, it compacts the lower layer Store.
+func (s *MemCachedStore) Compact() error {
+	return s.ps.Compact()
+}
+
 // Close implements Store interface, clears up memory and closes the lower layer
 // Store.
 func (s *MemCachedStore) Close() error {
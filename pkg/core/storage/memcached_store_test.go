@@ -174,6 +174,31 @@ func TestCachedSeek(t *testing.T) {
 	}
 }
 
+func TestCachedSeekReverse(t *testing.T) {
+	ps := NewMemoryStore()
+	ts := NewMemCachedStore(ps)
+	for _, k := range []string{"fa", "fc", "fe", "fg", "x"} {
+		require.NoError(t, ps.Put([]byte(k), []byte("ps"+k)))
+	}
+	for _, k := range []string{"fb", "fe", "fh"} {
+		require.NoError(t, ts.Put([]byte(k), []byte("ts"+k)))
+	}
+	require.NoError(t, ts.Delete([]byte("fc")))
+
+	check := func(t *testing.T, limit int, expected []string) {
+		var actual []string
+		ts.SeekReverse([]byte("f"), func(k, v []byte) bool {
+			actual = append(actual, string(k)+":"+string(v))
+			return limit <= 0 || len(actual) < limit
+		})
+		require.Equal(t, expected, actual)
+	}
+	check(t, 0, []string{"fh:tsfh", "fg:psfg", "fe:tsfe", "fb:tsfb", "fa:psfa"})
+	check(t, 1, []string{"fh:tsfh"})
+	check(t, 2, []string{"fh:tsfh", "fg:psfg"})
+	check(t, 4, []string{"fh:tsfh", "fg:psfg", "fe:tsfe", "fb:tsfb"})
+}
+
 func newMemCachedStoreForTesting(t *testing.T) Store {
 	return NewMemCachedStore(NewMemoryStore())
 }
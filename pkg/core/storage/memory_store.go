@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"sort"
 	"strings"
 	"sync"
 )
@@ -119,6 +120,30 @@ func (s *MemoryStore) SeekAll(key []byte, f func(k, v []byte)) {
 	}
 }
 
+// SeekReverse implements the Store interface.
+func (s *MemoryStore) SeekReverse(key []byte, f func(k, v []byte) bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	for _, k := range s.reverseKeys(key) {
+		if !f([]byte(k), s.mem[k]) {
+			return
+		}
+	}
+}
+
+// reverseKeys returns all keys with the given prefix sorted in descending
+// order. It's not protected by mutex.
+func (s *MemoryStore) reverseKeys(key []byte) []string {
+	var keys []string
+	for k := range s.mem {
+		if strings.HasPrefix(k, string(key)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	return keys
+}
+
 // seek is an internal unlocked implementation of Seek.
 func (s *MemoryStore) seek(key []byte, f func(k, v []byte)) {
 	for k, v := range s.mem {
@@ -138,6 +163,11 @@ func newMemoryBatch() *MemoryBatch {
 	return &MemoryBatch{MemoryStore: *NewMemoryStore()}
 }
 
+// Compact implements the Store interface, it's not supported for MemoryStore.
+func (s *MemoryStore) Compact() error {
+	return ErrCompactionNotSupported
+}
+
 // Close implements Store interface and clears up memory. Never returns an
 // error.
 func (s *MemoryStore) Close() error {
@@ -1,9 +1,18 @@
 package storage
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func newMemoryStoreForTesting(t *testing.T) Store {
 	return NewMemoryStore()
 }
+
+func TestMemoryStoreCompact(t *testing.T) {
+	s := NewMemoryStore()
+	require.True(t, errors.Is(s.Compact(), ErrCompactionNotSupported))
+	require.True(t, errors.Is(NewMemCachedStore(s).Compact(), ErrCompactionNotSupported))
+}
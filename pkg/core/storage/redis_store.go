@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/go-redis/redis"
 )
@@ -83,6 +84,28 @@ func (s *RedisStore) Seek(k []byte, f func(k, v []byte)) {
 	}
 }
 
+// SeekReverse implements the Store interface. Redis doesn't support ordered
+// iteration, so all matching keys are fetched and sorted first.
+func (s *RedisStore) SeekReverse(k []byte, f func(k, v []byte) bool) {
+	var keys []string
+	iter := s.client.Scan(0, fmt.Sprintf("%s*", k), 0).Iterator()
+	for iter.Next() {
+		keys = append(keys, iter.Val())
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	for _, key := range keys {
+		val, _ := s.client.Get(key).Result()
+		if !f([]byte(key), []byte(val)) {
+			break
+		}
+	}
+}
+
+// Compact implements the Store interface, it's not supported for RedisStore.
+func (s *RedisStore) Compact() error {
+	return ErrCompactionNotSupported
+}
+
 // Close implements the Store interface.
 func (s *RedisStore) Close() error {
 	return s.client.Close()
@@ -35,6 +35,10 @@ const (
 // when a certain key is not found.
 var ErrKeyNotFound = errors.New("key not found")
 
+// ErrCompactionNotSupported is an error returned by Store implementations
+// that can't compact their data.
+var ErrCompactionNotSupported = errors.New("compaction is not supported by this storage")
+
 type (
 	// Store is anything that can persist and retrieve the blockchain.
 	// information.
@@ -47,6 +51,14 @@ type (
 		// Seek can guarantee that provided key (k) and value (v) are the only valid until the next call to f.
 		// Key and value slices should not be modified.
 		Seek(k []byte, f func(k, v []byte))
+		// SeekReverse is similar to Seek, but it iterates over the items with
+		// the given prefix in descending key order and stops once f returns
+		// false.
+		SeekReverse(k []byte, f func(k, v []byte) bool)
+		// Compact compacts underlying DB to reclaim space occupied by
+		// deleted or overwritten data, ErrCompactionNotSupported is returned
+		// if it's not possible for this Store.
+		Compact() error
 		Close() error
 	}
 
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"reflect"
 	"runtime"
 	"testing"
@@ -121,6 +122,33 @@ func testStoreSeek(t *testing.T, s Store) {
 	require.NoError(t, s.Close())
 }
 
+func testStoreSeekReverse(t *testing.T, s Store) {
+	keys := []string{"r", "r0", "r1", "rz", "rzz", "q", "s", "s0", "~", "~~"}
+	for _, k := range keys {
+		require.NoError(t, s.Put([]byte(k), []byte("v"+k)))
+	}
+
+	check := func(t *testing.T, prefix string, limit int, expected []string) {
+		var actual, values []string
+		s.SeekReverse([]byte(prefix), func(k, v []byte) bool {
+			actual = append(actual, string(k))
+			values = append(values, string(v))
+			return limit <= 0 || len(actual) < limit
+		})
+		require.Equal(t, expected, actual)
+		for i := range actual {
+			require.Equal(t, "v"+actual[i], values[i])
+		}
+	}
+	check(t, "r", 0, []string{"rzz", "rz", "r1", "r0", "r"})
+	check(t, "r", 2, []string{"rzz", "rz"})
+	check(t, "rz", 0, []string{"rzz", "rz"})
+	check(t, "s", 0, []string{"s0", "s"})
+	check(t, "t", 0, nil)
+	check(t, "", 1, []string{"~~"})
+	require.NoError(t, s.Close())
+}
+
 func testStoreDeleteNonExistent(t *testing.T, s Store) {
 	key := []byte("sparse")
 
@@ -217,19 +245,45 @@ func testStorePutBatchWithDelete(t *testing.T, s Store) {
 	require.NoError(t, s.Close())
 }
 
+func testStoreCompact(t *testing.T, s Store) {
+	for i := byte(0); i < 10; i++ {
+		require.NoError(t, s.Put([]byte{i}, []byte{i}))
+	}
+	for i := byte(0); i < 5; i++ {
+		require.NoError(t, s.Delete([]byte{i}))
+	}
+	err := s.Compact()
+	if errors.Is(err, ErrCompactionNotSupported) {
+		require.NoError(t, s.Close())
+		return
+	}
+	require.NoError(t, err)
+	for i := byte(0); i < 10; i++ {
+		v, err := s.Get([]byte{i})
+		if i < 5 {
+			require.True(t, errors.Is(err, ErrKeyNotFound))
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, []byte{i}, v)
+		}
+	}
+	require.NoError(t, s.Close())
+}
+
 func TestAllDBs(t *testing.T) {
 	var DBs = []dbSetup{
 		{"BoltDB", newBoltStoreForTesting},
 		{"LevelDB", newLevelDBForTesting},
 		{"MemCached", newMemCachedStoreForTesting},
 		{"Memory", newMemoryStoreForTesting},
+		{"LRUMemory", newLRUMemoryStoreForTesting},
 		{"RedisDB", newRedisStoreForTesting},
 		{"BadgerDB", newBadgerDBForTesting},
 	}
 	var tests = []dbTestFunction{testStoreClose, testStorePutAndGet,
-		testStoreGetNonExistent, testStorePutBatch, testStoreSeek,
+		testStoreGetNonExistent, testStorePutBatch, testStoreSeek, testStoreSeekReverse,
 		testStoreDeleteNonExistent, testStorePutAndDelete,
-		testStorePutBatchWithDelete}
+		testStorePutBatchWithDelete, testStoreCompact}
 	for _, db := range DBs {
 		for _, test := range tests {
 			s := db.create(t)
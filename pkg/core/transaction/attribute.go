@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/nspcc-dev/neo-go/pkg/io"
 )
@@ -24,6 +25,8 @@ type Attribute struct {
 // attrJSON is used for JSON I/O of Attribute.
 type attrJSON struct {
 	Type string `json:"type"`
+	// Usage is only used for reserved attributes.
+	Usage *AttrType `json:"usage,omitempty"`
 }
 
 // DecodeBinary implements Serializable interface.
@@ -42,7 +45,7 @@ func (attr *Attribute) DecodeBinary(br *io.BinReader) {
 	case NotaryAssistedT:
 		attr.Value = new(NotaryAssisted)
 	default:
-		if t >= ReservedLowerBound && t <= ReservedUpperBound {
+		if t.isReserved() {
 			attr.Value = new(Reserved)
 			break
 		}
@@ -60,7 +63,7 @@ func (attr *Attribute) EncodeBinary(bw *io.BinWriter) {
 	case OracleResponseT, NotValidBeforeT, ConflictsT, NotaryAssistedT:
 		attr.Value.EncodeBinary(bw)
 	default:
-		if t >= ReservedLowerBound && t <= ReservedUpperBound {
+		if t.isReserved() {
 			attr.Value.EncodeBinary(bw)
 			break
 		}
@@ -71,6 +74,10 @@ func (attr *Attribute) EncodeBinary(bw *io.BinWriter) {
 // MarshalJSON implements the json Marshaller interface.
 func (attr *Attribute) MarshalJSON() ([]byte, error) {
 	m := map[string]interface{}{"type": attr.Type.String()}
+	if attr.Type.isReserved() {
+		m["type"] = reservedJSONType
+		m["usage"] = attr.Type
+	}
 	if attr.Value != nil {
 		attr.Value.toJSONMap(m)
 	}
@@ -102,8 +109,46 @@ func (attr *Attribute) UnmarshalJSON(data []byte) error {
 	case NotaryAssistedT.String():
 		attr.Type = NotaryAssistedT
 		attr.Value = new(NotaryAssisted)
+	case reservedJSONType:
+		if aj.Usage == nil || !aj.Usage.isReserved() {
+			return errors.New("invalid reserved attribute usage")
+		}
+		attr.Type = *aj.Usage
+		r := new(Reserved)
+		if err := json.Unmarshal(data, r); err != nil {
+			return err
+		}
+		if len(r.Value) > MaxReservedValueSize {
+			return errors.New("reserved attribute value is too big")
+		}
+		attr.Value = r
+		return nil
 	default:
 		return errors.New("wrong Type")
 	}
 	return json.Unmarshal(data, attr.Value)
 }
+
+// MaxAttributesFeeBase is the maximum base for AttributesFee that can't lead
+// to int64 overflow for any valid transaction.
+const MaxAttributesFeeBase = math.MaxInt64 / (MaxAttributes * (MaxAttributes + 1) / 2)
+
+// AttributesFee returns network fee escalation for the given attributes.
+// Every subsequent metered attribute costs base more than the previous one
+// (base, 2*base, 3*base, ...), so the total grows quadratically with the
+// number of attributes. NotaryAssisted and OracleResponse attributes are not
+// metered as they're paid for separately.
+func AttributesFee(base int64, attrs []Attribute) int64 {
+	if base == 0 {
+		return 0
+	}
+	var n int64
+	for i := range attrs {
+		switch attrs[i].Type {
+		case NotaryAssistedT, OracleResponseT:
+		default:
+			n++
+		}
+	}
+	return base * (n * (n + 1) / 2)
+}
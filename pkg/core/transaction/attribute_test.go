@@ -85,6 +85,18 @@ func TestAttribute_EncodeBinary(t *testing.T) {
 			_, err := testserdes.EncodeBinary(getReservedAttribute(ReservedLowerBound - 1))
 			require.Error(t, err)
 		})
+		t.Run("max size", func(t *testing.T) {
+			attr := getReservedAttribute(ReservedLowerBound + 5)
+			attr.Value.(*Reserved).Value = make([]byte, MaxReservedValueSize)
+			testserdes.EncodeDecodeBinary(t, attr, new(Attribute))
+		})
+		t.Run("too big", func(t *testing.T) {
+			attr := getReservedAttribute(ReservedLowerBound + 5)
+			attr.Value.(*Reserved).Value = make([]byte, MaxReservedValueSize+1)
+			data, err := testserdes.EncodeBinary(attr)
+			require.NoError(t, err)
+			require.Error(t, testserdes.DecodeBinary(data, new(Attribute)))
+		})
 	})
 	t.Run("Conflicts", func(t *testing.T) {
 		t.Run("positive", func(t *testing.T) {
@@ -191,4 +203,79 @@ func TestAttribute_MarshalJSON(t *testing.T) {
 		}
 		testserdes.MarshalUnmarshalJSON(t, attr, new(Attribute))
 	})
+	t.Run("Reserved", func(t *testing.T) {
+		attr := &Attribute{
+			Type: ReservedLowerBound + 5,
+			Value: &Reserved{
+				Value: []byte{1, 2, 3},
+			},
+		}
+		data, err := json.Marshal(attr)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type":"Reserved","usage":229,"value":"AQID"}`, string(data))
+		testserdes.MarshalUnmarshalJSON(t, attr, new(Attribute))
+
+		t.Run("max size", func(t *testing.T) {
+			attr := &Attribute{
+				Type:  ReservedUpperBound,
+				Value: &Reserved{Value: make([]byte, MaxReservedValueSize)},
+			}
+			testserdes.MarshalUnmarshalJSON(t, attr, new(Attribute))
+		})
+		t.Run("too big", func(t *testing.T) {
+			data, err := json.Marshal(&Attribute{
+				Type:  ReservedUpperBound,
+				Value: &Reserved{Value: make([]byte, MaxReservedValueSize+1)},
+			})
+			require.NoError(t, err)
+			require.Error(t, json.Unmarshal(data, new(Attribute)))
+		})
+		t.Run("invalid usage", func(t *testing.T) {
+			for _, js := range []string{
+				`{"type":"Reserved","value":"AQID"}`,
+				`{"type":"Reserved","usage":1,"value":"AQID"}`,
+				`{"type":"Reserved","usage":224,"value":"AQID"}`,
+				`{"type":"Reserved","usage":256,"value":"AQID"}`,
+			} {
+				require.Error(t, json.Unmarshal([]byte(js), new(Attribute)), js)
+			}
+		})
+	})
+}
+
+func TestAttributesFee(t *testing.T) {
+	const base = 100
+	getAttrs := func(n int) []Attribute {
+		attrs := make([]Attribute, n)
+		for i := range attrs {
+			attrs[i] = Attribute{Type: ReservedLowerBound + 3 + AttrType(i), Value: &Reserved{}}
+		}
+		return attrs
+	}
+
+	require.Equal(t, int64(0), AttributesFee(0, getAttrs(5)))
+	require.Equal(t, int64(0), AttributesFee(base, nil))
+	require.Equal(t, int64(base), AttributesFee(base, getAttrs(1)))
+	require.Equal(t, int64(3*base), AttributesFee(base, getAttrs(2)))
+	require.Equal(t, int64(6*base), AttributesFee(base, getAttrs(3)))
+
+	// Every additional attribute costs more than the previous one.
+	var prevDelta int64
+	for n := 1; n <= 10; n++ {
+		delta := AttributesFee(base, getAttrs(n)) - AttributesFee(base, getAttrs(n-1))
+		require.True(t, delta > prevDelta)
+		prevDelta = delta
+	}
+
+	t.Run("max base", func(t *testing.T) {
+		// No overflow for the maximum number of attributes.
+		require.Equal(t, int64(MaxAttributesFeeBase*(MaxAttributes*(MaxAttributes+1)/2)),
+			AttributesFee(MaxAttributesFeeBase, getAttrs(MaxAttributes)))
+	})
+	t.Run("not metered", func(t *testing.T) {
+		attrs := append(getAttrs(2),
+			Attribute{Type: NotaryAssistedT, Value: &NotaryAssisted{NKeys: 1}},
+			Attribute{Type: OracleResponseT, Value: &OracleResponse{}})
+		require.Equal(t, int64(3*base), AttributesFee(base, attrs))
+	})
 }
@@ -21,6 +21,17 @@ const (
 	NotaryAssistedT AttrType = ReservedLowerBound + 2 // NotaryAssisted
 )
 
+// isReserved checks whether attribute type belongs to the reserved range and
+// is not used by any known attribute.
+func (a AttrType) isReserved() bool {
+	switch a {
+	case NotValidBeforeT, ConflictsT, NotaryAssistedT:
+		return false
+	default:
+		return a >= ReservedLowerBound && a <= ReservedUpperBound
+	}
+}
+
 func (a AttrType) allowMultiple() bool {
 	switch a {
 	case ConflictsT:
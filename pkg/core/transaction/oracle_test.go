@@ -55,6 +55,18 @@ func TestOracleResponse_EncodeBinary(t *testing.T) {
 			err = testserdes.DecodeBinary(bs, new(OracleResponse))
 			require.True(t, errors.Is(err, ErrInvalidResult), "got: %v", err)
 		})
+		t.Run("TooBigResult", func(t *testing.T) {
+			r := &OracleResponse{
+				ID:     rand.Uint64(),
+				Code:   Success,
+				Result: make([]byte, MaxOracleResultSize+1),
+			}
+			bs, err := testserdes.EncodeBinary(r)
+			require.NoError(t, err)
+
+			err = testserdes.DecodeBinary(bs, new(OracleResponse))
+			require.Error(t, err)
+		})
 	})
 }
 
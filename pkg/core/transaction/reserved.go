@@ -1,17 +1,28 @@
 package transaction
 
 import (
+	"math"
+
 	"github.com/nspcc-dev/neo-go/pkg/io"
 )
 
-// Reserved represents an attribute for experimental or private usage.
+// MaxReservedValueSize is the maximum allowed size of reserved attribute value.
+const MaxReservedValueSize = math.MaxUint16
+
+// reservedJSONType is a JSON type name used for all reserved attributes, the
+// exact attribute type is stored in `usage` field.
+const reservedJSONType = "Reserved"
+
+// Reserved represents an attribute for experimental or private usage. Its
+// type can be any value from ReservedLowerBound to ReservedUpperBound range
+// not used by other attributes.
 type Reserved struct {
-	Value []byte
+	Value []byte `json:"value"`
 }
 
 // DecodeBinary implements io.Serializable interface.
 func (e *Reserved) DecodeBinary(br *io.BinReader) {
-	e.Value = br.ReadVarBytes()
+	e.Value = br.ReadVarBytes(MaxReservedValueSize)
 }
 
 // EncodeBinary implements io.Serializable interface.
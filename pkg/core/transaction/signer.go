@@ -19,6 +19,13 @@ type Signer struct {
 	AllowedGroups    []*keys.PublicKey `json:"allowedgroups,omitempty"`
 }
 
+// Cosigner is an alias for Signer kept for compatibility with the code
+// written before cosigners were renamed to signers. Both have exactly the same
+// binary and JSON representations.
+//
+// Deprecated: use Signer instead.
+type Cosigner = Signer
+
 // EncodeBinary implements Serializable interface.
 func (c *Signer) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteBytes(c.Account[:])
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +12,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 )
 
 const (
@@ -109,6 +112,14 @@ func (t *Transaction) Hash() util.Uint256 {
 	return t.hash
 }
 
+// SetNonce sets transaction nonce and drops cached transaction hash, so that
+// it's recalculated with the new nonce. It's useful to get deterministic
+// hashes for transactions created with New that uses random nonce.
+func (t *Transaction) SetNonce(nonce uint32) {
+	t.Nonce = nonce
+	t.hash = util.Uint256{}
+}
+
 // HasAttribute returns true iff t has an attribute of type typ.
 func (t *Transaction) HasAttribute(typ AttrType) bool {
 	for i := range t.Attributes {
@@ -141,7 +152,9 @@ func (t *Transaction) decodeHashableFields(br *io.BinReader) {
 	t.ValidUntilBlock = br.ReadU32LE()
 	br.ReadArray(&t.Signers, MaxAttributes)
 	br.ReadArray(&t.Attributes, MaxAttributes-len(t.Signers))
-	t.Script = br.ReadVarBytes(MaxScriptLength)
+	// Script length is checked by isValid, so that a proper error can be
+	// returned, the whole transaction can't be bigger than that anyway.
+	t.Script = br.ReadVarBytes(MaxTransactionSize)
 	if br.Err == nil {
 		br.Err = t.isValid()
 	}
@@ -212,6 +225,25 @@ func (t *Transaction) createHash() error {
 	return nil
 }
 
+// minSignedPartSize is the minimal possible size of transaction's signed part:
+// fixed fields, single signer with no additional scope data, no attributes
+// and one-byte script.
+const minSignedPartSize = 1 + 4 + 8 + 8 + 4 + (1 + util.Uint160Size + 1) + 1 + (1 + 1)
+
+// HashFromSignedPart returns transaction hash for the given signed part (the
+// one returned by EncodeHashableFields) without decoding the transaction. Only
+// its length is checked, so the data is not guaranteed to be a valid
+// transaction.
+func HashFromSignedPart(b []byte) (util.Uint256, error) {
+	if len(b) < minSignedPartSize {
+		return util.Uint256{}, fmt.Errorf("signed part is too short: %d bytes", len(b))
+	}
+	if len(b) > MaxTransactionSize {
+		return util.Uint256{}, fmt.Errorf("signed part is too big: %d bytes", len(b))
+	}
+	return hash.Sha256(b), nil
+}
+
 // DecodeHashableFields decodes a part of transaction which should be hashed.
 func (t *Transaction) DecodeHashableFields(buf []byte) error {
 	r := io.NewBinReaderFromBuf(buf)
@@ -256,6 +288,21 @@ func NewTransactionFromBytes(b []byte) (*Transaction, error) {
 	return tx, nil
 }
 
+// Base64 returns base64-encoded serialized transaction, it's the form used
+// to pass transactions via RPC.
+func (t *Transaction) Base64() string {
+	return base64.StdEncoding.EncodeToString(t.Bytes())
+}
+
+// NewFromBase64 decodes base64-encoded serialized transaction.
+func NewFromBase64(s string) (*Transaction, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+	return NewTransactionFromBytes(b)
+}
+
 // FeePerByte returns NetworkFee of the transaction divided by
 // its size.
 func (t *Transaction) FeePerByte() int64 {
@@ -350,6 +397,8 @@ var (
 	ErrNonUniqueSigners   = errors.New("transaction signers should be unique")
 	ErrInvalidAttribute   = errors.New("invalid attribute")
 	ErrEmptyScript        = errors.New("no script")
+	ErrTooBigScript       = errors.New("script is too big")
+	ErrInvalidScript      = errors.New("invalid script")
 )
 
 // isValid checks whether decoded/unmarshalled transaction has all fields valid.
@@ -389,6 +438,36 @@ func (t *Transaction) isValid() error {
 	if len(t.Script) == 0 {
 		return ErrEmptyScript
 	}
+	if len(t.Script) > MaxScriptLength {
+		return fmt.Errorf("%w: %d bytes", ErrTooBigScript, len(t.Script))
+	}
+	if err := checkScript(t.Script); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScript, err)
+	}
+	return nil
+}
+
+// checkScript ensures that the last instruction of the script is not
+// truncated. Opcodes are not checked here (invalid ones are skipped as
+// one-byte instructions), that's done during transaction verification along
+// with other script checks.
+func checkScript(script []byte) error {
+	var (
+		offset int
+		ctx    = vm.NewContext(script)
+	)
+	for offset+ctx.NextIP() < len(script) {
+		op, _, err := ctx.Next()
+		if err == nil {
+			continue
+		}
+		if !opcode.IsValid(op) {
+			offset += ctx.IP() + 1
+			ctx = vm.NewContext(script[offset:])
+			continue
+		}
+		return fmt.Errorf("offset %d: %w", offset+ctx.IP(), err)
+	}
 	return nil
 }
 
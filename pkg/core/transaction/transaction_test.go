@@ -67,6 +67,22 @@ func TestDecodeEncodeInvocationTX(t *testing.T) {
 	assert.Equal(t, rawInvocationTX, base64.StdEncoding.EncodeToString(data))
 }
 
+func TestBase64(t *testing.T) {
+	tx, err := NewFromBase64(rawInvocationTX)
+	require.NoError(t, err)
+	require.Equal(t, "25426643feed564cd3e57f346d6c68692f5622b3063da11c5572d99ee1a5b49a", tx.Hash().StringLE())
+	require.Equal(t, rawInvocationTX, tx.Base64())
+
+	actual, err := NewFromBase64(tx.Base64())
+	require.NoError(t, err)
+	require.Equal(t, tx, actual)
+
+	_, err = NewFromBase64("not a base64")
+	require.Error(t, err)
+	_, err = NewFromBase64(base64.StdEncoding.EncodeToString([]byte{1, 2, 3}))
+	require.Error(t, err)
+}
+
 func TestNew(t *testing.T) {
 	script := []byte{0x51}
 	tx := New(script, 1)
@@ -80,6 +96,28 @@ func TestNew(t *testing.T) {
 	testserdes.EncodeDecodeBinary(t, tx, &Transaction{})
 }
 
+func TestTransaction_SetNonce(t *testing.T) {
+	newTx := func() *Transaction {
+		tx := New([]byte{byte(opcode.PUSH1)}, 1)
+		tx.Signers = []Signer{{Account: util.Uint160{1, 2, 3}}}
+		tx.Scripts = []Witness{{}}
+		return tx
+	}
+	tx1, tx2 := newTx(), newTx()
+	h := tx1.Hash()
+
+	tx1.SetNonce(42)
+	require.Equal(t, uint32(42), tx1.Nonce)
+	require.NotEqual(t, h, tx1.Hash())
+	tx2.SetNonce(42)
+	require.Equal(t, tx1.Hash(), tx2.Hash())
+
+	// Hash is the same as for the decoded transaction.
+	actual, err := NewTransactionFromBytes(tx1.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, tx1.Hash(), actual.Hash())
+}
+
 func TestNewTransactionFromBytes(t *testing.T) {
 	script := []byte{0x51}
 	tx := New(script, 1)
@@ -102,6 +140,53 @@ func TestNewTransactionFromBytes(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestHashFromSignedPart(t *testing.T) {
+	check := func(t *testing.T, tx *Transaction) {
+		data, err := testserdes.EncodeBinary(tx)
+		require.NoError(t, err)
+		decoded, err := NewTransactionFromBytes(data)
+		require.NoError(t, err)
+
+		part, err := decoded.EncodeHashableFields()
+		require.NoError(t, err)
+		h, err := HashFromSignedPart(part)
+		require.NoError(t, err)
+		require.Equal(t, decoded.Hash(), h)
+	}
+	t.Run("minimal", func(t *testing.T) {
+		tx := New([]byte{byte(opcode.PUSH1)}, 1)
+		tx.Signers = []Signer{{Account: util.Uint160{1, 2, 3}}}
+		tx.Scripts = []Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
+		part, err := tx.EncodeHashableFields()
+		require.NoError(t, err)
+		require.Equal(t, minSignedPartSize, len(part))
+		check(t, tx)
+	})
+	t.Run("with attributes", func(t *testing.T) {
+		tx := New([]byte{byte(opcode.PUSH1), byte(opcode.RET)}, 100)
+		tx.NetworkFee = 123
+		tx.ValidUntilBlock = 42
+		tx.Signers = []Signer{
+			{Account: util.Uint160{1, 2, 3}, Scopes: CalledByEntry},
+			{Account: util.Uint160{4, 5, 6}, Scopes: CustomContracts, AllowedContracts: []util.Uint160{{7, 8, 9}}},
+		}
+		tx.Attributes = []Attribute{{Type: HighPriority}}
+		tx.Scripts = []Witness{
+			{InvocationScript: []byte{}, VerificationScript: []byte{}},
+			{InvocationScript: []byte{}, VerificationScript: []byte{}},
+		}
+		check(t, tx)
+	})
+	t.Run("too short", func(t *testing.T) {
+		_, err := HashFromSignedPart(make([]byte, minSignedPartSize-1))
+		require.Error(t, err)
+	})
+	t.Run("too big", func(t *testing.T) {
+		_, err := HashFromSignedPart(make([]byte, MaxTransactionSize+1))
+		require.Error(t, err)
+	})
+}
+
 func TestEncodingTXWithNoScript(t *testing.T) {
 	_, err := testserdes.EncodeBinary(new(Transaction))
 	require.Error(t, err)
@@ -157,7 +242,7 @@ func TestMarshalUnmarshalJSONInvocationTX(t *testing.T) {
 	tx := &Transaction{
 		Version:    0,
 		Signers:    []Signer{{Account: util.Uint160{1, 2, 3}}},
-		Script:     []byte{1, 2, 3, 4},
+		Script:     []byte{byte(opcode.PUSH1), byte(opcode.RET)},
 		Attributes: []Attribute{{Type: HighPriority}},
 		Scripts:    []Witness{},
 		SystemFee:  int64(fixedn.Fixed8FromFloat(123.45)),
@@ -168,6 +253,22 @@ func TestMarshalUnmarshalJSONInvocationTX(t *testing.T) {
 	testserdes.MarshalUnmarshalJSON(t, tx, new(Transaction))
 }
 
+func TestUnmarshalJSONNonUniqueSigners(t *testing.T) {
+	tx := &Transaction{
+		Signers: []Signer{
+			{Account: util.Uint160{1, 2, 3}},
+			{Account: util.Uint160{1, 2, 3}, Scopes: Global},
+		},
+		Script:  []byte{byte(opcode.PUSH1), byte(opcode.RET)},
+		Scripts: []Witness{},
+	}
+	data, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	err = json.Unmarshal(data, new(Transaction))
+	require.True(t, errors.Is(err, ErrNonUniqueSigners), err)
+}
+
 func TestTransaction_HasAttribute(t *testing.T) {
 	tx := New([]byte{1}, 0)
 	require.False(t, tx.HasAttribute(HighPriority))
@@ -190,7 +291,7 @@ func TestTransaction_isValid(t *testing.T) {
 					Scopes:  Global,
 				},
 			},
-			Script:     []byte{1, 2, 3, 4},
+			Script:     []byte{byte(opcode.PUSH1), byte(opcode.RET)},
 			Attributes: []Attribute{},
 			Scripts:    []Witness{},
 			Trimmed:    false,
@@ -259,6 +360,51 @@ func TestTransaction_isValid(t *testing.T) {
 		tx.Script = []byte{}
 		require.True(t, errors.Is(tx.isValid(), ErrEmptyScript))
 	})
+	t.Run("TooBigScript", func(t *testing.T) {
+		tx := newTx()
+		tx.Script = make([]byte, MaxScriptLength+1)
+		require.True(t, errors.Is(tx.isValid(), ErrTooBigScript))
+	})
+	t.Run("TruncatedInstruction", func(t *testing.T) {
+		tx := newTx()
+		tx.Script = []byte{byte(opcode.PUSH1), byte(opcode.PUSHINT32), 1, 2}
+		require.True(t, errors.Is(tx.isValid(), ErrInvalidScript))
+	})
+	t.Run("InvalidOpcode", func(t *testing.T) {
+		tx := newTx()
+		tx.Script = []byte{byte(opcode.PUSH1), 0xff, byte(opcode.PUSHINT8), 1}
+		require.NoError(t, tx.isValid())
+
+		tx.Script = []byte{byte(opcode.PUSH1), 0xff, byte(opcode.PUSHINT16), 1}
+		require.True(t, errors.Is(tx.isValid(), ErrInvalidScript))
+	})
+}
+
+func TestDecodingTXWithInvalidScript(t *testing.T) {
+	check := func(t *testing.T, script []byte, expected error) {
+		tx := New([]byte{byte(opcode.RET)}, 1)
+		tx.Signers = []Signer{{Account: util.Uint160{1, 2, 3}}}
+		tx.Scripts = []Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
+		tx.Script = script
+		data, err := testserdes.EncodeBinary(tx)
+		require.NoError(t, err)
+
+		err = testserdes.DecodeBinary(data, new(Transaction))
+		require.True(t, errors.Is(err, expected), err)
+	}
+	t.Run("too big", func(t *testing.T) {
+		script := make([]byte, MaxScriptLength+1)
+		for i := range script {
+			script[i] = byte(opcode.NOP)
+		}
+		check(t, script, ErrTooBigScript)
+	})
+	t.Run("truncated instruction", func(t *testing.T) {
+		check(t, []byte{byte(opcode.PUSHDATA1), 10, 1, 2, 3}, ErrInvalidScript)
+	})
+	t.Run("invalid opcode", func(t *testing.T) {
+		check(t, []byte{byte(opcode.PUSH1), 0xff}, nil)
+	})
 }
 
 func TestTransaction_GetAttributes(t *testing.T) {
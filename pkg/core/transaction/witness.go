@@ -2,8 +2,11 @@ package transaction
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 )
 
 const (
@@ -22,6 +25,40 @@ type Witness struct {
 	VerificationScript []byte `json:"verification"`
 }
 
+// NewSignatureWitness creates a witness for the standard signature contract
+// of pub using the given signature.
+func NewSignatureWitness(sig []byte, pub *keys.PublicKey) Witness {
+	return Witness{
+		InvocationScript:   invocationScript(sig),
+		VerificationScript: pub.GetVerificationScript(),
+	}
+}
+
+// NewMultisigWitness creates a witness for the m out of len(pubs) multisignature
+// contract using the given signatures. Signatures should be ordered the same
+// way as the corresponding sorted public keys are. pubs slice is not changed.
+func NewMultisigWitness(sigs [][]byte, m int, pubs keys.PublicKeys) (Witness, error) {
+	sorted := make(keys.PublicKeys, len(pubs))
+	copy(sorted, pubs)
+	script, err := smartcontract.CreateMultiSigRedeemScript(m, sorted)
+	if err != nil {
+		return Witness{}, err
+	}
+	return Witness{
+		InvocationScript:   invocationScript(sigs...),
+		VerificationScript: script,
+	}, nil
+}
+
+// invocationScript returns a script pushing all the given signatures in order.
+func invocationScript(sigs ...[]byte) []byte {
+	w := io.NewBufBinWriter()
+	for i := range sigs {
+		emit.Bytes(w.BinWriter, sigs[i])
+	}
+	return w.Bytes()
+}
+
 // DecodeBinary implements Serializable interface.
 func (w *Witness) DecodeBinary(br *io.BinReader) {
 	w.InvocationScript = br.ReadVarBytes(MaxInvocationScript)
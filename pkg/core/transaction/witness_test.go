@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,3 +37,23 @@ func TestWitnessSerDes(t *testing.T) {
 	require.Error(t, testserdes.DecodeBinary(bin1, exp))
 	require.Error(t, testserdes.DecodeBinary(bin2, exp))
 }
+
+func TestNewMultisigWitness(t *testing.T) {
+	pubs := make(keys.PublicKeys, 3)
+	for i := range pubs {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		pubs[i] = priv.PublicKey()
+	}
+	orig := make(keys.PublicKeys, len(pubs))
+	copy(orig, pubs)
+
+	sigs := [][]byte{make([]byte, 64), make([]byte, 64)}
+	w, err := NewMultisigWitness(sigs, 2, pubs)
+	require.NoError(t, err)
+	require.Equal(t, orig, pubs)
+	require.Equal(t, 2*66, len(w.InvocationScript))
+
+	_, err = NewMultisigWitness(sigs, 4, pubs)
+	require.Error(t, err)
+}
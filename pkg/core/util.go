@@ -2,12 +2,12 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
-	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -56,15 +56,29 @@ func validatorsFromConfig(cfg config.ProtocolConfiguration) ([]*keys.PublicKey,
 	return vs[:cfg.ValidatorsCount], nil
 }
 
+// committeeFromConfig parses and validates StandbyCommittee keys from the
+// configuration, the first ValidatorsCount of them are standby validators.
 func committeeFromConfig(cfg config.ProtocolConfiguration) ([]*keys.PublicKey, error) {
+	if len(cfg.StandbyCommittee) == 0 {
+		return nil, errors.New("StandbyCommittee is empty")
+	}
+	if cfg.ValidatorsCount <= 0 {
+		return nil, fmt.Errorf("ValidatorsCount must be positive, got %d", cfg.ValidatorsCount)
+	}
 	if len(cfg.StandbyCommittee) < cfg.ValidatorsCount {
-		return nil, errors.New("validators count can be less than the size of StandbyCommittee")
+		return nil, fmt.Errorf("validators count %d exceeds the size of StandbyCommittee (%d)",
+			cfg.ValidatorsCount, len(cfg.StandbyCommittee))
 	}
 	validators := make([]*keys.PublicKey, len(cfg.StandbyCommittee))
 	for i := range validators {
 		pubKey, err := keys.NewPublicKeyFromString(cfg.StandbyCommittee[i])
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("invalid StandbyCommittee key #%d: %w", i, err)
+		}
+		for j := 0; j < i; j++ {
+			if validators[j].Equal(pubKey) {
+				return nil, fmt.Errorf("duplicate StandbyCommittee key #%d", i)
+			}
 		}
 		validators[i] = pubKey
 	}
@@ -76,7 +90,7 @@ func getNextConsensusAddress(validators []*keys.PublicKey) (val util.Uint160, er
 	if err != nil {
 		return val, err
 	}
-	return hash.Hash160(raw), nil
+	return smartcontract.ScriptHash(raw), nil
 }
 
 // headerSliceReverse reverses the given slice of *Header.
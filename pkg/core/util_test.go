@@ -1,10 +1,12 @@
 package core
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,3 +41,52 @@ func TestGetConsensusAddressMainNet(t *testing.T) {
 	assert.Equal(t, consensusScript, script.String())
 	assert.Equal(t, consensusAddr, address.Uint160ToString(script))
 }
+
+func TestCommitteeFromConfig(t *testing.T) {
+	newKeys := func(t *testing.T, n int) ([]string, keys.PublicKeys) {
+		strs := make([]string, n)
+		pubs := make(keys.PublicKeys, n)
+		for i := range strs {
+			priv, err := keys.NewPrivateKey()
+			require.NoError(t, err)
+			pubs[i] = priv.PublicKey()
+			strs[i] = hex.EncodeToString(pubs[i].Bytes())
+		}
+		return strs, pubs
+	}
+
+	t.Run("good", func(t *testing.T) {
+		strs, pubs := newKeys(t, 3)
+		cfg := config.ProtocolConfiguration{StandbyCommittee: strs, ValidatorsCount: 2}
+		committee, err := committeeFromConfig(cfg)
+		require.NoError(t, err)
+		require.Equal(t, []*keys.PublicKey(pubs), committee)
+
+		validators, err := validatorsFromConfig(cfg)
+		require.NoError(t, err)
+		require.Equal(t, []*keys.PublicKey(pubs[:2]), validators)
+	})
+	t.Run("empty committee", func(t *testing.T) {
+		_, err := committeeFromConfig(config.ProtocolConfiguration{ValidatorsCount: 1})
+		require.Error(t, err)
+	})
+	t.Run("bad validators count", func(t *testing.T) {
+		strs, _ := newKeys(t, 2)
+		_, err := committeeFromConfig(config.ProtocolConfiguration{StandbyCommittee: strs})
+		require.Error(t, err)
+		_, err = committeeFromConfig(config.ProtocolConfiguration{StandbyCommittee: strs, ValidatorsCount: 3})
+		require.Error(t, err)
+	})
+	t.Run("bad key", func(t *testing.T) {
+		strs, _ := newKeys(t, 2)
+		strs[1] = "notakey"
+		_, err := committeeFromConfig(config.ProtocolConfiguration{StandbyCommittee: strs, ValidatorsCount: 1})
+		require.Error(t, err)
+	})
+	t.Run("duplicate key", func(t *testing.T) {
+		strs, _ := newKeys(t, 2)
+		strs[1] = strs[0]
+		_, err := committeeFromConfig(config.ProtocolConfiguration{StandbyCommittee: strs, ValidatorsCount: 1})
+		require.Error(t, err)
+	})
+}
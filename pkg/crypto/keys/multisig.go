@@ -0,0 +1,62 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// maxMultisigKeys is the maximum number of keys in multisignature script
+// (limited by the maximum VM array size).
+const maxMultisigKeys = 1024
+
+// ParseMultisigScript parses standard multisignature verification script
+// (the one created by smartcontract.CreateMultiSigRedeemScript) and returns
+// the number of signatures required and public keys from it. If the script
+// is not a multisignature one ok is false.
+func ParseMultisigScript(script []byte) (m int, pubs PublicKeys, ok bool) {
+	m, pos, ok := readScriptInt(script, 0)
+	if !ok {
+		return 0, nil, false
+	}
+	for pos+2 <= len(script) && script[pos] == byte(opcode.PUSHDATA1) && script[pos+1] == 33 {
+		if pos+35 > len(script) || len(pubs) >= maxMultisigKeys {
+			return 0, nil, false
+		}
+		pub, err := NewPublicKeyFromBytes(script[pos+2:pos+35], elliptic.P256())
+		if err != nil {
+			return 0, nil, false
+		}
+		pubs = append(pubs, pub)
+		pos += 35
+	}
+	n, pos, ok := readScriptInt(script, pos)
+	if !ok || n != len(pubs) || m < 1 || m > n {
+		return 0, nil, false
+	}
+	if pos+5 != len(script) || script[pos] != byte(opcode.SYSCALL) ||
+		binary.LittleEndian.Uint32(script[pos+1:]) != interopnames.ToID([]byte(interopnames.SystemCryptoCheckMultisig)) {
+		return 0, nil, false
+	}
+	return m, pubs, true
+}
+
+// readScriptInt reads an integer pushed by PUSH1-PUSH16, PUSHINT8 or
+// PUSHINT16 instruction at the given position and returns it along with the
+// next instruction position.
+func readScriptInt(script []byte, pos int) (int, int, bool) {
+	if pos >= len(script) {
+		return 0, 0, false
+	}
+	switch op := opcode.Opcode(script[pos]); {
+	case op >= opcode.PUSH1 && op <= opcode.PUSH16:
+		return int(op-opcode.PUSH1) + 1, pos + 1, true
+	case op == opcode.PUSHINT8 && pos+2 <= len(script):
+		return int(int8(script[pos+1])), pos + 2, true
+	case op == opcode.PUSHINT16 && pos+3 <= len(script):
+		return int(int16(binary.LittleEndian.Uint16(script[pos+1:]))), pos + 3, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,71 @@
+package keys
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/stretchr/testify/require"
+)
+
+func getMultisigScript(t *testing.T, m int, pubs PublicKeys) []byte {
+	buf := io.NewBufBinWriter()
+	emit.Int(buf.BinWriter, int64(m))
+	for _, pub := range pubs {
+		emit.Bytes(buf.BinWriter, pub.Bytes())
+	}
+	emit.Int(buf.BinWriter, int64(len(pubs)))
+	emit.Syscall(buf.BinWriter, interopnames.SystemCryptoCheckMultisig)
+	require.NoError(t, buf.Err)
+	return buf.Bytes()
+}
+
+func getPublicKeys(t *testing.T, n int) PublicKeys {
+	pubs := make(PublicKeys, n)
+	for i := range pubs {
+		priv, err := NewPrivateKey()
+		require.NoError(t, err)
+		pubs[i] = priv.PublicKey()
+	}
+	sort.Sort(pubs)
+	return pubs
+}
+
+func TestParseMultisigScript(t *testing.T) {
+	check := func(t *testing.T, m, n int) {
+		pubs := getPublicKeys(t, n)
+		actualM, actualPubs, ok := ParseMultisigScript(getMultisigScript(t, m, pubs))
+		require.True(t, ok)
+		require.Equal(t, m, actualM)
+		require.Equal(t, pubs, actualPubs)
+	}
+	t.Run("1 of 1", func(t *testing.T) { check(t, 1, 1) })
+	t.Run("2 of 3", func(t *testing.T) { check(t, 2, 3) })
+	t.Run("17 of 20", func(t *testing.T) { check(t, 17, 20) })
+
+	t.Run("non-multisig", func(t *testing.T) {
+		pubs := getPublicKeys(t, 3)
+		script := getMultisigScript(t, 2, pubs)
+		bad := map[string][]byte{
+			"empty":         {},
+			"signature":     pubs[0].GetVerificationScript(),
+			"m > n":         getMultisigScript(t, 4, pubs),
+			"m = 0":         getMultisigScript(t, 0, pubs),
+			"truncated":     script[:len(script)-1],
+			"trailing byte": append(append([]byte{}, script...), 0x40),
+			"bad syscall":   append(append([]byte{}, script[:len(script)-4]...), 1, 2, 3, 4),
+		}
+		wrongN := append([]byte{}, script...)
+		wrongN[len(script)-6]++ // PUSH3 -> PUSH4
+		bad["wrong n"] = wrongN
+
+		for name, s := range bad {
+			t.Run(name, func(t *testing.T) {
+				_, _, ok := ParseMultisigScript(s)
+				require.False(t, ok)
+			})
+		}
+	})
+}
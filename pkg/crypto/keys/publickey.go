@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/x509"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 )
 
 // coordLen is the number of bytes in serialized X or Y coordinate.
@@ -320,6 +322,22 @@ func (p *PublicKey) GetVerificationScript() []byte {
 	return buf.Bytes()
 }
 
+// ParseSignatureContract parses standard signature verification script (the
+// one returned by GetVerificationScript) and returns the public key from it.
+// If the script is not a signature one ok is false.
+func ParseSignatureContract(script []byte) (*PublicKey, bool) {
+	if len(script) != 40 || script[0] != byte(opcode.PUSHDATA1) || script[1] != 33 ||
+		script[35] != byte(opcode.SYSCALL) ||
+		binary.LittleEndian.Uint32(script[36:]) != interopnames.ToID([]byte(interopnames.SystemCryptoCheckSig)) {
+		return nil, false
+	}
+	pub, err := NewPublicKeyFromBytes(script[2:35], elliptic.P256())
+	if err != nil {
+		return nil, false
+	}
+	return pub, true
+}
+
 // GetScriptHash returns a Hash160 of verification script for the key.
 func (p *PublicKey) GetScriptHash() util.Uint160 {
 	return hash.Hash160(p.GetVerificationScript())
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/stretchr/testify/require"
 )
 
@@ -209,3 +211,37 @@ func TestUnmarshallJSONBadFormat(t *testing.T) {
 	err := json.Unmarshal([]byte(str), actual)
 	require.Error(t, err)
 }
+
+func TestParseSignatureContract(t *testing.T) {
+	priv, err := NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+
+	script := pub.GetVerificationScript()
+	actual, ok := ParseSignatureContract(script)
+	require.True(t, ok)
+	require.Equal(t, pub, actual)
+
+	addr, err := address.FromVerificationScript(script)
+	require.NoError(t, err)
+	require.Equal(t, pub.Address(), addr)
+	u, err := address.StringToUint160(addr)
+	require.NoError(t, err)
+	require.Equal(t, hash.Hash160(actual.GetVerificationScript()), u)
+
+	t.Run("bad", func(t *testing.T) {
+		for _, s := range [][]byte{
+			{},
+			script[:len(script)-1],
+			append(append([]byte{}, script...), 0x40),
+			getMultisigScript(t, 1, PublicKeys{pub}),
+		} {
+			_, ok := ParseSignatureContract(s)
+			require.False(t, ok)
+		}
+		bad := append([]byte{}, script...)
+		bad[len(bad)-1]++
+		_, ok := ParseSignatureContract(bad)
+		require.False(t, ok)
+	})
+}
@@ -3,6 +3,7 @@ package address
 import (
 	"errors"
 
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
@@ -37,3 +38,13 @@ func StringToUint160(s string) (u util.Uint160, err error) {
 	}
 	return util.Uint160DecodeBytesBE(b[1:21])
 }
+
+// FromVerificationScript returns the "NEO address" of the account with the
+// given verification script. It doesn't check script contents, use
+// keys.ParseSignatureContract or keys.ParseMultisigScript for that.
+func FromVerificationScript(script []byte) (string, error) {
+	if len(script) == 0 {
+		return "", errors.New("empty verification script")
+	}
+	return Uint160ToString(hash.Hash160(script)), nil
+}
@@ -3,6 +3,7 @@ package address
 import (
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -55,3 +56,13 @@ func TestPrefixFirstLetter(t *testing.T) {
 	}
 	require.EqualValues(t, 'N', Uint160ToString(u)[0])
 }
+
+func TestFromVerificationScript(t *testing.T) {
+	script := []byte{1, 2, 3}
+	addr, err := FromVerificationScript(script)
+	require.NoError(t, err)
+	require.Equal(t, Uint160ToString(hash.Hash160(script)), addr)
+
+	_, err = FromVerificationScript(nil)
+	require.Error(t, err)
+}
@@ -25,3 +25,31 @@ type Transaction struct {
 	// Script represents code to run in NeoVM for this transaction.
 	Script []byte
 }
+
+// WitnessScope represents the scope of the transaction signer's witness.
+type WitnessScope byte
+
+// Witness scopes, see transaction.WitnessScope for details.
+const (
+	None            WitnessScope = 0
+	CalledByEntry   WitnessScope = 0x01
+	CustomContracts WitnessScope = 0x10
+	CustomGroups    WitnessScope = 0x20
+	Global          WitnessScope = 0x80
+)
+
+// TransactionSigner represents a signer of the transaction, it's similar to
+// Signer class in Neo .net framework.
+type TransactionSigner struct {
+	// Account represents the account (160 bit BE value in a 20 byte slice) of
+	// the given signer.
+	Account interop.Hash160
+	// Scopes represents the witness scope of the signer.
+	Scopes WitnessScope
+	// AllowedContracts represents contracts the witness is valid for
+	// (in CustomContracts scope).
+	AllowedContracts []interop.Hash160
+	// AllowedGroups represents contract groups the witness is valid for
+	// (in CustomGroups scope).
+	AllowedGroups []interop.PublicKey
+}
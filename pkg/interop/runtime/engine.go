@@ -14,6 +14,13 @@ func GetScriptContainer() *ledger.Transaction {
 	return neogointernal.Syscall0("System.Runtime.GetScriptContainer").(*ledger.Transaction)
 }
 
+// CurrentSigners returns signers of the transaction that initially triggered
+// current execution or nil if current execution is not triggered by a
+// transaction. This function uses `System.Runtime.CurrentSigners` syscall.
+func CurrentSigners() []ledger.TransactionSigner {
+	return neogointernal.Syscall0("System.Runtime.CurrentSigners").([]ledger.TransactionSigner)
+}
+
 // GetExecutingScriptHash returns script hash (160 bit in BE form represented
 // as 20-byte slice) of the contract that is currently being executed. Any
 // AppCall can change the value returned by this function if it calls a
@@ -1,9 +1,13 @@
 package network
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/Workiva/go-datastructures/queue"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"go.uber.org/zap"
 )
 
@@ -13,29 +17,55 @@ type blockQueue struct {
 	checkBlocks chan struct{}
 	chain       blockchainer.Blockchainer
 	relayF      func(*block.Block)
+	// cacheSize is the amount of blocks above current height
+	// which can be stored in queue.
+	cacheSize int
 }
 
 const (
-	// blockCacheSize is the amount of blocks above current height
+	// blockCacheSize is the default amount of blocks above current height
 	// which are stored in queue.
 	blockCacheSize = 2000
+	// blockRetryCount is the number of attempts made to add the next block
+	// into the chain before dropping it. Failures can be caused by transient
+	// storage problems, so we don't want to lose the block immediately.
+	blockRetryCount = 3
+	// blockRetryDelay is the initial delay between attempts to add the block,
+	// it's doubled after every failure.
+	blockRetryDelay = 100 * time.Millisecond
 )
 
+// newBlockQueue creates a queue storing at most capacity blocks above the
+// current chain height, default blockCacheSize is used if capacity is not
+// positive.
 func newBlockQueue(capacity int, bc blockchainer.Blockchainer, log *zap.Logger, relayer func(*block.Block)) *blockQueue {
 	if log == nil {
 		return nil
 	}
+	if capacity <= 0 {
+		capacity = blockCacheSize
+	}
 
 	return &blockQueue{
 		log:         log,
-		queue:       queue.NewPriorityQueue(capacity, false),
+		cacheSize:   capacity,
+		queue:       queue.NewPriorityQueue(maxBlockBatch, false),
 		checkBlocks: make(chan struct{}, 1),
 		chain:       bc,
 		relayF:      relayer,
 	}
 }
 
-func (bq *blockQueue) run() {
+// run processes queued blocks until the queue is discarded. Failed blocks
+// are retried blockRetryCount times with increasing delays before being
+// dropped. Panics happening while adding blocks are recovered and reported to
+// errCh (if it's not nil), the queue stops processing blocks after that
+// because the chain state can't be trusted anymore.
+func (bq *blockQueue) run(errCh chan<- error) {
+	var (
+		failures int
+		delay    = blockRetryDelay
+	)
 	for {
 		_, ok := <-bq.checkBlocks
 		if !ok {
@@ -51,18 +81,49 @@ func (bq *blockQueue) run() {
 				_, _ = bq.queue.Get(1)
 				updateBlockQueueLenMetric(bq.length())
 				if minblock.Index == bq.chain.BlockHeight()+1 {
-					err := bq.chain.AddBlock(minblock)
-					if err != nil {
-						// The block might already be added by consensus.
-						if bq.chain.BlockHeight() < minblock.Index {
-							bq.log.Warn("blockQueue: failed adding block into the blockchain",
-								zap.String("error", err.Error()),
-								zap.Uint32("blockHeight", bq.chain.BlockHeight()),
-								zap.Uint32("nextIndex", minblock.Index))
+					err := bq.addBlock(minblock)
+					if err == nil {
+						failures, delay = 0, blockRetryDelay
+						if bq.relayF != nil {
+							bq.relayF(minblock)
+						}
+						continue
+					}
+					// The block might already be added by consensus.
+					if bq.chain.BlockHeight() >= minblock.Index {
+						continue
+					}
+					if _, ok := err.(panicError); ok {
+						// There is no point in retrying or adding other
+						// blocks, something is seriously broken.
+						bq.log.Error("blockQueue: panic while adding block into the blockchain, stopping",
+							zap.String("error", err.Error()),
+							zap.Uint32("nextIndex", minblock.Index))
+						if errCh != nil {
+							errCh <- err
+						}
+						return
+					}
+					failures++
+					if failures < blockRetryCount {
+						bq.log.Warn("blockQueue: failed adding block into the blockchain, retrying",
+							zap.String("error", err.Error()),
+							zap.Uint32("blockHeight", bq.chain.BlockHeight()),
+							zap.Uint32("nextIndex", minblock.Index),
+							zap.Duration("delay", delay))
+						time.Sleep(delay)
+						delay *= 2
+						if bq.queue.Put(minblock) != nil {
+							return // Queue is disposed.
 						}
-					} else if bq.relayF != nil {
-						bq.relayF(minblock)
+						continue
 					}
+					bq.log.Error("blockQueue: failed adding block into the blockchain, dropping it",
+						zap.String("error", err.Error()),
+						zap.Uint32("blockHeight", bq.chain.BlockHeight()),
+						zap.Uint32("nextIndex", minblock.Index),
+						zap.Int("attempts", failures))
+					failures, delay = 0, blockRetryDelay
 				}
 			} else {
 				break
@@ -71,9 +132,24 @@ func (bq *blockQueue) run() {
 	}
 }
 
+// panicError is an error created from the panic recovered while adding block.
+type panicError struct {
+	error
+}
+
+// addBlock adds block into the chain converting panics into errors.
+func (bq *blockQueue) addBlock(b *block.Block) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError{fmt.Errorf("blockQueue: panic while adding block %d: %v", b.Index, r)}
+		}
+	}()
+	return bq.chain.AddBlock(b)
+}
+
 func (bq *blockQueue) putBlock(block *block.Block) error {
 	h := bq.chain.BlockHeight()
-	if block.Index <= h || h+blockCacheSize < block.Index {
+	if block.Index <= h || h+uint32(bq.cacheSize) < block.Index {
 		// can easily happen when fetching the same blocks from
 		// different peers, thus not considered as error
 		return nil
@@ -90,6 +166,17 @@ func (bq *blockQueue) putBlock(block *block.Block) error {
 	return err
 }
 
+// isFull returns true if there is no space left for another batch of blocks
+// and the next block is already in the queue, so that the chain has something
+// to process. It's used to pause block requests until the queue is drained.
+func (bq *blockQueue) isFull() bool {
+	if bq.length()+payload.MaxHashesCount <= bq.cacheSize {
+		return false
+	}
+	item := bq.queue.Peek()
+	return item != nil && item.(*block.Block).Index == bq.chain.BlockHeight()+1
+}
+
 func (bq *blockQueue) discard() {
 	close(bq.checkBlocks)
 	bq.queue.Dispose()
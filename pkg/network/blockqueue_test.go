@@ -1,12 +1,16 @@
 package network
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -35,7 +39,7 @@ func TestBlockQueue(t *testing.T) {
 	// block with too big index is dropped
 	assert.NoError(t, bq.putBlock(&block.Block{Header: block.Header{Index: bq.chain.BlockHeight() + blockCacheSize + 1}}))
 	assert.Equal(t, 4, bq.length())
-	go bq.run()
+	go bq.run(nil)
 	// run() is asynchronous, so we need some kind of timeout anyway and this is the simplest one
 	for i := 0; i < 5; i++ {
 		if chain.BlockHeight() != 4 {
@@ -74,3 +78,73 @@ func TestBlockQueue(t *testing.T) {
 	bq.discard()
 	assert.Equal(t, 0, bq.length())
 }
+
+// failingChain is a FakeChain with customizable AddBlock.
+type failingChain struct {
+	*fakechain.FakeChain
+	attempts  atomic.Int32
+	addBlockF func(attempt int32) error
+}
+
+func (c *failingChain) AddBlock(b *block.Block) error {
+	if err := c.addBlockF(c.attempts.Inc()); err != nil {
+		return err
+	}
+	return c.FakeChain.AddBlock(b)
+}
+
+func TestBlockQueueErrors(t *testing.T) {
+	newQueue := func(t *testing.T, f func(int32) error) (*blockQueue, *failingChain, chan error) {
+		chain := &failingChain{FakeChain: fakechain.NewFakeChain(), addBlockF: f}
+		bq := newBlockQueue(0, chain, zaptest.NewLogger(t), nil)
+		errCh := make(chan error, 1)
+		go bq.run(errCh)
+		t.Cleanup(bq.discard)
+		return bq, chain, errCh
+	}
+	b1 := &block.Block{Header: block.Header{Index: 1}}
+
+	t.Run("transient", func(t *testing.T) {
+		bq, chain, errCh := newQueue(t, func(attempt int32) error {
+			if attempt < blockRetryCount {
+				return errors.New("transient")
+			}
+			return nil
+		})
+		require.NoError(t, bq.putBlock(b1))
+		require.Eventually(t, func() bool { return chain.BlockHeight() == 1 }, 2*time.Second, 10*time.Millisecond)
+		require.EqualValues(t, blockRetryCount, chain.attempts.Load())
+		require.Equal(t, 0, len(errCh))
+	})
+	t.Run("persistent", func(t *testing.T) {
+		bq, chain, errCh := newQueue(t, func(int32) error {
+			return errors.New("persistent")
+		})
+		require.NoError(t, bq.putBlock(b1))
+		require.Eventually(t, func() bool { return chain.attempts.Load() == blockRetryCount }, 2*time.Second, 10*time.Millisecond)
+		require.Eventually(t, func() bool { return bq.length() == 0 }, time.Second, 10*time.Millisecond)
+		require.Equal(t, uint32(0), chain.BlockHeight())
+		require.Equal(t, 0, len(errCh))
+	})
+	t.Run("panic", func(t *testing.T) {
+		bq, chain, errCh := newQueue(t, func(attempt int32) error {
+			if attempt == 1 {
+				panic("storage is broken")
+			}
+			return nil
+		})
+		require.NoError(t, bq.putBlock(b1))
+		select {
+		case err := <-errCh:
+			require.True(t, strings.Contains(err.Error(), "storage is broken"), err)
+		case <-time.After(time.Second):
+			t.Fatal("no error received")
+		}
+		require.Equal(t, uint32(0), chain.BlockHeight())
+
+		// Queue is stopped, the block is neither retried nor added again.
+		require.NoError(t, bq.putBlock(b1))
+		require.Never(t, func() bool { return chain.attempts.Load() > 1 }, 300*time.Millisecond, 10*time.Millisecond)
+		require.Equal(t, uint32(0), chain.BlockHeight())
+	})
+}
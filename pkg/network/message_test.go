@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 )
 
@@ -299,7 +300,7 @@ func newDummyBlock(height uint32, txCount int) *block.Block {
 }
 
 func newDummyTx() *transaction.Transaction {
-	tx := transaction.New(random.Bytes(100), 123)
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 123)
 	tx.Signers = []transaction.Signer{{Account: random.Uint160()}}
 	tx.Scripts = []transaction.Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
 	tx.Size()
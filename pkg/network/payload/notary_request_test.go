@@ -144,7 +144,7 @@ func TestNotaryRequestIsValid(t *testing.T) {
 func TestNotaryRequestBytesFromBytes(t *testing.T) {
 	mainTx := &transaction.Transaction{
 		Attributes:      []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}},
-		Script:          []byte{0, 1, 2},
+		Script:          []byte{byte(opcode.RET)},
 		ValidUntilBlock: 123,
 		Signers:         []transaction.Signer{{Account: util.Uint160{1, 5, 9}}},
 		Scripts: []transaction.Witness{{
@@ -155,7 +155,7 @@ func TestNotaryRequestBytesFromBytes(t *testing.T) {
 	_ = mainTx.Hash()
 	_ = mainTx.Size()
 	fallbackTx := &transaction.Transaction{
-		Script:          []byte{3, 2, 1},
+		Script:          []byte{byte(opcode.PUSH1)},
 		ValidUntilBlock: 123,
 		Attributes: []transaction.Attribute{
 			{Type: transaction.NotValidBeforeT, Value: &transaction.NotValidBefore{Height: 123}},
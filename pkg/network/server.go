@@ -36,6 +36,12 @@ const (
 	defaultExtensiblePoolSize = 20
 	maxBlockBatch             = 200
 	minPoolCount              = 30
+	// maxBlockRequestsPerPeer is the maximum number of outstanding block
+	// range requests to a single peer.
+	maxBlockRequestsPerPeer = 2
+	// blockRequestTimeout is the time after which outstanding block range
+	// request is considered to be lost.
+	blockRequestTimeout = 30 * time.Second
 )
 
 var (
@@ -71,6 +77,7 @@ type (
 		extensiblePool    *extpool.Pool
 		notaryFeer        NotaryFeer
 		notaryModule      *notary.Notary
+		txVerifier        TxVerifier
 
 		lock  sync.RWMutex
 		peers map[Peer]bool
@@ -78,6 +85,11 @@ type (
 		// lastRequestedHeight contains last requested height.
 		lastRequestedHeight atomic.Uint32
 
+		// blockReqLock protects blockReqs.
+		blockReqLock sync.Mutex
+		// blockReqs contains outstanding block range requests for every peer.
+		blockReqs map[Peer][]blockRequest
+
 		register   chan Peer
 		unregister chan peerDrop
 		quit       chan struct{}
@@ -96,6 +108,14 @@ type (
 		peer   Peer
 		reason error
 	}
+
+	// blockRequest is an outstanding request for a range of blocks.
+	blockRequest struct {
+		// last is the index of the last block that is expected to be
+		// received in response.
+		last uint32
+		sent time.Time
+	}
 )
 
 func randomID() uint32 {
@@ -125,6 +145,11 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		log.Info("ExtensiblePoolSize is not set or wrong, using default value",
 			zap.Int("ExtensiblePoolSize", config.ExtensiblePoolSize))
 	}
+	if config.BlockQueueSize < 2*payload.MaxHashesCount {
+		config.BlockQueueSize = blockCacheSize
+		log.Info("BlockQueueSize is not set or wrong, using default value",
+			zap.Int("BlockQueueSize", config.BlockQueueSize))
+	}
 
 	s := &Server{
 		ServerConfig:      config,
@@ -136,10 +161,12 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		register:          make(chan Peer),
 		unregister:        make(chan peerDrop),
 		peers:             make(map[Peer]bool),
+		blockReqs:         make(map[Peer][]blockRequest),
 		syncReached:       atomic.NewBool(false),
 		extensiblePool:    extpool.New(chain, config.ExtensiblePoolSize),
 		log:               log,
 		transactions:      make(chan *transaction.Transaction, 64),
+		txVerifier:        nopTxVerifier{},
 	}
 	if chain.P2PSigExtensionsEnabled() {
 		s.notaryFeer = NewNotaryFeer(chain)
@@ -170,7 +197,7 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 	} else if config.P2PNotaryCfg.Enabled {
 		return nil, errors.New("P2PSigExtensions are disabled, but Notary service is enable")
 	}
-	s.bQueue = newBlockQueue(maxBlockBatch, chain, log, func(b *block.Block) {
+	s.bQueue = newBlockQueue(config.BlockQueueSize, chain, log, func(b *block.Block) {
 		if !s.syncReached.Load() {
 			s.tryStartServices()
 		}
@@ -216,7 +243,8 @@ func newServerFromConstructors(config ServerConfig, chain blockchainer.Blockchai
 		RequestTx:             s.requestTx,
 		Wallet:                config.Wallet,
 
-		TimePerBlock: config.TimePerBlock,
+		TimePerBlock:  config.TimePerBlock,
+		InstantBlocks: config.InstantBlocks,
 	})
 	if err != nil {
 		return nil, err
@@ -271,7 +299,7 @@ func (s *Server) Start(errChan chan error) {
 
 	go s.broadcastTxLoop()
 	go s.relayBlocksLoop()
-	go s.bQueue.run()
+	go s.bQueue.run(errChan)
 	go s.transport.Accept()
 	setServerAndNodeVersions(s.UserAgent, strconv.FormatUint(uint64(s.id), 10))
 	s.run()
@@ -371,6 +399,9 @@ func (s *Server) run() {
 			if s.peers[drop.peer] {
 				delete(s.peers, drop.peer)
 				s.lock.Unlock()
+				s.blockReqLock.Lock()
+				delete(s.blockReqs, drop.peer)
+				s.blockReqLock.Unlock()
 				s.log.Warn("peer disconnected",
 					zap.Stringer("addr", drop.peer.RemoteAddr()),
 					zap.String("reason", drop.reason.Error()),
@@ -842,15 +873,36 @@ func (s *Server) handleExtensibleCmd(e *payload.Extensible) error {
 	return nil
 }
 
+// SubmitConsensusPayload processes given consensus payload as if it was
+// received from the network, so it's passed to the consensus service and
+// relayed to other nodes.
+func (s *Server) SubmitConsensusPayload(e *payload.Extensible) error {
+	if e.Category != consensus.Category {
+		return fmt.Errorf("invalid category %q", e.Category)
+	}
+	if !s.syncReached.Load() {
+		return errors.New("node is not synchronized")
+	}
+	return s.handleExtensibleCmd(e)
+}
+
+// GetConsensusState returns the state of the current consensus round.
+func (s *Server) GetConsensusState() consensus.State {
+	return s.consensus.GetState()
+}
+
+// ForceBlock makes the consensus service propose a new block right away, it
+// only works for single-validator networks.
+func (s *Server) ForceBlock() error {
+	return s.consensus.ForceBlock()
+}
+
 // handleTxCmd processes received transaction.
 // It never returns an error.
 func (s *Server) handleTxCmd(tx *transaction.Transaction) error {
 	// It's OK for it to fail for various reasons like tx already existing
 	// in the pool.
-	if s.verifyAndPoolTX(tx) == nil {
-		s.consensus.OnTransaction(tx)
-		s.broadcastTX(tx, nil)
-	}
+	_ = s.RelayTxn(tx)
 	return nil
 }
 
@@ -946,10 +998,32 @@ func (s *Server) handleGetAddrCmd(p Peer) error {
 // 1. Block range is divided into chunks of payload.MaxHashesCount.
 // 2. Send requests for chunk in increasing order.
 // 3. After all requests were sent, request random height.
+// The number of outstanding requests to a single peer is limited by
+// maxBlockRequestsPerPeer, request is considered to be completed when
+// all blocks from its range are added to the chain or after
+// blockRequestTimeout. No requests are sent if the block queue is nearly
+// full and the chain is busy processing blocks from it.
 func (s *Server) requestBlocks(p Peer) error {
+	if s.bQueue.isFull() {
+		return nil
+	}
 	var currHeight = s.chain.BlockHeight()
 	var peerHeight = p.LastBlockIndex()
 	var needHeight uint32
+	var cacheSize = uint32(s.bQueue.cacheSize)
+
+	s.blockReqLock.Lock()
+	reqs := s.blockReqs[p][:0]
+	for _, r := range s.blockReqs[p] {
+		if r.last > currHeight && time.Since(r.sent) < blockRequestTimeout {
+			reqs = append(reqs, r)
+		}
+	}
+	if len(reqs) >= maxBlockRequestsPerPeer {
+		s.blockReqs[p] = reqs
+		s.blockReqLock.Unlock()
+		return nil
+	}
 	// lastRequestedHeight can only be increased.
 	for {
 		old := s.lastRequestedHeight.Load()
@@ -958,7 +1032,7 @@ func (s *Server) requestBlocks(p Peer) error {
 			if !s.lastRequestedHeight.CAS(old, needHeight) {
 				continue
 			}
-		} else if old < currHeight+(blockCacheSize-payload.MaxHashesCount) {
+		} else if old < currHeight+(cacheSize-payload.MaxHashesCount) {
 			needHeight = currHeight + 1
 			if peerHeight > old+payload.MaxHashesCount {
 				needHeight = old + payload.MaxHashesCount
@@ -967,11 +1041,18 @@ func (s *Server) requestBlocks(p Peer) error {
 				}
 			}
 		} else {
-			index := mrand.Intn(blockCacheSize / payload.MaxHashesCount)
+			index := mrand.Intn(int(cacheSize / payload.MaxHashesCount))
 			needHeight = currHeight + 1 + uint32(index*payload.MaxHashesCount)
 		}
 		break
 	}
+	last := needHeight + payload.MaxHashesCount - 1
+	if peerHeight < last {
+		last = peerHeight
+	}
+	s.blockReqs[p] = append(reqs, blockRequest{last: last, sent: time.Now()})
+	s.blockReqLock.Unlock()
+
 	payload := payload.NewGetBlockByIndex(needHeight, -1)
 	return p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, payload))
 }
@@ -1181,8 +1262,17 @@ func (s *Server) relayBlocksLoop() {
 	}
 }
 
+// SetTxVerifier sets additional transaction verifier to be used before
+// pooling transactions. It must be called before the Server is started.
+func (s *Server) SetTxVerifier(v TxVerifier) {
+	s.txVerifier = v
+}
+
 // verifyAndPoolTX verifies the TX and adds it to the local mempool.
 func (s *Server) verifyAndPoolTX(t *transaction.Transaction) error {
+	if err := s.txVerifier.VerifyTx(t); err != nil {
+		return fmt.Errorf("%w: %v", ErrTxRejected, err)
+	}
 	return s.chain.PoolTx(t)
 }
 
@@ -1191,6 +1281,7 @@ func (s *Server) verifyAndPoolTX(t *transaction.Transaction) error {
 func (s *Server) RelayTxn(t *transaction.Transaction) error {
 	err := s.verifyAndPoolTX(t)
 	if err == nil {
+		s.consensus.OnTransaction(t)
 		s.broadcastTX(t, nil)
 	}
 	return err
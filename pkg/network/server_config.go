@@ -81,6 +81,15 @@ type (
 
 		// ExtensiblePoolSize is size of the pool for extensible payloads from a single sender.
 		ExtensiblePoolSize int
+
+		// BlockQueueSize is the maximum number of blocks above the current
+		// height kept in the block queue. New blocks are not requested when
+		// the queue is nearly full.
+		BlockQueueSize int
+
+		// InstantBlocks enables immediate block production for
+		// single-validator networks.
+		InstantBlocks bool
 	}
 )
 
@@ -116,5 +125,7 @@ func NewServerConfig(cfg config.Config) ServerConfig {
 		P2PNotaryCfg:       appConfig.P2PNotary,
 		StateRootCfg:       appConfig.StateRoot,
 		ExtensiblePoolSize: appConfig.ExtensiblePoolSize,
+		BlockQueueSize:     appConfig.BlockQueueSize,
+		InstantBlocks:      appConfig.InstantBlocks,
 	}
 }
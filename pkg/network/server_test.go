@@ -5,20 +5,28 @@ import (
 	"math/big"
 	"net"
 	"strconv"
+	"sync"
 	atomic2 "sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
 	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +52,8 @@ func (f *fakeConsensus) Shutdown()                                     { f.stopp
 func (f *fakeConsensus) OnPayload(p *payload.Extensible)               { f.payloads = append(f.payloads, p) }
 func (f *fakeConsensus) OnTransaction(tx *transaction.Transaction)     { f.txs = append(f.txs, tx) }
 func (f *fakeConsensus) GetPayload(h util.Uint256) *payload.Extensible { panic("implement me") }
+func (f *fakeConsensus) GetState() consensus.State                     { return consensus.State{} }
+func (f *fakeConsensus) ForceBlock() error                             { return nil }
 
 func TestNewServer(t *testing.T) {
 	bc := &fakechain.FakeChain{}
@@ -234,6 +244,128 @@ func TestGetBlocksByIndex(t *testing.T) {
 	checkPingRespond(t, 3, 5000, 2124, 2624, 3124, 3624)
 }
 
+func TestRequestBlocksParallel(t *testing.T) {
+	s := startTestServer(t)
+	const peerHeight = 3 * payload.MaxHashesCount
+
+	var (
+		lock      sync.Mutex
+		requested = make(map[*localPeer][]uint32)
+	)
+	serve := func(start uint32) {
+		for i := start; i < start+payload.MaxHashesCount && i <= peerHeight; i++ {
+			b := block.New(false)
+			b.Index = i
+			require.NoError(t, s.handleBlockCmd(nil, b))
+		}
+	}
+	newPeer := func() *localPeer {
+		p := newLocalPeer(t, s)
+		p.handshaked = true
+		p.lastBlockIndex = peerHeight
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			if msg.Command == CMDGetBlockByIndex {
+				lock.Lock()
+				requested[p] = append(requested[p], msg.Payload.(*payload.GetBlockByIndex).IndexStart)
+				lock.Unlock()
+			}
+		}
+		return p
+	}
+	getRequests := func(p *localPeer) []uint32 {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]uint32(nil), requested[p]...)
+	}
+
+	ps := []*localPeer{newPeer(), newPeer(), newPeer()}
+	for i, p := range ps {
+		require.NoError(t, s.requestBlocks(p))
+		require.Equal(t, []uint32{1 + uint32(i)*payload.MaxHashesCount}, getRequests(p))
+	}
+
+	t.Run("limit per peer", func(t *testing.T) {
+		p := ps[0]
+		for i := 0; i < maxBlockRequestsPerPeer+2; i++ {
+			require.NoError(t, s.requestBlocks(p))
+		}
+		require.Equal(t, maxBlockRequestsPerPeer, len(getRequests(p)))
+	})
+
+	// Serve ranges in reverse order, blocks are to be added only after
+	// the first range is received.
+	serve(getRequests(ps[2])[0])
+	serve(getRequests(ps[1])[0])
+	require.Equal(t, uint32(0), s.chain.BlockHeight())
+	serve(getRequests(ps[0])[0])
+	require.Eventually(t, func() bool { return s.chain.BlockHeight() == peerHeight },
+		time.Second, 10*time.Millisecond)
+
+	// All requests are completed now, so new ones can be sent.
+	atomic2.StoreUint32(&s.chain.(*fakechain.FakeChain).Blockheight, peerHeight-1)
+	n := len(getRequests(ps[0]))
+	require.NoError(t, s.requestBlocks(ps[0]))
+	require.Equal(t, n+1, len(getRequests(ps[0])))
+}
+
+// slowChain is a chain which doesn't add blocks until allowed to.
+type slowChain struct {
+	*fakechain.FakeChain
+	release chan struct{}
+}
+
+func (c *slowChain) AddBlock(b *block.Block) error {
+	<-c.release
+	return c.FakeChain.AddBlock(b)
+}
+
+func TestRequestBlocksBackpressure(t *testing.T) {
+	chain := &slowChain{FakeChain: fakechain.NewFakeChain(), release: make(chan struct{})}
+	s, err := newServerFromConstructors(ServerConfig{Port: 0, UserAgent: "/test/", BlockQueueSize: 2 * payload.MaxHashesCount},
+		chain, zaptest.NewLogger(t), newFakeTransp, newFakeConsensus, newTestDiscovery)
+	require.NoError(t, err)
+	t.Cleanup(s.discovery.Close)
+	ch := startWithChannel(s)
+	t.Cleanup(func() {
+		close(chain.release)
+		s.Shutdown()
+		<-ch
+	})
+
+	var requests atomic.Int32
+	p := newLocalPeer(t, s)
+	p.handshaked = true
+	p.lastBlockIndex = 5000
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		if msg.Command == CMDGetBlockByIndex {
+			requests.Inc()
+		}
+	}
+
+	require.NoError(t, s.requestBlocks(p))
+	require.Equal(t, int32(1), requests.Load())
+
+	for i := uint32(1); i <= payload.MaxHashesCount+1; i++ {
+		b := block.New(false)
+		b.Index = i
+		require.NoError(t, s.handleBlockCmd(p, b))
+	}
+	// Chain is stuck processing the first block, so the queue is full.
+	require.True(t, s.bQueue.isFull())
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.requestBlocks(p))
+	}
+	require.Equal(t, int32(1), requests.Load())
+
+	// Let the chain process some blocks.
+	for i := 0; i < 10; i++ {
+		chain.release <- struct{}{}
+	}
+	require.Eventually(t, func() bool { return !s.bQueue.isFull() }, time.Second, 10*time.Millisecond)
+	require.NoError(t, s.requestBlocks(p))
+	require.Equal(t, int32(2), requests.Load())
+}
+
 func TestSendVersion(t *testing.T) {
 	var (
 		s = newTestServer(t, ServerConfig{Port: 0, UserAgent: "/test/"})
@@ -464,6 +596,11 @@ func TestTransaction(t *testing.T) {
 		s.testHandleMessage(t, nil, CMDTX, tx)
 		require.Contains(t, s.consensus.(*fakeConsensus).txs, tx)
 	})
+	t.Run("relay", func(t *testing.T) {
+		tx := newDummyTx()
+		require.NoError(t, s.RelayTxn(tx))
+		require.Contains(t, s.consensus.(*fakeConsensus).txs, tx)
+	})
 	t.Run("bad", func(t *testing.T) {
 		tx := newDummyTx()
 		s.chain.(*fakechain.FakeChain).PoolTxF = func(*transaction.Transaction) error { return core.ErrInsufficientFunds }
@@ -474,6 +611,91 @@ func TestTransaction(t *testing.T) {
 	})
 }
 
+func TestRelayTxnInstantBlocks(t *testing.T) {
+	cfg, err := config.LoadFile("../../config/protocol.unit_testnet.single.yml")
+	require.NoError(t, err)
+	chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	go chain.Run()
+	t.Cleanup(chain.Close)
+
+	s, err := newServerFromConstructors(ServerConfig{
+		UserAgent: "/test/",
+		Wallet: &config.Wallet{
+			Path:     "../consensus/testdata/wallet1.json",
+			Password: "one",
+		},
+		TimePerBlock:  time.Hour,
+		InstantBlocks: true,
+	}, chain, zaptest.NewLogger(t), newFakeTransp, consensus.NewService, newTestDiscovery)
+	require.NoError(t, err)
+	t.Cleanup(s.discovery.Close)
+	ch := startWithChannel(s)
+	t.Cleanup(func() {
+		s.Shutdown()
+		<-ch
+	})
+
+	priv := testchain.PrivateKeyByID(0)
+	rawScript, err := smartcontract.CreateMultiSigRedeemScript(1, keys.PublicKeys{priv.PublicKey()})
+	require.NoError(t, err)
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 100000)
+	tx.ValidUntilBlock = chain.BlockHeight() + 10
+	tx.Signers = []transaction.Signer{{Account: hash.Hash160(rawScript)}}
+	netFee, sizeDelta := fee.Calculate(chain.GetPolicer().GetBaseExecFee(), rawScript)
+	tx.NetworkFee = netFee + int64(io.GetVarSize(tx)+sizeDelta)*chain.FeePerByte()
+	tx.Scripts = []transaction.Witness{{
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, priv.SignHashable(uint32(testchain.Network()), tx)...),
+		VerificationScript: rawScript,
+	}}
+	require.NoError(t, s.RelayTxn(tx))
+
+	require.Eventually(t, func() bool { return chain.BlockHeight() == 1 }, 5*time.Second, 10*time.Millisecond)
+	b, err := chain.GetBlock(chain.GetHeaderHash(1))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(b.Transactions))
+	require.Equal(t, tx.Hash(), b.Transactions[0].Hash())
+}
+
+func TestTxVerifier(t *testing.T) {
+	s := startTestServer(t)
+	var pooled []*transaction.Transaction
+	s.chain.(*fakechain.FakeChain).PoolTxF = func(tx *transaction.Transaction) error {
+		pooled = append(pooled, tx)
+		return nil
+	}
+
+	banned := random.Uint160()
+	s.SetTxVerifier(TxVerifierFunc(func(tx *transaction.Transaction) error {
+		if tx.Sender().Equals(banned) {
+			return errors.New("banned sender")
+		}
+		return nil
+	}))
+
+	t.Run("rejected", func(t *testing.T) {
+		tx := newDummyTx()
+		tx.Signers[0].Account = banned
+		err := s.RelayTxn(tx)
+		require.True(t, errors.Is(err, ErrTxRejected), err)
+
+		s.testHandleMessage(t, nil, CMDTX, tx)
+		require.NotContains(t, s.consensus.(*fakeConsensus).txs, tx)
+		require.NotContains(t, pooled, tx)
+	})
+	t.Run("accepted", func(t *testing.T) {
+		tx := newDummyTx()
+		require.NoError(t, s.RelayTxn(tx))
+		require.Contains(t, pooled, tx)
+
+		tx = newDummyTx()
+		s.testHandleMessage(t, nil, CMDTX, tx)
+		require.Contains(t, s.consensus.(*fakeConsensus).txs, tx)
+		require.Contains(t, pooled, tx)
+	})
+}
+
 func (s *Server) testHandleGetData(t *testing.T, invType payload.InventoryType, hs, notFound []util.Uint256, found payload.Payload) {
 	var recvResponse atomic.Bool
 	var recvNotFound atomic.Bool
@@ -518,7 +740,7 @@ func TestGetData(t *testing.T) {
 	t.Run("p2pNotaryRequest", func(t *testing.T) {
 		mainTx := &transaction.Transaction{
 			Attributes:      []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}},
-			Script:          []byte{0, 1, 2},
+			Script:          []byte{byte(opcode.RET)},
 			ValidUntilBlock: 123,
 			Signers:         []transaction.Signer{{Account: random.Uint160()}},
 			Scripts:         []transaction.Witness{{InvocationScript: []byte{1, 2, 3}, VerificationScript: []byte{1, 2, 3}}},
@@ -715,7 +937,7 @@ func TestInv(t *testing.T) {
 		})
 	})
 	t.Run("p2pNotaryRequest", func(t *testing.T) {
-		fallbackTx := transaction.New(random.Bytes(100), 123)
+		fallbackTx := transaction.New([]byte{byte(opcode.PUSH1)}, 123)
 		fallbackTx.Signers = []transaction.Signer{{Account: random.Uint160()}, {Account: random.Uint160()}}
 		fallbackTx.Size()
 		fallbackTx.Hash()
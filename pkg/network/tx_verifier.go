@@ -0,0 +1,36 @@
+package network
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// ErrTxRejected is returned for transactions rejected by TxVerifier.
+var ErrTxRejected = errors.New("transaction rejected by verifier")
+
+// TxVerifier is an additional transaction check performed by the Server
+// before pooling any transaction (received from peers or relayed locally). It
+// allows to implement custom node policies (like sender blocklists or fee
+// floors) on top of the standard verification. Returning an error rejects the
+// transaction.
+type TxVerifier interface {
+	VerifyTx(*transaction.Transaction) error
+}
+
+// TxVerifierFunc is an adapter to allow the use of ordinary functions as
+// TxVerifier.
+type TxVerifierFunc func(*transaction.Transaction) error
+
+// VerifyTx implements TxVerifier interface.
+func (f TxVerifierFunc) VerifyTx(t *transaction.Transaction) error {
+	return f(t)
+}
+
+// nopTxVerifier is the default TxVerifier accepting all transactions.
+type nopTxVerifier struct{}
+
+// VerifyTx implements TxVerifier interface.
+func (nopTxVerifier) VerifyTx(*transaction.Transaction) error {
+	return nil
+}
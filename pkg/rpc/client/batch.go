@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Batch is a set of RPC calls to be sent to the server at once using
+// JSON-RPC batch requests. Calls are queued with Batch methods named after
+// the corresponding Client methods and then sent with Execute. Batch is not
+// thread-safe.
+type Batch struct {
+	c      *Client
+	calls  []batchCall
+	decode []func() (interface{}, error)
+}
+
+// BatchResult is a result of a single call from Batch.
+type BatchResult struct {
+	// Value is the call result, its type is the same as the type returned by
+	// the corresponding Client method (like *block.Block for GetBlockByIndex).
+	// It's nil if Err is not nil.
+	Value interface{}
+	// Err is an error returned for this particular call.
+	Err error
+}
+
+// Batch returns a new empty batch of calls for this client. You should
+// initialize network magic with Init before executing batches containing
+// calls that require it.
+func (c *Client) Batch() *Batch {
+	return &Batch{c: c}
+}
+
+// Len returns the number of calls queued.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// add queues a call with a result to unmarshal the response into and a
+// function converting it to the final value.
+func (b *Batch) add(method string, params request.RawParams, res interface{}, decode func() (interface{}, error)) *Batch {
+	b.calls = append(b.calls, batchCall{
+		method: method,
+		params: params,
+		result: res,
+	})
+	b.decode = append(b.decode, decode)
+	return b
+}
+
+// GetApplicationLog queues GetApplicationLog call.
+func (b *Batch) GetApplicationLog(hash util.Uint256, trig *trigger.Type) *Batch {
+	var (
+		params = request.NewRawParams(hash.StringLE())
+		resp   = new(result.ApplicationLog)
+	)
+	if trig != nil {
+		params.Values = append(params.Values, trig.String())
+	}
+	return b.add("getapplicationlog", params, resp, func() (interface{}, error) {
+		return resp, nil
+	})
+}
+
+// GetBlockByIndex queues GetBlockByIndex call.
+func (b *Batch) GetBlockByIndex(index uint32) *Batch {
+	var resp []byte
+	return b.add("getblock", request.NewRawParams(index), &resp, func() (interface{}, error) {
+		if !b.c.initDone {
+			return nil, errNetworkNotInitialized
+		}
+		r := io.NewBinReaderFromBuf(resp)
+		blk := block.New(b.c.StateRootInHeader())
+		blk.DecodeBinary(r)
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return blk, nil
+	})
+}
+
+// GetBlockByIndexVerbose queues GetBlockByIndexVerbose call.
+func (b *Batch) GetBlockByIndexVerbose(index uint32) *Batch {
+	var resp = new(result.Block)
+	return b.add("getblock", request.NewRawParams(index, 1), resp, func() (interface{}, error) {
+		return resp, nil
+	})
+}
+
+// GetBlockHash queues GetBlockHash call.
+func (b *Batch) GetBlockHash(index uint32) *Batch {
+	var resp util.Uint256
+	return b.add("getblockhash", request.NewRawParams(index), &resp, func() (interface{}, error) {
+		return resp, nil
+	})
+}
+
+// GetRawTransaction queues GetRawTransaction call.
+func (b *Batch) GetRawTransaction(hash util.Uint256) *Batch {
+	var resp []byte
+	return b.add("getrawtransaction", request.NewRawParams(hash.StringLE()), &resp, func() (interface{}, error) {
+		return transaction.NewTransactionFromBytes(resp)
+	})
+}
+
+// GetRawTransactionVerbose queues GetRawTransactionVerbose call.
+func (b *Batch) GetRawTransactionVerbose(hash util.Uint256) *Batch {
+	var resp = new(result.TransactionOutputRaw)
+	return b.add("getrawtransaction", request.NewRawParams(hash.StringLE(), 1), resp, func() (interface{}, error) {
+		return resp, nil
+	})
+}
+
+// Execute sends all queued calls to the server (using as few requests as
+// possible) and returns their results in the same order the calls were
+// queued in. Errors of individual calls are returned in their results and
+// don't affect other calls, the error returned is only non-nil if the batch
+// can't be sent at all. Cancelling the context aborts HTTP request in
+// progress, WSClient (sending calls one by one) checks it before every call.
+// Executed batch can't be reused.
+func (b *Batch) Execute(ctx context.Context) ([]BatchResult, error) {
+	errs, err := b.c.sendBatch(ctx, b.calls)
+	if err != nil {
+		return nil, err
+	}
+	var res = make([]BatchResult, len(b.calls))
+	for i := range res {
+		if errs[i] != nil {
+			res[i].Err = errs[i]
+			continue
+		}
+		res[i].Value, res[i].Err = b.decode[i]()
+		if res[i].Err != nil {
+			res[i].Value = nil
+		}
+	}
+	return res, nil
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
@@ -16,6 +17,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
 const (
@@ -23,6 +25,9 @@ const (
 	defaultRequestTimeout = 4 * time.Second
 	// Number of blocks after which cache is expired.
 	cacheTimeout = 100
+	// maxBatchSize is the maximum number of requests sent in a single batch,
+	// it's the limit used by the server.
+	maxBatchSize = 100
 )
 
 // Client represents the middleman for executing JSON RPC calls
@@ -32,10 +37,12 @@ type Client struct {
 	endpoint          *url.URL
 	network           netmode.Magic
 	stateRootInHeader bool
+	attributeFeeBase  int64
 	initDone          bool
 	ctx               context.Context
 	opts              Options
 	requestF          func(*request.Raw) (*response.Raw, error)
+	batchRequestF     func(context.Context, []*request.Raw) ([]*response.Raw, error)
 	cache             cache
 }
 
@@ -50,12 +57,34 @@ type Options struct {
 	CACert         string
 	DialTimeout    time.Duration
 	RequestTimeout time.Duration
+	// GasBreakdown makes InvokeScript, InvokeFunction and
+	// InvokeContractVerify request GAS consumption breakdown (it's a
+	// NeoGo-specific extension, so it won't work with C# nodes).
+	GasBreakdown bool
 }
 
 // cache stores cache values for the RPC client methods.
 type cache struct {
 	calculateValidUntilBlock calculateValidUntilBlockCache
 	nativeHashes             map[string]util.Uint160
+	tokens                   *tokenCache
+}
+
+// tokenCache stores metadata of tokens requested via the client. Token
+// symbol, decimals and name are not expected to change, so they're requested
+// only once for every token unless invalidated.
+type tokenCache struct {
+	lock   sync.RWMutex
+	tokens map[util.Uint160]*tokenMetadata
+}
+
+// tokenMetadata is a set of cached token properties, nil values are not
+// cached yet.
+type tokenMetadata struct {
+	symbol   *string
+	decimals *int64
+	// info is the result of the last successful token info request.
+	info *wallet.Token
 }
 
 // calculateValidUntilBlockCache stores cached number of validators and
@@ -100,10 +129,12 @@ func New(ctx context.Context, endpoint string, opts Options) (*Client, error) {
 		endpoint: url,
 		cache: cache{
 			nativeHashes: make(map[string]util.Uint160),
+			tokens:       &tokenCache{tokens: make(map[util.Uint160]*tokenMetadata)},
 		},
 	}
 	cl.opts = opts
 	cl.requestF = cl.makeHTTPRequest
+	cl.batchRequestF = cl.makeHTTPBatchRequest
 	return cl, nil
 }
 
@@ -117,6 +148,7 @@ func (c *Client) Init() error {
 	}
 	c.network = version.Magic
 	c.stateRootInHeader = version.StateRootInHeader
+	c.attributeFeeBase = version.AttributeFeeBase
 	neoContractHash, err := c.GetContractStateByAddressOrName(nativenames.Neo)
 	if err != nil {
 		return fmt.Errorf("failed to get NEO contract scripthash: %w", err)
@@ -156,29 +188,161 @@ func (c *Client) performRequest(method string, p request.RawParams, v interface{
 	return json.Unmarshal(raw.Result, v)
 }
 
+// batchCall is a single call of a batch request.
+type batchCall struct {
+	method string
+	params request.RawParams
+	result interface{}
+}
+
+// performBatchRequest sends given calls in JSON-RPC batches and unmarshals
+// their results. It fails if any of the calls returns an error.
+func (c *Client) performBatchRequest(calls []batchCall) error {
+	errs, err := c.sendBatch(c.ctx, calls)
+	if err != nil {
+		return err
+	}
+	for i := range errs {
+		if errs[i] != nil {
+			return fmt.Errorf("request #%d: %w", i, errs[i])
+		}
+	}
+	return nil
+}
+
+// sendBatch sends given calls in JSON-RPC batches (of up to maxBatchSize
+// requests each) and unmarshals their results. It returns individual errors
+// of all calls (nil for successful ones) and an error if some batch can't be
+// sent at all. Context cancellation is checked before sending every batch.
+func (c *Client) sendBatch(ctx context.Context, calls []batchCall) ([]error, error) {
+	var errs = make([]error, len(calls))
+	for off := 0; off < len(calls); off += maxBatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n := len(calls) - off
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		var rs = make([]*request.Raw, n)
+		for i := range rs {
+			rs[i] = &request.Raw{
+				JSONRPC:   request.JSONRPCVersion,
+				Method:    calls[off+i].method,
+				RawParams: calls[off+i].params.Values,
+				ID:        i + 1,
+			}
+		}
+		raws, err := c.batchRequestF(ctx, rs)
+		if err != nil {
+			return nil, err
+		}
+		for i, raw := range raws {
+			if raw.Error != nil {
+				errs[off+i] = raw.Error
+			} else if raw.Result == nil {
+				errs[off+i] = errors.New("no result returned")
+			} else if err := json.Unmarshal(raw.Result, calls[off+i].result); err != nil {
+				errs[off+i] = err
+			}
+		}
+	}
+	return errs, nil
+}
+
 func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
-	var (
-		buf = new(bytes.Buffer)
-		raw = new(response.Raw)
-	)
+	var raw = new(response.Raw)
 
-	if err := json.NewEncoder(buf).Encode(r); err != nil {
+	if err := c.doHTTPRequest(context.Background(), r, raw); err != nil {
 		return nil, err
 	}
+	return raw, nil
+}
 
-	req, err := http.NewRequest("POST", c.endpoint.String(), buf)
-	if err != nil {
+// makeHTTPBatchRequest sends requests in a single HTTP request and returns
+// responses in the same order. Requests are expected to have distinct IDs.
+// HTTP request is aborted if the context is cancelled.
+func (c *Client) makeHTTPBatchRequest(ctx context.Context, rs []*request.Raw) ([]*response.Raw, error) {
+	var data json.RawMessage
+
+	if err := c.doHTTPRequest(ctx, rs, &data); err != nil {
 		return nil, err
 	}
+	// The whole batch can be rejected with a single error response.
+	if d := bytes.TrimSpace(data); len(d) != 0 && d[0] == '{' {
+		raw := new(response.Raw)
+		if err := json.Unmarshal(d, raw); err != nil {
+			return nil, fmt.Errorf("JSON decoding: %w", err)
+		}
+		if raw.Error != nil {
+			return nil, raw.Error
+		}
+		return nil, errors.New("single response for batch request")
+	}
+	var raws []*response.Raw
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, fmt.Errorf("JSON decoding: %w", err)
+	}
+	var res = make([]*response.Raw, len(rs))
+	for _, raw := range raws {
+		var id int
+		if err := json.Unmarshal(raw.ID, &id); err != nil || id < 1 || id > len(rs) || res[id-1] != nil {
+			return nil, fmt.Errorf("unexpected response ID %s", string(raw.ID))
+		}
+		res[id-1] = raw
+	}
+	for i := range res {
+		if res[i] == nil {
+			return nil, fmt.Errorf("no response for request #%d", i)
+		}
+	}
+	return res, nil
+}
+
+// makeSequentialBatchRequest sends requests one by one, it's used for
+// transports that don't support batching. Context is checked before sending
+// every request.
+func (c *Client) makeSequentialBatchRequest(ctx context.Context, rs []*request.Raw) ([]*response.Raw, error) {
+	var res = make([]*response.Raw, len(rs))
+	for i := range rs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		raw, err := c.requestF(rs[i])
+		if raw != nil && raw.Error != nil {
+			res[i] = raw
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res[i] = raw
+	}
+	return res, nil
+}
+
+// doHTTPRequest POSTs JSON-encoded body to the endpoint and decodes the
+// response into res.
+func (c *Client) doHTTPRequest(ctx context.Context, body interface{}, res interface{}) error {
+	var buf = new(bytes.Buffer)
+
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint.String(), buf)
+	if err != nil {
+		return err
+	}
 	resp, err := c.cli.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	// The node might send us proper JSON anyway, so look there first and if
 	// it parses, then it has more relevant data than HTTP error code.
-	err = json.NewDecoder(resp.Body).Decode(raw)
+	err = json.NewDecoder(resp.Body).Decode(res)
 	if err != nil {
 		if resp.StatusCode != http.StatusOK {
 			err = fmt.Errorf("HTTP %d/%s", resp.StatusCode, http.StatusText(resp.StatusCode))
@@ -186,10 +350,7 @@ func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
 			err = fmt.Errorf("JSON decoding: %w", err)
 		}
 	}
-	if err != nil {
-		return nil, err
-	}
-	return raw, nil
+	return err
 }
 
 // Ping attempts to create a connection to the endpoint.
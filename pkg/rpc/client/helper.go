@@ -4,6 +4,7 @@ import (
 	"crypto/elliptic"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
@@ -42,6 +43,12 @@ func topIntFromStack(st []stackitem.Item) (int64, error) {
 	return bi.Int64(), nil
 }
 
+// topBigIntFromStack returns the top integer value from stack as big.Int.
+func topBigIntFromStack(st []stackitem.Item) (*big.Int, error) {
+	index := len(st) - 1 // top stack element is last in the array
+	return st[index].TryInteger()
+}
+
 // topPublicKeysFromStack returns the top array of public keys from stack.
 func topPublicKeysFromStack(st []stackitem.Item) (keys.PublicKeys, error) {
 	index := len(st) - 1 // top stack element is last in the array
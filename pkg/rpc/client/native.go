@@ -5,12 +5,16 @@ package client
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
 // GetOraclePrice invokes `getPrice` method on a native Oracle contract.
@@ -116,3 +120,50 @@ func (c *Client) NNSIsAvailable(name string) (bool, error) {
 	}
 	return topBoolFromStack(result.Stack)
 }
+
+// GetClaimableGAS invokes `unclaimedGas` method on a native NEO contract and
+// returns the amount of GAS that can be claimed by the given account if
+// claimed in the next block.
+func (c *Client) GetClaimableGAS(account util.Uint160) (*big.Int, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	result, err := c.InvokeFunction(neoHash, "unclaimedGas", []smartcontract.Parameter{
+		{
+			Type:  smartcontract.Hash160Type,
+			Value: account,
+		},
+		{
+			Type:  smartcontract.IntegerType,
+			Value: int64(count),
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return nil, fmt.Errorf("`unclaimedGas`: %w", err)
+	}
+	return topBigIntFromStack(result.Stack)
+}
+
+// ClaimGAS claims GAS accrued for NEO held by the given account. GAS is
+// distributed to NEO holders on NEO transfers, so it sends zero NEO transfer
+// from acc to itself and returns the hash of the transaction sent.
+func (c *Client) ClaimGAS(acc *wallet.Account) (util.Uint256, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	h, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("bad account address: %w", err)
+	}
+	return c.TransferNEP17(acc, h, neoHash, 0, 0, nil, nil)
+}
@@ -8,8 +8,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
-// nepDecimals invokes `decimals` NEP* method on a specified contract.
+// nepDecimals invokes `decimals` NEP* method on a specified contract. The result
+// is cached.
 func (c *Client) nepDecimals(tokenHash util.Uint160) (int64, error) {
+	if m := c.cache.tokens.get(tokenHash); m != nil && m.decimals != nil {
+		return *m.decimals, nil
+	}
 	result, err := c.InvokeFunction(tokenHash, "decimals", []smartcontract.Parameter{}, nil)
 	if err != nil {
 		return 0, err
@@ -19,11 +23,20 @@ func (c *Client) nepDecimals(tokenHash util.Uint160) (int64, error) {
 		return 0, err
 	}
 
-	return topIntFromStack(result.Stack)
+	decimals, err := topIntFromStack(result.Stack)
+	if err != nil {
+		return 0, err
+	}
+	c.cache.tokens.update(tokenHash, func(m *tokenMetadata) { m.decimals = &decimals })
+	return decimals, nil
 }
 
-// nepSymbol invokes `symbol` NEP* method on a specified contract.
+// nepSymbol invokes `symbol` NEP* method on a specified contract. The result
+// is cached.
 func (c *Client) nepSymbol(tokenHash util.Uint160) (string, error) {
+	if m := c.cache.tokens.get(tokenHash); m != nil && m.symbol != nil {
+		return *m.symbol, nil
+	}
 	result, err := c.InvokeFunction(tokenHash, "symbol", []smartcontract.Parameter{}, nil)
 	if err != nil {
 		return "", err
@@ -33,7 +46,12 @@ func (c *Client) nepSymbol(tokenHash util.Uint160) (string, error) {
 		return "", err
 	}
 
-	return topStringFromStack(result.Stack)
+	symbol, err := topStringFromStack(result.Stack)
+	if err != nil {
+		return "", err
+	}
+	c.cache.tokens.update(tokenHash, func(m *tokenMetadata) { m.symbol = &symbol })
+	return symbol, nil
 }
 
 // nepTotalSupply invokes `totalSupply` NEP* method on a specified contract.
@@ -74,8 +92,12 @@ func (c *Client) nepBalanceOf(tokenHash, acc util.Uint160, tokenID *string) (int
 	return topIntFromStack(result.Stack)
 }
 
-// nepTokenInfo returns full NEP* token info.
+// nepTokenInfo returns full NEP* token info. The result is cached.
 func (c *Client) nepTokenInfo(tokenHash util.Uint160, standard string) (*wallet.Token, error) {
+	if m := c.cache.tokens.get(tokenHash); m != nil && m.info != nil && m.info.Standard == standard {
+		tok := *m.info
+		return &tok, nil
+	}
 	cs, err := c.GetContractStateByHash(tokenHash)
 	if err != nil {
 		return nil, err
@@ -98,5 +120,50 @@ func (c *Client) nepTokenInfo(tokenHash util.Uint160, standard string) (*wallet.
 	if err != nil {
 		return nil, err
 	}
-	return wallet.NewToken(tokenHash, cs.Manifest.Name, symbol, decimals, standard), nil
+	tok := wallet.NewToken(tokenHash, cs.Manifest.Name, symbol, decimals, standard)
+	c.cache.tokens.update(tokenHash, func(m *tokenMetadata) {
+		info := *tok
+		m.info = &info
+	})
+	return tok, nil
+}
+
+// InvalidateTokenCache drops cached metadata (symbol, decimals and token info)
+// of the specified token, so that it's requested from the server next time.
+// All cached tokens are dropped if no hashes are given.
+func (c *Client) InvalidateTokenCache(tokenHashes ...util.Uint160) {
+	tc := c.cache.tokens
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	if len(tokenHashes) == 0 {
+		tc.tokens = make(map[util.Uint160]*tokenMetadata)
+		return
+	}
+	for _, h := range tokenHashes {
+		delete(tc.tokens, h)
+	}
+}
+
+// get returns a copy of cached token metadata or nil if there is none.
+func (tc *tokenCache) get(h util.Uint160) *tokenMetadata {
+	tc.lock.RLock()
+	defer tc.lock.RUnlock()
+	m, ok := tc.tokens[h]
+	if !ok {
+		return nil
+	}
+	res := *m
+	return &res
+}
+
+// update applies f to cached token metadata creating it if needed.
+func (tc *tokenCache) update(h util.Uint160, f func(*tokenMetadata)) {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	m, ok := tc.tokens[h]
+	if !ok {
+		m = new(tokenMetadata)
+		tc.tokens[h] = m
+	}
+	f(m)
 }
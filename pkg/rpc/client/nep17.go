@@ -1,19 +1,44 @@
 package client
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
+// nep17BalancePrefix is the storage prefix used by native NEP17 contracts for
+// account balances.
+const nep17BalancePrefix = 20
+
+// historyPageSize is the number of transfers requested per
+// getnep17transfers call by GetAddressHistory.
+const historyPageSize = 1000
+
+// AddressHistoryItem is a single NEP17 transfer involving some address.
+type AddressHistoryItem struct {
+	result.NEP17Transfer
+	// Incoming is true for transfers made to the address and false for
+	// transfers made from it.
+	Incoming bool
+}
+
 // TransferTarget represents target address, token amount and data for transfer.
 type TransferTarget struct {
 	Token   util.Uint160
@@ -54,6 +79,67 @@ func (c *Client) NEP17TokenInfo(tokenHash util.Uint160) (*wallet.Token, error) {
 	return c.nepTokenInfo(tokenHash, manifest.NEP17StandardName)
 }
 
+// VerifyBalance checks that the account has the expected balance of the given
+// token using state proofs. It fetches the state root for the current block
+// height, requests a proof for the account balance key and verifies it against
+// this root locally, so the balance value itself doesn't need to be trusted.
+// Notice that the state root is not checked to be signed by state validators
+// and that only tokens storing balances the way native NEP17 contracts do
+// (struct with the balance as its first field under 0x14+account key) are
+// supported. Missing balance key leads to an error as there is no proof for it.
+func (c *Client) VerifyBalance(account, token util.Uint160, expected *big.Int) (bool, error) {
+	cs, err := c.GetContractStateByHash(token)
+	if err != nil {
+		return false, fmt.Errorf("failed to get token contract state: %w", err)
+	}
+	height, err := c.GetStateHeight()
+	if err != nil {
+		return false, fmt.Errorf("failed to get state height: %w", err)
+	}
+	root, err := c.GetStateRootByHeight(height.BlockHeight)
+	if err != nil {
+		return false, fmt.Errorf("failed to get state root: %w", err)
+	}
+	key := append([]byte{nep17BalancePrefix}, account.BytesBE()...)
+	proof, err := c.GetProof(root.Root, token, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to get proof: %w", err)
+	}
+	skey := make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(skey, uint32(cs.ID))
+	copy(skey[4:], key)
+	if !bytes.Equal(proof.Key, skey) {
+		return false, errors.New("proof key mismatch")
+	}
+	val, ok := mpt.VerifyProof(root.Root, proof.Key, proof.Proof)
+	if !ok {
+		return false, errors.New("invalid proof")
+	}
+	balance, err := balanceFromStorageItem(val)
+	if err != nil {
+		return false, err
+	}
+	return balance.Cmp(expected) == 0, nil
+}
+
+// balanceFromStorageItem extracts balance from the serialized NEP17 balance
+// state.
+func balanceFromStorageItem(val []byte) (*big.Int, error) {
+	item, err := stackitem.DeserializeItem(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize balance: %w", err)
+	}
+	st, ok := item.(*stackitem.Struct)
+	if !ok || st.Len() == 0 {
+		return nil, errors.New("invalid balance format")
+	}
+	balance, err := st.Value().([]stackitem.Item)[0].TryInteger()
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance format: %w", err)
+	}
+	return balance, nil
+}
+
 // CreateNEP17TransferTx creates an invocation transaction for the 'transfer'
 // method of a given contract (token) to move specified amount of NEP17 assets
 // (in FixedN format using contract's number of decimals) to given account and
@@ -69,6 +155,19 @@ func (c *Client) CreateNEP17TransferTx(acc *wallet.Account, to util.Uint160,
 	}, cosigners)
 }
 
+// CreateNEP17TransferTxWithNonce is the same as CreateNEP17TransferTx, but
+// it uses the given nonce instead of the random one, so transactions created
+// with the same parameters (and valid until the same block) have the same hash.
+func (c *Client) CreateNEP17TransferTxWithNonce(acc *wallet.Account, to util.Uint160,
+	token util.Uint160, amount int64, gas int64, data interface{}, cosigners []SignerAccount, nonce uint32) (*transaction.Transaction, error) {
+	tx, err := c.CreateNEP17TransferTx(acc, to, token, amount, gas, data, cosigners)
+	if err != nil {
+		return nil, err
+	}
+	tx.SetNonce(nonce)
+	return tx, nil
+}
+
 // CreateNEP17MultiTransferTx creates an invocation transaction for performing
 // NEP17 transfers from a single sender to multiple recipients with the given
 // data and cosigners. Transaction's sender is included with the CalledByEntry
@@ -133,6 +232,54 @@ func (c *Client) CreateTxFromScript(script []byte, acc *wallet.Account, sysFee,
 	return tx, nil
 }
 
+// CreateTxFromScriptAutoScope is similar to CreateTxFromScript, but it picks
+// the scope of sender's signer automatically (any sender scope specified in
+// cosigners is ignored). The script is test-invoked with Global sender scope
+// and if it calls a single contract then CalledByEntry scope is used,
+// otherwise CustomContracts scope is set listing exactly the contracts
+// invoked. It relies on invoked contracts list returned by neo-go RPC server.
+func (c *Client) CreateTxFromScriptAutoScope(script []byte, acc *wallet.Account, sysFee, netFee int64,
+	cosigners []SignerAccount) (*transaction.Transaction, error) {
+	from, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bad sender account address: %w", err)
+	}
+	others := make([]SignerAccount, 0, len(cosigners))
+	for _, s := range cosigners {
+		if !s.Signer.Account.Equals(from) {
+			others = append(others, s)
+		}
+	}
+	sender := SignerAccount{
+		Signer: transaction.Signer{
+			Account: from,
+			Scopes:  transaction.Global,
+		},
+		Account: acc,
+	}
+	signers, _, err := getSigners(acc, append([]SignerAccount{sender}, others...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct tx signers: %w", err)
+	}
+	result, err := c.InvokeScript(script, signers)
+	if err != nil {
+		return nil, fmt.Errorf("can't determine invoked contracts: %w", err)
+	}
+	if result.State != "HALT" {
+		return nil, fmt.Errorf("can't determine invoked contracts: bad vm state: %s due to an error: %s", result.State, result.FaultException)
+	}
+	if len(result.Invocations) > 1 {
+		sender.Signer.Scopes = transaction.CustomContracts
+		sender.Signer.AllowedContracts = result.Invocations
+	} else {
+		sender.Signer.Scopes = transaction.CalledByEntry
+	}
+	if sysFee < 0 {
+		sysFee = result.GasConsumed
+	}
+	return c.CreateTxFromScript(script, acc, sysFee, netFee, append([]SignerAccount{sender}, others...))
+}
+
 // TransferNEP17 creates an invocation transaction that invokes 'transfer' method
 // on a given token to move specified amount of NEP17 assets (in FixedN format
 // using contract's number of decimals) to given account with data specified and
@@ -167,3 +314,62 @@ func (c *Client) MultiTransferNEP17(acc *wallet.Account, gas int64, recipients [
 
 	return c.SignAndPushTx(tx, acc, cosigners)
 }
+
+// GetAddressHistory returns all NEP17 transfers (native NEO and GAS ones
+// included, like GAS fee burns and rewards) involving the given address made
+// in blocks from `from` to `to` (both inclusive). Transfers are sorted
+// chronologically, as many getnep17transfers calls are made as needed to
+// fetch all of them. Both blocks must already be present in the chain.
+func (c *Client) GetAddressHistory(address string, from, to uint32) ([]AddressHistoryItem, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: %d > %d", from, to)
+	}
+	start, err := c.getBlockTimestamp(from)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := c.getBlockTimestamp(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []AddressHistoryItem
+	for page := 0; ; page++ {
+		var (
+			params = request.NewRawParams(address, start, stop, historyPageSize, page)
+			resp   = new(result.NEP17Transfers)
+		)
+		if err := c.performRequest("getnep17transfers", params, resp); err != nil {
+			return nil, fmt.Errorf("failed to get transfers page %d: %w", page, err)
+		}
+		for _, tr := range resp.Received {
+			res = append(res, AddressHistoryItem{NEP17Transfer: tr, Incoming: true})
+		}
+		for _, tr := range resp.Sent {
+			res = append(res, AddressHistoryItem{NEP17Transfer: tr})
+		}
+		if len(resp.Received)+len(resp.Sent) < historyPageSize {
+			break
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Index != res[j].Index {
+			return res[i].Index < res[j].Index
+		}
+		return res[i].NotifyIndex < res[j].NotifyIndex
+	})
+	return res, nil
+}
+
+// getBlockTimestamp returns timestamp of the block with the given index.
+func (c *Client) getBlockTimestamp(index uint32) (uint64, error) {
+	h, err := c.GetBlockHash(index)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block %d hash: %w", index, err)
+	}
+	hdr, err := c.GetBlockHeaderVerbose(h)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block %d header: %w", index, err)
+	}
+	return hdr.Timestamp, nil
+}
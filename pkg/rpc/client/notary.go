@@ -0,0 +1,120 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// CreateNotaryDepositTx creates a transaction transferring the given amount of
+// GAS from acc to the native Notary contract as a deposit for `to` account (acc
+// itself if it's nil) locked till the given height. The returned transaction is
+// not signed. You should initialize network magic with Init before calling
+// CreateNotaryDepositTx.
+func (c *Client) CreateNotaryDepositTx(acc *wallet.Account, to *util.Uint160, amount int64, till uint32, gas int64) (*transaction.Transaction, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	notaryHash, err := c.GetNativeContractHash(nativenames.Notary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native Notary hash: %w", err)
+	}
+	gasHash, err := c.GetNativeContractHash(nativenames.Gas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native GAS hash: %w", err)
+	}
+	var owner interface{}
+	if to != nil {
+		owner = *to
+	}
+	return c.CreateNEP17TransferTx(acc, notaryHash, gasHash, amount, gas, []interface{}{owner, int64(till)}, nil)
+}
+
+// NotaryDeposit creates a notary deposit transaction (see CreateNotaryDepositTx),
+// signs it with acc and sends it to the network returning just a hash of it.
+func (c *Client) NotaryDeposit(acc *wallet.Account, to *util.Uint160, amount int64, till uint32, gas int64) (util.Uint256, error) {
+	tx, err := c.CreateNotaryDepositTx(acc, to, amount, till, gas)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return c.SignAndPushTx(tx, acc, nil)
+}
+
+// CreateNotaryAssistedTx creates the main transaction of notary request from the
+// given script and cosigners meeting all of SignAndPushP2PNotaryRequest
+// requirements: native Notary contract is added as the last signer with None
+// scope, NotaryAssisted attribute is set with nKeys specified and network fee
+// includes the Notary fee. Witnesses contain verification scripts of cosigners
+// with empty invocation scripts (to be filled by signing parties) and a dummy
+// Notary witness. If sysFee is negative, it is determined via result of
+// `invokescript` RPC. You should initialize network magic with Init before
+// calling CreateNotaryAssistedTx.
+func (c *Client) CreateNotaryAssistedTx(script []byte, sysFee int64, nKeys uint8, cosigners []SignerAccount) (*transaction.Transaction, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if len(cosigners) == 0 {
+		return nil, errors.New("no cosigners")
+	}
+	notaryHash, err := c.GetNativeContractHash(nativenames.Notary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native Notary hash: %w", err)
+	}
+	signers := make([]transaction.Signer, 0, len(cosigners)+1)
+	accounts := make([]*wallet.Account, 0, len(cosigners)+1)
+	scripts := make([]transaction.Witness, 0, len(cosigners)+1)
+	for _, s := range cosigners {
+		if s.Signer.Account.Equals(notaryHash) {
+			return nil, errors.New("cosigners shouldn't include Notary contract")
+		}
+		signers = append(signers, s.Signer)
+		accounts = append(accounts, s.Account)
+		scripts = append(scripts, transaction.Witness{
+			InvocationScript:   []byte{},
+			VerificationScript: s.Account.GetVerificationScript(),
+		})
+	}
+	signers = append(signers, transaction.Signer{Account: notaryHash, Scopes: transaction.None})
+	// Don't call `verify` for Notary contract witness, because it will fail.
+	accounts = append(accounts, &wallet.Account{Contract: &wallet.Contract{Deployed: false}})
+	scripts = append(scripts, transaction.Witness{
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), 64}, make([]byte, 64)...),
+		VerificationScript: []byte{},
+	})
+
+	if sysFee < 0 {
+		result, err := c.InvokeScript(script, signers)
+		if err != nil {
+			return nil, fmt.Errorf("can't add system fee to transaction: %w", err)
+		}
+		if result.State != "HALT" {
+			return nil, fmt.Errorf("can't add system fee to transaction: bad vm state: %s due to an error: %s", result.State, result.FaultException)
+		}
+		sysFee = result.GasConsumed
+	}
+	tx := transaction.New(script, sysFee)
+	tx.Signers = signers
+	tx.Attributes = []transaction.Attribute{{
+		Type:  transaction.NotaryAssistedT,
+		Value: &transaction.NotaryAssisted{NKeys: nKeys},
+	}}
+	tx.ValidUntilBlock, err = c.CalculateValidUntilBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add validUntilBlock to transaction: %w", err)
+	}
+	notaryFee, err := c.CalculateNotaryFee(nKeys)
+	if err != nil {
+		return nil, err
+	}
+	err = c.AddNetworkFee(tx, notaryFee, accounts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add network fee: %w", err)
+	}
+	tx.Scripts = scripts
+	return tx, nil
+}
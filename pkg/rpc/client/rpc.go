@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -18,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -28,6 +31,9 @@ import (
 
 var errNetworkNotInitialized = errors.New("RPC client network is not initialized")
 
+// waitForHeightInterval is the block count polling interval of WaitForHeight.
+const waitForHeightInterval = 100 * time.Millisecond
+
 // CalculateNetworkFee calculates network fee for transaction. The transaction may
 // have empty witnesses for contract signers and may have only verification scripts
 // filled for standard sig/multisig signers.
@@ -57,6 +63,55 @@ func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*resu
 	return resp, nil
 }
 
+// GetApplicationLogs returns application logs for the given transaction or
+// block hashes in one request, nil elements are returned for unknown hashes.
+// The number of hashes is limited by 100 in neo-go server.
+func (c *Client) GetApplicationLogs(hashes []util.Uint256, trig *trigger.Type) ([]*result.ApplicationLog, error) {
+	var (
+		strHashes = make([]string, len(hashes))
+		params    request.RawParams
+		resp      []*result.ApplicationLog
+	)
+	for i := range hashes {
+		strHashes[i] = hashes[i].StringLE()
+	}
+	params = request.NewRawParams(strHashes)
+	if trig != nil {
+		params.Values = append(params.Values, trig.String())
+	}
+	if err := c.performRequest("getapplicationlogs", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBlockApplicationLog returns block-level (OnPersist and PostPersist)
+// executions of the block with the given hash. An error is returned if the
+// hash is not a block hash.
+func (c *Client) GetBlockApplicationLog(hash util.Uint256) (*result.ApplicationLog, error) {
+	log, err := c.GetApplicationLog(hash, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range log.Executions {
+		if log.Executions[i].Trigger&(trigger.OnPersist|trigger.PostPersist) == 0 {
+			return nil, fmt.Errorf("%s is not a block hash", hash.StringLE())
+		}
+	}
+	return log, nil
+}
+
+// GetInvokedContracts returns a sorted list of contracts invoked during
+// the execution of transaction or block with the given hash, trig can be used
+// to filter executions the same way it's done in GetApplicationLog.
+func (c *Client) GetInvokedContracts(hash util.Uint256, trig *trigger.Type) ([]util.Uint160, error) {
+	log, err := c.GetApplicationLog(hash, trig)
+	if err != nil {
+		return nil, err
+	}
+	return log.InvokedContracts(), nil
+}
+
 // GetBestBlockHash returns the hash of the tallest block in the main chain.
 func (c *Client) GetBestBlockHash() (util.Uint256, error) {
 	var resp = util.Uint256{}
@@ -75,6 +130,34 @@ func (c *Client) GetBlockCount() (uint32, error) {
 	return resp, nil
 }
 
+// WaitForHeight polls the node (using getblockcount) until it reaches the given
+// block height or the timeout expires, the latter is reported as an error.
+// It's mostly useful for tests.
+func (c *Client) WaitForHeight(h uint32, timeout time.Duration) error {
+	var (
+		timer  = time.NewTimer(timeout)
+		ticker = time.NewTicker(waitForHeightInterval)
+	)
+	defer timer.Stop()
+	defer ticker.Stop()
+	for {
+		count, err := c.GetBlockCount()
+		if err != nil {
+			return err
+		}
+		if count > h {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			return fmt.Errorf("timeout waiting for height %d, current block count is %d", h, count)
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		}
+	}
+}
+
 // GetBlockByIndex returns a block by its height. You should initialize network magic
 // with Init before calling GetBlockByIndex.
 func (c *Client) GetBlockByIndex(index uint32) (*block.Block, error) {
@@ -135,6 +218,33 @@ func (c *Client) getBlockVerbose(params request.RawParams) (*result.Block, error
 	return resp, nil
 }
 
+// GetBlockWithLogsByIndex returns a block wrapper with additional metadata
+// by its index along with application logs of all block transactions and
+// block-level executions. You should initialize network magic with Init before
+// calling GetBlockWithLogsByIndex. This method is specific to neo-go.
+func (c *Client) GetBlockWithLogsByIndex(index uint32) (*result.BlockWithLogs, error) {
+	return c.getBlockWithLogs(request.NewRawParams(index))
+}
+
+// GetBlockWithLogsByHash returns a block wrapper with additional metadata by
+// its hash along with application logs of all block transactions and
+// block-level executions. You should initialize network magic with Init before
+// calling GetBlockWithLogsByHash. This method is specific to neo-go.
+func (c *Client) GetBlockWithLogsByHash(hash util.Uint256) (*result.BlockWithLogs, error) {
+	return c.getBlockWithLogs(request.NewRawParams(hash.StringLE()))
+}
+
+func (c *Client) getBlockWithLogs(params request.RawParams) (*result.BlockWithLogs, error) {
+	var resp = new(result.BlockWithLogs)
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if err := c.performRequest("getblockwithlogs", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBlockHash returns the hash value of the corresponding block, based on the specified index.
 func (c *Client) GetBlockHash(index uint32) (util.Uint256, error) {
 	var (
@@ -180,6 +290,33 @@ func (c *Client) GetBlockHeaderCount() (uint32, error) {
 	return resp, nil
 }
 
+// GetBlockHeaders returns up to count block headers starting from the given
+// index. The server can return less headers than requested (if it doesn't have
+// them or if count exceeds its limit), but never zero. This method is
+// specific to neo-go.
+func (c *Client) GetBlockHeaders(start, count uint32) ([]*block.Header, error) {
+	var (
+		params = request.NewRawParams(start, count)
+		resp   [][]byte
+	)
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	if err := c.performRequest("getblockheaders", params, &resp); err != nil {
+		return nil, err
+	}
+	res := make([]*block.Header, len(resp))
+	for i := range resp {
+		r := io.NewBinReaderFromBuf(resp[i])
+		res[i] = &block.Header{StateRootEnabled: c.StateRootInHeader()}
+		res[i].DecodeBinary(r)
+		if r.Err != nil {
+			return nil, fmt.Errorf("failed to decode header #%d: %w", i, r.Err)
+		}
+	}
+	return res, nil
+}
+
 // GetBlockHeaderVerbose returns the corresponding block header information from Json format string
 // according to the specified script hash.
 func (c *Client) GetBlockHeaderVerbose(hash util.Uint256) (*result.Header, error) {
@@ -217,6 +354,35 @@ func (c *Client) GetConnectionCount() (int, error) {
 	return resp, nil
 }
 
+// GetFeeHistogram returns fee per byte distribution of transactions currently
+// in the mempool and fee statistics for the given number of recent blocks (0
+// means server's default).
+func (c *Client) GetFeeHistogram(blocks int) (*result.FeeHistogram, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.FeeHistogram)
+	)
+	if blocks > 0 {
+		params.Values = append(params.Values, blocks)
+	}
+	if err := c.performRequest("getfeehistogram", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EstimateInclusionProbability estimates the probability for transaction with
+// the given fee per byte to be included into one of the next k blocks using
+// current fee histogram. See result.FeeHistogram.InclusionProbability for
+// details.
+func (c *Client) EstimateInclusionProbability(feePerByte int64, k int) (float64, error) {
+	h, err := c.GetFeeHistogram(0)
+	if err != nil {
+		return 0, err
+	}
+	return h.InclusionProbability(feePerByte, k), nil
+}
+
 // GetCommittee returns the current public keys of NEO nodes in committee.
 func (c *Client) GetCommittee() (keys.PublicKeys, error) {
 	var (
@@ -229,6 +395,35 @@ func (c *Client) GetCommittee() (keys.PublicKeys, error) {
 	return *resp, nil
 }
 
+// GetCommitteeAddress returns the script hash of the committee multisignature
+// account (that requires majority of committee members signatures) computed
+// from the current committee list.
+func (c *Client) GetCommitteeAddress() (util.Uint160, error) {
+	pubs, err := c.GetCommittee()
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	script, err := smartcontract.CreateMajorityMultiSigRedeemScript(pubs)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return smartcontract.ScriptHash(script), nil
+}
+
+// GetConsensusState returns the state of the current consensus round of the
+// consensus node. This method is a neo-go extension, it's not supported by
+// the C# node.
+func (c *Client) GetConsensusState() (*result.ConsensusState, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.ConsensusState)
+	)
+	if err := c.performRequest("getconsensusstate", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetContractStateByHash queries contract information, according to the contract script hash.
 func (c *Client) GetContractStateByHash(hash util.Uint160) (*state.Contract, error) {
 	return c.getContractState(hash.StringLE())
@@ -268,6 +463,34 @@ func (c *Client) GetNativeContracts() ([]state.NativeContract, error) {
 	return resp, nil
 }
 
+// ListContracts returns the given page (starting from 0) of deployed
+// (non-native) contracts sorted by ID. Pages contain up to 100 contracts, an
+// empty result means there are no more contracts. This method is specific to
+// neo-go.
+func (c *Client) ListContracts(page int) ([]result.ContractInfo, error) {
+	var (
+		params = request.NewRawParams(page)
+		resp   []result.ContractInfo
+	)
+	if err := c.performRequest("listcontracts", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNEP17Balance is a wrapper for getnep17balance RPC, it returns the balance
+// of the given token for the given account along with the height of the last
+// balance change. It doesn't invoke the token contract, so it's cheaper than
+// NEP17BalanceOf. This method is specific to neo-go.
+func (c *Client) GetNEP17Balance(tokenHash, acc util.Uint160) (*result.NEP17Balance, error) {
+	params := request.NewRawParams(tokenHash.StringLE(), acc.StringLE())
+	resp := new(result.NEP17Balance)
+	if err := c.performRequest("getnep17balance", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNEP17Balances is a wrapper for getnep17balances RPC.
 func (c *Client) GetNEP17Balances(address util.Uint160) (*result.NEP17Balances, error) {
 	params := request.NewRawParams(address.StringLE())
@@ -334,18 +557,31 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetRawMemPoolBySender returns the list of unconfirmed transactions in memory
+// sent by the given account. This method is a neo-go extension, it's not
+// supported by the C# node.
+func (c *Client) GetRawMemPoolBySender(sender util.Uint160) ([]util.Uint256, error) {
+	var (
+		params = request.NewRawParams(false, sender.StringLE())
+		resp   = new([]util.Uint256)
+	)
+	if err := c.performRequest("getrawmempool", params, resp); err != nil {
+		return *resp, err
+	}
+	return *resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash. You should initialize network magic
 // with Init before calling GetRawTransaction.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
 	var (
-		params = request.NewRawParams(hash.StringLE())
-		resp   []byte
-		err    error
+		resp []byte
+		err  error
 	)
 	if !c.initDone {
 		return nil, errNetworkNotInitialized
 	}
-	if err = c.performRequest("getrawtransaction", params, &resp); err != nil {
+	if resp, err = c.GetRawTransactionBytes(hash); err != nil {
 		return nil, err
 	}
 	tx, err := transaction.NewTransactionFromBytes(resp)
@@ -355,6 +591,20 @@ func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction,
 	return tx, nil
 }
 
+// GetRawTransactionBytes returns serialized transaction by its hash exactly
+// as it's returned by the server (without decoding and re-encoding it), which
+// can be useful for resubmitting it.
+func (c *Client) GetRawTransactionBytes(hash util.Uint256) ([]byte, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE())
+		resp   []byte
+	)
+	if err := c.performRequest("getrawtransaction", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransactionVerbose returns a transaction wrapper with additional
 // metadata by transaction's hash. You should initialize network magic
 // with Init before calling GetRawTransactionVerbose.
@@ -374,6 +624,76 @@ func (c *Client) GetRawTransactionVerbose(hash util.Uint256) (*result.Transactio
 	return resp, nil
 }
 
+// GetRawTransactionJSON returns a transaction by hash decoding it from its JSON
+// representation (requesting it without block metadata). Unlike GetRawTransaction
+// it doesn't require network magic to be initialized.
+func (c *Client) GetRawTransactionJSON(hash util.Uint256) (*transaction.Transaction, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE(), 1, true)
+		resp   = new(transaction.Transaction)
+	)
+	if err := c.performRequest("getrawtransaction", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetProof returns existence proof of storage item state by the given stateroot
+// historical contract hash and historical item key.
+func (c *Client) GetProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalKey []byte) (*result.ProofWithKey, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), historicalContractHash.StringLE(), historicalKey)
+		resp   = &result.ProofWithKey{}
+	)
+	if err := c.performRequest("getproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VerifyProof returns value by the given stateroot and proof. It returns nil
+// value if the proof is invalid.
+func (c *Client) VerifyProof(stateroot util.Uint256, proof *result.ProofWithKey) ([]byte, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), proof.String())
+		resp   = &result.VerifyProof{}
+	)
+	if err := c.performRequest("verifyproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// GetStateHeight returns current validated and local node state height.
+func (c *Client) GetStateHeight() (*result.StateHeight, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = &result.StateHeight{}
+	)
+	if err := c.performRequest("getstateheight", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStateRootByHeight returns state root for the specified height.
+func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	return c.getStateRoot(request.NewRawParams(height))
+}
+
+// GetStateRootByBlockHash returns state root for the block with the specified hash.
+func (c *Client) GetStateRootByBlockHash(hash util.Uint256) (*state.MPTRoot, error) {
+	return c.getStateRoot(request.NewRawParams(hash.StringLE()))
+}
+
+func (c *Client) getStateRoot(params request.RawParams) (*state.MPTRoot, error) {
+	var resp = new(state.MPTRoot)
+	if err := c.performRequest("getstateroot", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetStorageByID returns the stored value, according to the contract ID and the stored key.
 func (c *Client) GetStorageByID(id int32, key []byte) ([]byte, error) {
 	return c.getStorage(request.NewRawParams(id, base64.StdEncoding.EncodeToString(key)))
@@ -404,6 +724,25 @@ func (c *Client) GetTransactionHeight(hash util.Uint256) (uint32, error) {
 	return resp, nil
 }
 
+// TraceTransaction re-executes transaction with the given hash against the
+// state it was executed with and returns its execution result along with up to
+// maxSteps last executed instructions (the server default is used if it's 0).
+// This method is specific to neo-go and requires historical state to be kept
+// by the server.
+func (c *Client) TraceTransaction(hash util.Uint256, maxSteps int) (*result.TransactionTrace, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE())
+		resp   = new(result.TransactionTrace)
+	)
+	if maxSteps != 0 {
+		params.Values = append(params.Values, maxSteps)
+	}
+	if err := c.performRequest("tracetransaction", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetUnclaimedGas returns unclaimed GAS amount for the specified address.
 func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	var (
@@ -416,6 +755,31 @@ func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	return resp, nil
 }
 
+// GetUnclaimedGasBatch returns unclaimed GAS amounts for the specified
+// addresses using a single batch request. The result maps addresses to
+// their unclaimed GAS amounts.
+func (c *Client) GetUnclaimedGasBatch(addresses []string) (map[string]*big.Int, error) {
+	var (
+		calls = make([]batchCall, len(addresses))
+		resps = make([]result.UnclaimedGas, len(addresses))
+	)
+	for i := range addresses {
+		calls[i] = batchCall{
+			method: "getunclaimedgas",
+			params: request.NewRawParams(addresses[i]),
+			result: &resps[i],
+		}
+	}
+	if err := c.performBatchRequest(calls); err != nil {
+		return nil, err
+	}
+	var res = make(map[string]*big.Int, len(addresses))
+	for i := range addresses {
+		res[addresses[i]] = &resps[i].Unclaimed
+	}
+	return res, nil
+}
+
 // GetNextBlockValidators returns the current NEO consensus nodes information and voting status.
 func (c *Client) GetNextBlockValidators() ([]result.Validator, error) {
 	var (
@@ -428,6 +792,52 @@ func (c *Client) GetNextBlockValidators() ([]result.Validator, error) {
 	return *resp, nil
 }
 
+// GetNextBlockValidatorsVerbose is the same as GetNextBlockValidators, but
+// also returns accounts voting for every validator along with their NEO
+// balances.
+func (c *Client) GetNextBlockValidatorsVerbose() ([]result.Validator, error) {
+	var (
+		params = request.NewRawParams(true)
+		resp   = new([]result.Validator)
+	)
+	if err := c.performRequest("getnextblockvalidators", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
+// GetNextValidatorsAddress returns the script hash of the next block validators
+// multisignature account (that requires 2/3+1 of validators signatures). Next
+// block validators are taken from the native NEO contract, so unlike
+// GetNextBlockValidators it also works for validators that are not registered
+// candidates (like standby ones).
+func (c *Client) GetNextValidatorsAddress() (util.Uint160, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	result, err := c.InvokeFunction(neoHash, "getNextBlockValidators", []smartcontract.Parameter{}, nil)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("`getNextBlockValidators`: %w", err)
+	}
+	pubs, err := topPublicKeysFromStack(result.Stack)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	if len(pubs) == 0 {
+		return util.Uint160{}, errors.New("no next block validators")
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return smartcontract.ScriptHash(script), nil
+}
+
 // GetVersion returns the version information about the queried node.
 func (c *Client) GetVersion() (*result.Version, error) {
 	var (
@@ -441,18 +851,21 @@ func (c *Client) GetVersion() (*result.Version, error) {
 }
 
 // InvokeScript returns the result of the given script after running it true the VM.
+// Witnesses are optional, if specified, their number must match the number of
+// signers.
 // NOTE: This is a test invoke and will not affect the blockchain.
-func (c *Client) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+func (c *Client) InvokeScript(script []byte, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
 	var p = request.NewRawParams(script)
-	return c.invokeSomething("invokescript", p, signers)
+	return c.invokeSomething("invokescript", p, signers, witnesses...)
 }
 
 // InvokeFunction returns the results after calling the smart contract scripthash
-// with the given operation and parameters.
+// with the given operation and parameters. Witnesses are optional, if specified,
+// their number must match the number of signers.
 // NOTE: this is test invoke and will not affect the blockchain.
-func (c *Client) InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+func (c *Client) InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
 	var p = request.NewRawParams(contract.StringLE(), operation, params)
-	return c.invokeSomething("invokefunction", p, signers)
+	return c.invokeSomething("invokefunction", p, signers, witnesses...)
 }
 
 // InvokeContractVerify returns the results after calling `verify` method of the smart contract
@@ -470,19 +883,19 @@ func (c *Client) invokeSomething(method string, p request.RawParams, signers []t
 		if witnesses == nil {
 			p.Values = append(p.Values, signers)
 		} else {
-			if len(witnesses) != len(signers) {
-				return nil, fmt.Errorf("number of witnesses should match number of signers, got %d vs %d", len(witnesses), len(signers))
-			}
-			signersWithWitnesses := make([]request.SignerWithWitness, len(signers))
-			for i := range signersWithWitnesses {
-				signersWithWitnesses[i] = request.SignerWithWitness{
-					Signer:  signers[i],
-					Witness: witnesses[i],
-				}
+			signersWithWitnesses, err := request.NewSignersWithWitnesses(signers, witnesses)
+			if err != nil {
+				return nil, err
 			}
 			p.Values = append(p.Values, signersWithWitnesses)
 		}
 	}
+	if c.opts.GasBreakdown {
+		if signers == nil {
+			p.Values = append(p.Values, []transaction.Signer{})
+		}
+		p.Values = append(p.Values, true)
+	}
 	if err := c.performRequest(method, p, resp); err != nil {
 		return nil, err
 	}
@@ -504,6 +917,53 @@ func (c *Client) SendRawTransaction(rawTX *transaction.Transaction) (util.Uint25
 	return resp.Hash, nil
 }
 
+// SendRawTransactionIdempotent is the same as SendRawTransaction, but it
+// treats "already exists" server error as success and returns transaction hash
+// in this case, so it's safe to be used in retry loops. All other errors are
+// returned as is.
+func (c *Client) SendRawTransactionIdempotent(rawTX *transaction.Transaction) (util.Uint256, error) {
+	h, err := c.SendRawTransaction(rawTX)
+	if err != nil {
+		var rErr *response.Error
+		if errors.As(err, &rErr) && rErr.Code == response.ErrAlreadyExists.Code {
+			return rawTX.Hash(), nil
+		}
+		return util.Uint256{}, err
+	}
+	return h, nil
+}
+
+// SendAndWait sends given transaction to the network and waits for it to be
+// persisted (at most for the given timeout, that is rounded up to seconds)
+// returning its application log. Notice that HTTP request is still limited
+// by Options.RequestTimeout, so it should be bigger than the given timeout.
+func (c *Client) SendAndWait(rawTX *transaction.Transaction, timeout time.Duration) (*result.ApplicationLog, error) {
+	var (
+		seconds = int64((timeout + time.Second - 1) / time.Second)
+		params  = request.NewRawParams(rawTX.Bytes(), seconds)
+		resp    = new(result.ApplicationLog)
+	)
+	if err := c.performRequest("sendandwait", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VerifyTransaction checks whether given fully-signed transaction passes all
+// node-side verifications (witnesses, fees, ValidUntilBlock, etc.) without
+// relaying it to the network. If it does, the result of transaction script
+// test invocation is returned.
+func (c *Client) VerifyTransaction(tx *transaction.Transaction) (*result.Invoke, error) {
+	var (
+		params = request.NewRawParams(tx.Bytes())
+		resp   = new(result.Invoke)
+	)
+	if err := c.performRequest("verifytransaction", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // SubmitBlock broadcasts a raw block over the NEO network.
 func (c *Client) SubmitBlock(b block.Block) (util.Uint256, error) {
 	var (
@@ -545,46 +1005,63 @@ func (c *Client) SignAndPushInvocationTx(script []byte, acc *wallet.Account, sys
 // SignAndPushTx signs given transaction using given wif and cosigners and pushes
 // it to the chain. It returns a hash of the transaction and an error. If one of
 // the cosigners accounts is neither contract-based nor unlocked an error is
-// returned.
+// returned. It's a shortcut for SignAndRelay with AccountSigner and the Client
+// used as a Relayer.
 func (c *Client) SignAndPushTx(tx *transaction.Transaction, acc *wallet.Account, cosigners []SignerAccount) (util.Uint256, error) {
-	var (
-		txHash util.Uint256
-		err    error
-	)
-	if err = acc.SignTx(c.GetNetwork(), tx); err != nil {
-		return txHash, fmt.Errorf("failed to sign tx: %w", err)
-	}
-	// try to add witnesses for the rest of the signers
-	for i, signer := range tx.Signers[1:] {
-		var isOk bool
-		for _, cosigner := range cosigners {
-			if signer.Account == cosigner.Signer.Account {
-				err = cosigner.Account.SignTx(c.GetNetwork(), tx)
-				if err != nil { // then account is non-contract-based and locked, but let's provide more detailed error
-					if paramNum := len(cosigner.Account.Contract.Parameters); paramNum != 0 && cosigner.Account.Contract.Deployed {
-						return txHash, fmt.Errorf("failed to add contract-based witness for signer #%d (%s): "+
-							"%d parameters must be provided to construct invocation script", i, address.Uint160ToString(signer.Account), paramNum)
-					}
-					return txHash, fmt.Errorf("failed to add witness for signer #%d (%s): account should be unlocked to add the signature. "+
-						"Store partially-signed transaction and then use 'wallet sign' command to cosign it", i, address.Uint160ToString(signer.Account))
-				}
-				isOk = true
-				break
-			}
-		}
-		if !isOk {
-			return txHash, fmt.Errorf("failed to add witness for signer #%d (%s): account wasn't provided", i, address.Uint160ToString(signer.Account))
-		}
+	return SignAndRelay(AccountSigner{Account: acc, Cosigners: cosigners}, c, c.GetNetwork(), tx)
+}
+
+// CancelTransaction sends a minimal transaction conflicting with the target
+// one (via Conflicts attribute) which replaces the target in the mempool if
+// accepted. The target should be in the mempool and acc should be its sender
+// (or at least one of its signers). Cancelling transaction network fee is
+// higher than the target's one at least by gas (and at least by 1 if gas is
+// zero). It returns a hash of the cancelling transaction and an error.
+// Conflicts attribute requires P2PSigExtensions to be enabled on the network.
+// Note: client should be initialized before CancelTransaction call.
+func (c *Client) CancelTransaction(acc *wallet.Account, target util.Uint256, gas int64) (util.Uint256, error) {
+	if !c.initDone {
+		return util.Uint256{}, errNetworkNotInitialized
 	}
-	txHash = tx.Hash()
-	actualHash, err := c.SendRawTransaction(tx)
+	if gas < 0 {
+		return util.Uint256{}, errors.New("negative gas")
+	}
+	targetTx, err := c.GetRawTransactionVerbose(target)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to get target transaction: %w", err)
+	}
+	if !targetTx.Blockhash.Equals(util.Uint256{}) {
+		return util.Uint256{}, fmt.Errorf("target transaction is already persisted in block %s", targetTx.Blockhash.StringLE())
+	}
+	from, err := address.StringToUint160(acc.Address)
 	if err != nil {
-		return txHash, fmt.Errorf("failed to send tx: %w", err)
+		return util.Uint256{}, fmt.Errorf("bad account address: %v", err)
+	}
+	if !targetTx.HasSigner(from) {
+		return util.Uint256{}, errors.New("account is not a signer of the target transaction")
+	}
+	tx := transaction.New([]byte{byte(opcode.RET)}, 0)
+	tx.Signers = []transaction.Signer{{Account: from, Scopes: transaction.None}}
+	tx.Attributes = []transaction.Attribute{{
+		Type:  transaction.ConflictsT,
+		Value: &transaction.Conflicts{Hash: target},
+	}}
+	tx.ValidUntilBlock, err = c.CalculateValidUntilBlock()
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to add validUntilBlock to transaction: %w", err)
+	}
+	err = c.AddNetworkFee(tx, gas, acc)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to add network fee: %w", err)
+	}
+	minFee := targetTx.NetworkFee + gas
+	if gas == 0 {
+		minFee++
 	}
-	if !actualHash.Equals(txHash) {
-		return actualHash, fmt.Errorf("sent and actual tx hashes mismatch:\n\tsent: %v\n\tactual: %v", txHash.StringLE(), actualHash.StringLE())
+	if tx.NetworkFee < minFee {
+		tx.NetworkFee = minFee
 	}
-	return txHash, nil
+	return c.SignAndPushTx(tx, acc, nil)
 }
 
 // getSigners returns an array of transaction signers and corresponding accounts from
@@ -605,7 +1082,7 @@ func getSigners(sender *wallet.Account, cosigners []SignerAccount) ([]transactio
 	}
 	for _, c := range cosigners {
 		if c.Signer.Account == from {
-			s.Scopes = c.Signer.Scopes
+			s = c.Signer
 			continue
 		}
 		signers = append(signers, c.Signer)
@@ -628,7 +1105,7 @@ func getSigners(sender *wallet.Account, cosigners []SignerAccount) ([]transactio
 // 3. Main transaction should have dummy contract witness for Notary signer.
 // 4. Main transaction should have NotaryAssisted attribute with NKeys specified.
 // 5. NotaryAssisted attribute and dummy Notary witness (as long as the other incomplete witnesses)
-//    should be paid for. Use CalculateNotaryWitness to calculate the amount of network fee to pay
+//    should be paid for. Use CalculateNotaryFee to calculate the amount of network fee to pay
 //    for the attribute and Notary witness.
 // 6. Main transaction either shouldn't have all witnesses attached (in this case none of them
 //	  can be multisignature), or it only should have a partial multisignature.
@@ -724,6 +1201,17 @@ func (c *Client) SignAndPushP2PNotaryRequest(mainTx *transaction.Transaction, fa
 // CalculateNotaryFee calculates network fee for one dummy Notary witness and NotaryAssisted attribute with NKeys specified.
 // The result should be added to the transaction's net fee for successful verification.
 func (c *Client) CalculateNotaryFee(nKeys uint8) (int64, error) {
+	witnessFee, err := c.CalculateNotaryWitnessFee()
+	if err != nil {
+		return 0, err
+	}
+	return notaryAssistedFee(nKeys) + witnessFee, nil
+}
+
+// CalculateNotaryWitnessFee calculates network fee for one dummy Notary
+// witness only (verification and per-byte costs), NotaryAssisted attribute
+// fee is not included, see CalculateNotaryAssistedFee.
+func (c *Client) CalculateNotaryWitnessFee() (int64, error) {
 	baseExecFee, err := c.GetExecFeeFactor()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get BaseExecFeeFactor: %w", err)
@@ -732,16 +1220,40 @@ func (c *Client) CalculateNotaryFee(nKeys uint8) (int64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("failed to get FeePerByte: %w", err)
 	}
-	return int64((nKeys+1))*transaction.NotaryServiceFeePerKey + // fee for NotaryAssisted attribute
-			fee.Opcode(baseExecFee, // Notary node witness
-				opcode.PUSHDATA1, opcode.RET, // invocation script
-				opcode.PUSH0, opcode.SYSCALL, opcode.RET) + // System.Contract.CallNative
+	return fee.Opcode(baseExecFee, // Notary node witness
+			opcode.PUSHDATA1, opcode.RET, // invocation script
+			opcode.PUSH0, opcode.SYSCALL, opcode.RET) + // System.Contract.CallNative
 			nativeprices.NotaryVerificationPrice*baseExecFee + // Notary witness verification price
 			feePerByte*int64(io.GetVarSize(make([]byte, 66))) + // invocation script per-byte fee
 			feePerByte*int64(io.GetVarSize([]byte{})), // verification script per-byte fee
 		nil
 }
 
+// CalculateNotaryAssistedFee returns network fee required for all
+// NotaryAssisted attributes of the given transaction, (NKeys+1) times
+// transaction.NotaryServiceFeePerKey for each of them. AddNetworkFee and
+// NetworkFeeForWitnesses don't account for it, so it should be added
+// separately (e.g. as an extra fee).
+func (c *Client) CalculateNotaryAssistedFee(tx *transaction.Transaction) int64 {
+	var res int64
+	for _, attr := range tx.GetAttributes(transaction.NotaryAssistedT) {
+		res += notaryAssistedFee(attr.Value.(*transaction.NotaryAssisted).NKeys)
+	}
+	return res
+}
+
+// notaryAssistedFee returns the fee for NotaryAssisted attribute with the
+// given number of keys.
+func notaryAssistedFee(nKeys uint8) int64 {
+	return (int64(nKeys) + 1) * transaction.NotaryServiceFeePerKey
+}
+
+// attributesFee returns network fee required for transaction attributes
+// escalation (if enabled on the network).
+func (c *Client) attributesFee(tx *transaction.Transaction) int64 {
+	return transaction.AttributesFee(c.attributeFeeBase, tx.Attributes)
+}
+
 // SubmitP2PNotaryRequest submits given P2PNotaryRequest payload to the RPC node.
 func (c *Client) SubmitP2PNotaryRequest(req *payload.P2PNotaryRequest) (util.Uint256, error) {
 	var resp = new(result.RelayResult)
@@ -802,34 +1314,22 @@ func (c *Client) CalculateValidUntilBlock() (uint32, error) {
 	return blockCount + validatorsCount + 1, nil
 }
 
-// AddNetworkFee adds network fee for each witness script and optional extra
-// network fee to transaction. `accs` is an array signer's accounts.
+// AddNetworkFee adds network fee for each witness script, attributes
+// escalation fee (if enabled on the network) and optional extra network fee
+// to transaction. `accs` is an array signer's accounts.
 func (c *Client) AddNetworkFee(tx *transaction.Transaction, extraFee int64, accs ...*wallet.Account) error {
 	if len(tx.Signers) != len(accs) {
 		return errors.New("number of signers must match number of scripts")
 	}
 	size := io.GetVarSize(tx)
 	var ef int64
-	for i, cosigner := range tx.Signers {
+	for i := range tx.Signers {
 		if accs[i].Contract.Deployed {
-			res, err := c.InvokeContractVerify(cosigner.Account, smartcontract.Params{}, tx.Signers)
-			if err != nil {
-				return fmt.Errorf("failed to invoke verify: %w", err)
-			}
-			if res.State != "HALT" {
-				return fmt.Errorf("invalid VM state %s due to an error: %s", res.State, res.FaultException)
-			}
-			if l := len(res.Stack); l != 1 {
-				return fmt.Errorf("result stack length should be equal to 1, got %d", l)
-			}
-			r, err := topIntFromStack(res.Stack)
+			gas, err := c.getVerifyFee(tx.Signers, i)
 			if err != nil {
-				return fmt.Errorf("signer #%d: failed to get `verify` result from stack: %w", i, err)
+				return err
 			}
-			if r == 0 {
-				return fmt.Errorf("signer #%d: `verify` returned `false`", i)
-			}
-			tx.NetworkFee += res.GasConsumed
+			tx.NetworkFee += gas
 			size += io.GetVarSize([]byte{}) * 2 // both scripts are empty
 			continue
 		}
@@ -849,10 +1349,109 @@ func (c *Client) AddNetworkFee(tx *transaction.Transaction, extraFee int64, accs
 	if err != nil {
 		return err
 	}
-	tx.NetworkFee += int64(size)*fee + extraFee
+	tx.NetworkFee += int64(size)*fee + c.attributesFee(tx) + extraFee
 	return nil
 }
 
+// NetworkFeeForWitnesses returns network fee needed for the transaction with
+// the given verification scripts (one per signer, in the same order). Scripts
+// can be standard signature or multisignature ones, empty script means that
+// the signer is a deployed contract and its `verify` method is test-invoked to
+// get the price. The result includes verification costs, attributes escalation
+// fee and per-byte fee for the whole transaction with witnesses, so the
+// transaction is expected to not have any witnesses attached yet. Transaction fields are not changed.
+func (c *Client) NetworkFeeForWitnesses(tx *transaction.Transaction, scripts [][]byte) (int64, error) {
+	if len(tx.Signers) != len(scripts) {
+		return 0, errors.New("number of signers must match number of scripts")
+	}
+	var (
+		netFee int64
+		size   = io.GetVarSize(tx)
+		ef     int64
+	)
+	for i := range tx.Signers {
+		if len(scripts[i]) == 0 {
+			gas, err := c.getVerifyFee(tx.Signers, i)
+			if err != nil {
+				return 0, err
+			}
+			netFee += gas
+			size += io.GetVarSize([]byte{}) * 2 // both scripts are empty
+			continue
+		}
+
+		if ef == 0 {
+			var err error
+			ef, err = c.GetExecFeeFactor()
+			if err != nil {
+				return 0, fmt.Errorf("can't get `ExecFeeFactor`: %w", err)
+			}
+		}
+		verFee, sizeDelta := fee.Calculate(ef, scripts[i])
+		if verFee == 0 {
+			return 0, fmt.Errorf("signer #%d: non-standard verification script", i)
+		}
+		netFee += verFee
+		size += sizeDelta
+	}
+	feePerByte, err := c.GetFeePerByte()
+	if err != nil {
+		return 0, err
+	}
+	return netFee + int64(size)*feePerByte + c.attributesFee(tx), nil
+}
+
+// getVerifyFee test-invokes `verify` method of the contract that is i-th
+// signer and returns GAS consumed by it.
+func (c *Client) getVerifyFee(signers []transaction.Signer, i int) (int64, error) {
+	res, err := c.InvokeContractVerify(signers[i].Account, smartcontract.Params{}, signers)
+	if err != nil {
+		return 0, fmt.Errorf("failed to invoke verify: %w", err)
+	}
+	if res.State != "HALT" {
+		return 0, fmt.Errorf("invalid VM state %s due to an error: %s", res.State, res.FaultException)
+	}
+	if l := len(res.Stack); l != 1 {
+		return 0, fmt.Errorf("result stack length should be equal to 1, got %d", l)
+	}
+	r, err := topIntFromStack(res.Stack)
+	if err != nil {
+		return 0, fmt.Errorf("signer #%d: failed to get `verify` result from stack: %w", i, err)
+	}
+	if r == 0 {
+		return 0, fmt.Errorf("signer #%d: `verify` returned `false`", i)
+	}
+	return res.GasConsumed, nil
+}
+
+// RecommendFees returns system and network fees required for the transaction
+// with the given script and signers. System fee is calculated via test
+// invocation of the script and network fee is calculated by the node
+// (see CalculateNetworkFee). Witnesses are to contain verification scripts
+// for standard (signature and multisignature) signers in the same order
+// signers are, contract-based signers need no witnesses (or empty ones).
+func (c *Client) RecommendFees(script []byte, signers []transaction.Signer, witnesses []transaction.Witness) (int64, int64, error) {
+	if len(witnesses) > len(signers) {
+		return 0, 0, errors.New("more witnesses than there are signers")
+	}
+	res, err := c.InvokeScript(script, signers)
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't calculate system fee: %w", err)
+	}
+	if res.State != "HALT" {
+		return 0, 0, fmt.Errorf("can't calculate system fee: bad vm state: %s due to an error: %s", res.State, res.FaultException)
+	}
+	tx := transaction.New(script, res.GasConsumed)
+	tx.Signers = signers
+	tx.Scripts = make([]transaction.Witness, len(signers))
+	copy(tx.Scripts, witnesses)
+	netFee, err := c.CalculateNetworkFee(tx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't calculate network fee: %w", err)
+	}
+	return res.GasConsumed, netFee, nil
+}
+
 // GetNetwork returns the network magic of the RPC node client connected to.
 func (c *Client) GetNetwork() netmode.Magic {
 	return c.network
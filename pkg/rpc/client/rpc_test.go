@@ -87,11 +87,7 @@ func getResultBlock1() *result.Block {
 
 func getTxMoveNeo() *result.TransactionOutputRaw {
 	b1 := getResultBlock1()
-	txBin, err := base64.StdEncoding.DecodeString(base64TxMoveNeo)
-	if err != nil {
-		panic(err)
-	}
-	tx, err := transaction.NewTransactionFromBytes(txBin)
+	tx, err := transaction.NewFromBase64(base64TxMoveNeo)
 	if err != nil {
 		panic(err)
 	}
@@ -136,6 +132,49 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "invoked contracts",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetInvokedContracts(util.Uint256{}, nil)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"blockhash":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","executions":[{"trigger":"OnPersist","vmstate":"HALT","gasconsumed":"1","stack":[],"notifications":[],"invocations":["0xd2a4cff31913016155e38e474a2c06d08be276cf","0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5"]},{"trigger":"PostPersist","vmstate":"HALT","gasconsumed":"1","stack":[],"notifications":[],"invocations":["0xd2a4cff31913016155e38e474a2c06d08be276cf"]}]}}`,
+			result: func(c *Client) interface{} {
+				return []util.Uint160{
+					{0xcf, 0x76, 0xe2, 0x8b, 0xd0, 0x06, 0x2c, 0x4a, 0x47, 0x8e, 0xe3, 0x55, 0x61, 0x01, 0x13, 0x19, 0xf3, 0xcf, 0xa4, 0xd2},
+					{0xf5, 0x63, 0xea, 0x40, 0xbc, 0x28, 0x3d, 0x4d, 0x0e, 0x05, 0xc4, 0x8e, 0xa3, 0x05, 0xb3, 0xf2, 0xa0, 0x73, 0x40, 0xef},
+				}
+			},
+		},
+	},
+	"getapplicationlogs": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetApplicationLogs([]util.Uint256{{1}, {2}}, nil)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"txid":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","executions":[{"trigger":"Application","vmstate":"HALT","gasconsumed":"1","stack":[{"type":"Integer","value":"1"}],"notifications":[]}]},null]}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				return []*result.ApplicationLog{
+					{
+						Container: txHash,
+						Executions: []state.Execution{
+							{
+								Trigger:     trigger.Application,
+								VMState:     vm.HaltState,
+								GasConsumed: 1,
+								Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(1))},
+								Events:      []state.NotificationEvent{},
+							},
+						},
+					},
+					nil,
+				}
+			},
+		},
 	},
 	"getbestblockhash": {
 		{
@@ -318,6 +357,20 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return keys.PublicKeys{member}
 			},
 		},
+		{
+			name: "address",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCommitteeAddress()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":["02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e","02a7bc55fe8684e0119768d104ba30795bdcc86619e864add26156723ed185cd62","02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","03d90c07df63e690ce77912e10ab51acc944b66860237b608c4f8f8309e71ee699","034a8b93a35eab834030d1a8eb603e82f072b6cae4ec4f2671d63d4e6113070ead"]}`,
+			result: func(c *Client) interface{} {
+				h, err := address.StringToUint160("NWKBZs5KnbkyrQ7bVKJ7hHR4ehtfqkQZqT")
+				if err != nil {
+					panic(err)
+				}
+				return h
+			},
+		},
 	},
 	"getconnectioncount": {
 		{
@@ -331,6 +384,50 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getconsensusstate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetConsensusState()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":42,"view":2,"primary":3,"nextblocktime":1616059782001}}`,
+			result: func(c *Client) interface{} {
+				return &result.ConsensusState{
+					Height:        42,
+					View:          2,
+					Primary:       3,
+					NextBlockTime: 1616059782001,
+				}
+			},
+		},
+	},
+	"getfeehistogram": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetFeeHistogram(2)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":5,"maxtransactionsperblock":512,"mempool":[{"feeperbyte":1000,"count":1},{"feeperbyte":100,"count":2}],"blocks":[{"index":5,"txcount":0,"minfeeperbyte":0},{"index":4,"txcount":2,"minfeeperbyte":1000}]}}`,
+			result: func(c *Client) interface{} {
+				return &result.FeeHistogram{
+					Height:                  5,
+					MaxTransactionsPerBlock: 512,
+					Mempool:                 []result.FeeBucket{{FeePerByte: 1000, Count: 1}, {FeePerByte: 100, Count: 2}},
+					Blocks:                  []result.BlockFees{{Index: 5}, {Index: 4, TxCount: 2, MinFeePerByte: 1000}},
+				}
+			},
+		},
+		{
+			name: "inclusion probability",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.EstimateInclusionProbability(100, 1)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":5,"maxtransactionsperblock":512,"mempool":[{"feeperbyte":1000,"count":1},{"feeperbyte":100,"count":2}],"blocks":[{"index":5,"txcount":0,"minfeeperbyte":0},{"index":4,"txcount":2,"minfeeperbyte":1000}]}}`,
+			result: func(c *Client) interface{} {
+				return 1.0
+			},
+		},
+	},
 	"getcontractstate": {
 		{
 			name: "positive, by hash",
@@ -648,6 +745,24 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return &tx.Transaction
 			},
 		},
+		{
+			name: "bytes_positive",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint256DecodeStringLE("f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetRawTransactionBytes(hash)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":"` + base64TxMoveNeo + `"}`,
+			result: func(c *Client) interface{} {
+				b, err := base64.StdEncoding.DecodeString(base64TxMoveNeo)
+				if err != nil {
+					panic(err)
+				}
+				return b
+			},
+		},
 		{
 			name: "verbose_positive",
 			invoke: func(c *Client) (interface{}, error) {
@@ -667,6 +782,25 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return getTxMoveNeo()
 			},
 		},
+		{
+			name: "json_positive",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint256DecodeStringLE("f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275")
+				if err != nil {
+					panic(err)
+				}
+				out, err := c.GetRawTransactionJSON(hash)
+				if err != nil {
+					return nil, err
+				}
+				out.FeePerByte() // set fee per byte
+				return out, nil
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":` + txMoveNeoVerbose + `}`,
+			result: func(c *Client) interface{} {
+				return &getTxMoveNeo().Transaction
+			},
+		},
 	},
 	"getstorage": {
 		{
@@ -759,6 +893,68 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				assert.Equal(t, 4, len(res))
 			},
 		},
+		{
+			name: "verbose",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNextBlockValidatorsVerbose()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"publickey":"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","votes":"100","active":true,"voters":[{"account":"0xffc7a658923a0bb92e6abab09800f389c179eede","votes":"70"},{"account":"0x5ffc7a658923a0bb92e6abab09800f389c179eed","votes":"30"}]}]}`,
+			result: func(c *Client) interface{} {
+				pub, err := keys.NewPublicKeyFromString("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2")
+				if err != nil {
+					panic(err)
+				}
+				acc1, err := util.Uint160DecodeStringLE("ffc7a658923a0bb92e6abab09800f389c179eede")
+				if err != nil {
+					panic(err)
+				}
+				acc2, err := util.Uint160DecodeStringLE("5ffc7a658923a0bb92e6abab09800f389c179eed")
+				if err != nil {
+					panic(err)
+				}
+				return []result.Validator{{
+					PublicKey: *pub,
+					Votes:     100,
+					Active:    true,
+					Voters: []result.Voter{
+						{Account: acc1, Votes: 70},
+						{Account: acc2, Votes: 30},
+					},
+				}}
+			},
+		},
+	},
+	"getNextValidatorsAddress": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNextValidatorsAddress()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"wh8MFmdldE5leHRCbG9ja1ZhbGlkYXRvcnMMFPV+ftz6qvbR0SHvlUlb4iUxFf5NQWJ9W1I=","stack":[{"type":"Array","value":[{"type":"ByteString","value":"ArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43C"},{"type":"ByteString","value":"AhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBu"},{"type":"ByteString","value":"A9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZ"},{"type":"ByteString","value":"Aqe8Vf6GhOARl2jRBLoweVvcyGYZ6GSt0mFWcj7Rhc1i"}]}]}}`,
+			result: func(c *Client) interface{} {
+				h, err := address.StringToUint160("NVTiAjNgagDkTr5HTzDmQP9kPwPHN5BgVq")
+				if err != nil {
+					panic(err)
+				}
+				return h
+			},
+		},
+		{
+			name: "no validators",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNextValidatorsAddress()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"wh8MFmdldE5leHRCbG9ja1ZhbGlkYXRvcnMMFPV+ftz6qvbR0SHvlUlb4iUxFf5NQWJ9W1I=","stack":[{"type":"Array","value":[]}]}}`,
+			fails:          true,
+		},
+		{
+			name: "fault",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNextValidatorsAddress()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"FAULT","gasconsumed":"2007390","script":"wh8MFmdldE5leHRCbG9ja1ZhbGlkYXRvcnMMFPV+ftz6qvbR0SHvlUlb4iUxFf5NQWJ9W1I=","stack":[],"exception":"error"}}`,
+			fails:          true,
+		},
 	},
 	"getversion": {
 		{
@@ -895,6 +1091,23 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"verifytransaction": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.VerifyTransaction(transaction.New([]byte{byte(opcode.PUSH1)}, 0))
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"EQ==","state":"HALT","gasconsumed":"30","stack":[{"type":"Integer","value":"1"}]}}`,
+			result: func(c *Client) interface{} {
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 30,
+					Script:      []byte{byte(opcode.PUSH1)},
+					Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(1))},
+				}
+			},
+		},
+	},
 	"invokecontractverify": {
 		{
 			name: "positive",
@@ -1653,6 +1866,98 @@ func TestCalculateValidUntilBlock(t *testing.T) {
 	assert.Equal(t, 1, getValidatorsCalled)
 }
 
+func TestGetClaimableGAS(t *testing.T) {
+	var end int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		if err != nil {
+			t.Fatalf("Cannot decode request body: %s", req.Body)
+		}
+		var response string
+		switch r.In.Method {
+		case "getblockcount":
+			response = `{"jsonrpc":"2.0","id":1,"result":50}`
+		case "invokefunction":
+			ps, err := r.In.Params()
+			require.NoError(t, err)
+			method, err := ps.Value(1).GetString()
+			require.NoError(t, err)
+			require.Equal(t, "unclaimedGas", method)
+			args, err := ps.Value(2).GetArray()
+			require.NoError(t, err)
+			require.Equal(t, 2, len(args))
+			fp, err := args[1].GetFuncParam()
+			require.NoError(t, err)
+			e, err := fp.Value.GetInt()
+			require.NoError(t, err)
+			end = int64(e)
+			response = `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"Integer","value":"897299680935"}],"tx":null}}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	claimable, err := c.GetClaimableGAS(util.Uint160{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(897299680935), claimable)
+	require.Equal(t, int64(50), end)
+}
+
+func TestNEP17DecimalsCache(t *testing.T) {
+	var invokeCalled int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		if err != nil {
+			t.Fatalf("Cannot decode request body: %s", req.Body)
+		}
+		var response string
+		switch r.In.Method {
+		case "invokefunction":
+			invokeCalled++
+			response = `{"jsonrpc":"2.0","id":1,"result":{"script":"wh8MCGRlY2ltYWxzDBQlBZ7LSHjTqHX5HFHO3tMw1Fdf3kFifVtS","state":"HALT","gasconsumed":"1007390","stack":[{"type":"Integer","value":"8"}]}}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+
+	h := util.Uint160{1, 2, 3}
+	decimals, err := c.NEP17Decimals(h)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), decimals)
+	require.Equal(t, 1, invokeCalled)
+
+	// check, whether caching is working
+	decimals, err = c.NEP17Decimals(h)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), decimals)
+	require.Equal(t, 1, invokeCalled)
+
+	// another token is not cached
+	_, err = c.NEP17Decimals(util.Uint160{3, 2, 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, invokeCalled)
+
+	c.InvalidateTokenCache(h)
+	decimals, err = c.NEP17Decimals(h)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), decimals)
+	require.Equal(t, 3, invokeCalled)
+
+	c.InvalidateTokenCache()
+	_, err = c.NEP17Decimals(h)
+	require.NoError(t, err)
+	require.Equal(t, 4, invokeCalled)
+}
+
 func TestGetNetwork(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		r := request.NewRequest()
@@ -1726,3 +2031,102 @@ func newTestNEF(script []byte) nef.File {
 	ne.Checksum = ne.CalculateChecksum()
 	return ne
 }
+
+func TestAttributesFeeEscalation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		if err != nil {
+			t.Fatalf("Cannot decode request body: %s", req.Body)
+		}
+		if r.In.Method == "getversion" {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, err = w.Write([]byte(`{"id":1,"jsonrpc":"2.0","result":{"network":42,"tcpport":20332,"nonce":2153672787,"useragent":"/NEO-GO:0.73.1-pre-273-ge381358/","attributefeebase":1000}}`))
+			require.NoError(t, err)
+			return
+		}
+		requestHandler(t, r.In, w, "")
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	var prev, prevDelta int64
+	for i := 0; i < 5; i++ {
+		tx.Attributes = append(tx.Attributes, transaction.Attribute{
+			Type:  transaction.ReservedLowerBound + 3 + transaction.AttrType(i),
+			Value: &transaction.Reserved{},
+		})
+		cur := c.attributesFee(tx)
+		require.True(t, cur-prev > prevDelta)
+		prev, prevDelta = cur, cur-prev
+	}
+	require.Equal(t, int64(15000), prev)
+
+	tx.Attributes = append(tx.Attributes, transaction.Attribute{
+		Type:  transaction.NotaryAssistedT,
+		Value: &transaction.NotaryAssisted{NKeys: 1},
+	})
+	require.Equal(t, prev, c.attributesFee(tx))
+	require.Equal(t, int64(2*transaction.NotaryServiceFeePerKey), c.CalculateNotaryAssistedFee(tx))
+}
+
+func TestSendRawTransactionIdempotent(t *testing.T) {
+	var response string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		if err != nil {
+			t.Fatalf("Cannot decode request body: %s", req.Body)
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	tx.Scripts = []transaction.Witness{{}}
+
+	t.Run("success", func(t *testing.T) {
+		response = `{"jsonrpc":"2.0","id":1,"result":{"hash":"0x` + tx.Hash().StringLE() + `"}}`
+		h, err := c.SendRawTransactionIdempotent(tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), h)
+	})
+	t.Run("already exists", func(t *testing.T) {
+		response = `{"jsonrpc":"2.0","id":1,"error":{"code":-501,"message":"Block or transaction already exists and cannot be sent repeatedly."}}`
+		_, err := c.SendRawTransaction(tx)
+		require.Error(t, err)
+
+		h, err := c.SendRawTransactionIdempotent(tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), h)
+	})
+	t.Run("validation failed", func(t *testing.T) {
+		response = `{"jsonrpc":"2.0","id":1,"error":{"code":-504,"message":"Block or transaction validation failed."}}`
+		_, err := c.SendRawTransactionIdempotent(tx)
+		require.Error(t, err)
+	})
+	t.Run("transport error", func(t *testing.T) {
+		response = `not a json`
+		_, err := c.SendRawTransactionIdempotent(tx)
+		require.Error(t, err)
+	})
+}
+
+func TestTxMoveNeoBase64(t *testing.T) {
+	tx := getTxMoveNeo().Transaction
+	require.Equal(t, base64TxMoveNeo, tx.Base64())
+
+	actual, err := transaction.NewFromBase64(tx.Base64())
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), actual.Hash())
+	require.Equal(t, tx.Bytes(), actual.Bytes())
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// Signer is an entity able to add witnesses to the transaction for the given
+// network. wallet.Account is the simplest Signer that adds a single witness.
+type Signer interface {
+	SignTx(net netmode.Magic, tx *transaction.Transaction) error
+}
+
+// Relayer is an entity able to send signed transactions to the network and
+// return their hashes. Client is a Relayer that sends transactions via its
+// RPC node.
+type Relayer interface {
+	SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error)
+}
+
+// AccountSigner is a Signer that adds witnesses for the sender account and all
+// other transaction signers using the accounts provided in Cosigners. Every
+// transaction signer (except the sender) must have its account in Cosigners.
+type AccountSigner struct {
+	Account   *wallet.Account
+	Cosigners []SignerAccount
+}
+
+var (
+	_ Signer  = (*wallet.Account)(nil)
+	_ Signer  = AccountSigner{}
+	_ Relayer = (*Client)(nil)
+)
+
+// SignTx implements Signer interface. If one of the cosigners accounts is
+// neither contract-based nor unlocked an error is returned.
+func (s AccountSigner) SignTx(net netmode.Magic, tx *transaction.Transaction) error {
+	if err := s.Account.SignTx(net, tx); err != nil {
+		return fmt.Errorf("failed to sign tx: %w", err)
+	}
+	// try to add witnesses for the rest of the signers
+	for i, signer := range tx.Signers[1:] {
+		var isOk bool
+		for _, cosigner := range s.Cosigners {
+			if signer.Account == cosigner.Signer.Account {
+				err := cosigner.Account.SignTx(net, tx)
+				if err != nil { // then account is non-contract-based and locked, but let's provide more detailed error
+					if paramNum := len(cosigner.Account.Contract.Parameters); paramNum != 0 && cosigner.Account.Contract.Deployed {
+						return fmt.Errorf("failed to add contract-based witness for signer #%d (%s): "+
+							"%d parameters must be provided to construct invocation script", i, address.Uint160ToString(signer.Account), paramNum)
+					}
+					return fmt.Errorf("failed to add witness for signer #%d (%s): account should be unlocked to add the signature. "+
+						"Store partially-signed transaction and then use 'wallet sign' command to cosign it", i, address.Uint160ToString(signer.Account))
+				}
+				isOk = true
+				break
+			}
+		}
+		if !isOk {
+			return fmt.Errorf("failed to add witness for signer #%d (%s): account wasn't provided", i, address.Uint160ToString(signer.Account))
+		}
+	}
+	return nil
+}
+
+// SignAndRelay signs given transaction for the specified network using s and
+// sends it via r. It returns a hash of the transaction and an error. Signing
+// and relaying can also be done separately, e.g. to send transaction later or
+// via some other node.
+func SignAndRelay(s Signer, r Relayer, net netmode.Magic, tx *transaction.Transaction) (util.Uint256, error) {
+	if err := s.SignTx(net, tx); err != nil {
+		return util.Uint256{}, err
+	}
+	txHash := tx.Hash()
+	actualHash, err := r.SendRawTransaction(tx)
+	if err != nil {
+		return txHash, fmt.Errorf("failed to send tx: %w", err)
+	}
+	if !actualHash.Equals(txHash) {
+		return actualHash, fmt.Errorf("sent and actual tx hashes mismatch:\n\tsent: %v\n\tactual: %v", txHash.StringLE(), actualHash.StringLE())
+	}
+	return txHash, nil
+}
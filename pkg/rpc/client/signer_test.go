@@ -0,0 +1,97 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRelayer struct {
+	sent []*transaction.Transaction
+	hash *util.Uint256
+	err  error
+}
+
+func (r *mockRelayer) SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error) {
+	if r.err != nil {
+		return util.Uint256{}, r.err
+	}
+	r.sent = append(r.sent, tx)
+	if r.hash != nil {
+		return *r.hash, nil
+	}
+	return tx.Hash(), nil
+}
+
+func TestSignAndRelay(t *testing.T) {
+	const net = netmode.UnitTestNet
+
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	cosigner, err := wallet.NewAccount()
+	require.NoError(t, err)
+
+	newTx := func() *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = 1
+		tx.Signers = []transaction.Signer{
+			{Account: acc.Contract.ScriptHash()},
+			{Account: cosigner.Contract.ScriptHash()},
+		}
+		return tx
+	}
+	signer := AccountSigner{
+		Account: acc,
+		Cosigners: []SignerAccount{{
+			Signer:  transaction.Signer{Account: cosigner.Contract.ScriptHash()},
+			Account: cosigner,
+		}},
+	}
+
+	t.Run("sign now, relay later", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, signer.SignTx(net, tx))
+		require.Equal(t, 2, len(tx.Scripts))
+		require.Equal(t, acc.GetVerificationScript(), tx.Scripts[0].VerificationScript)
+		require.Equal(t, cosigner.GetVerificationScript(), tx.Scripts[1].VerificationScript)
+		require.True(t, acc.PrivateKey().PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(net), tx))
+
+		r := new(mockRelayer)
+		h, err := r.SendRawTransaction(tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), h)
+		require.Equal(t, []*transaction.Transaction{tx}, r.sent)
+	})
+	t.Run("SignAndRelay", func(t *testing.T) {
+		tx := newTx()
+		r := new(mockRelayer)
+		h, err := SignAndRelay(signer, r, net, tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), h)
+		require.Equal(t, 1, len(r.sent))
+		require.Equal(t, 2, len(r.sent[0].Scripts))
+	})
+	t.Run("missing cosigner", func(t *testing.T) {
+		r := new(mockRelayer)
+		_, err := SignAndRelay(AccountSigner{Account: acc}, r, net, newTx())
+		require.Error(t, err)
+		require.Equal(t, 0, len(r.sent))
+	})
+	t.Run("relay error", func(t *testing.T) {
+		r := &mockRelayer{err: errors.New("bad")}
+		_, err := SignAndRelay(signer, r, net, newTx())
+		require.Error(t, err)
+	})
+	t.Run("hash mismatch", func(t *testing.T) {
+		r := &mockRelayer{hash: &util.Uint256{1, 2, 3}}
+		h, err := SignAndRelay(signer, r, net, newTx())
+		require.Error(t, err)
+		require.Equal(t, util.Uint256{1, 2, 3}, h)
+	})
+}
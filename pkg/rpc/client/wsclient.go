@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -12,7 +14,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 )
 
 // WSClient is a websocket-enabled RPC client that can be used with appropriate
@@ -29,12 +33,30 @@ type WSClient struct {
 	// be closed, so make sure to handle this.
 	Notifications chan Notification
 
-	ws            *websocket.Conn
-	done          chan struct{}
-	responses     chan *response.Raw
-	requests      chan *request.Raw
-	shutdown      chan struct{}
-	subscriptions map[string]bool
+	ws        *websocket.Conn
+	done      chan struct{}
+	responses chan *response.Raw
+	requests  chan *request.Raw
+	shutdown  chan struct{}
+
+	// subsLock protects subscriptions and lastBlock that are updated by
+	// wsReader on every block event.
+	subsLock      sync.RWMutex
+	subscriptions map[string]*SubscriptionCheckpoint
+	lastBlock     *uint32
+}
+
+// SubscriptionCheckpoint is a subscription state that can be used to restore
+// it (see Restore) after connection loss without missing events. Height is
+// the index of the last block received via block_added event while the
+// subscription was active, all events for this block and blocks before it
+// are considered to be processed. Since the server sends block_added event
+// after all other events for the same block, it's only updated if there is
+// an active subscription for new blocks.
+type SubscriptionCheckpoint struct {
+	Event  response.EventID
+	Filter interface{}
+	Height uint32
 }
 
 // Notification represents server-generated notification for client subscriptions.
@@ -93,11 +115,12 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 		done:          make(chan struct{}),
 		responses:     make(chan *response.Raw),
 		requests:      make(chan *request.Raw),
-		subscriptions: make(map[string]bool),
+		subscriptions: make(map[string]*SubscriptionCheckpoint),
 	}
 	go wsc.wsReader()
 	go wsc.wsWriter()
 	wsc.requestF = wsc.makeWsRequest
+	wsc.batchRequestF = wsc.Client.makeSequentialBatchRequest
 	return wsc, nil
 }
 
@@ -162,6 +185,9 @@ readloop:
 					break
 				}
 			}
+			if event == response.BlockEventID {
+				c.updateCheckpoints(val.(*block.Block).Index)
+			}
 			c.Notifications <- Notification{event, val}
 		} else if rr.RawID != nil && (rr.Error != nil || rr.Result != nil) {
 			resp := new(response.Raw)
@@ -225,20 +251,46 @@ func (c *WSClient) makeWsRequest(r *request.Raw) (*response.Raw, error) {
 	}
 }
 
-func (c *WSClient) performSubscription(params request.RawParams) (string, error) {
-	var resp string
+// updateCheckpoints sets height of all active subscriptions to the given
+// block index.
+func (c *WSClient) updateCheckpoints(index uint32) {
+	c.subsLock.Lock()
+	defer c.subsLock.Unlock()
+	for _, cp := range c.subscriptions {
+		cp.Height = index
+	}
+	c.lastBlock = &index
+}
+
+func (c *WSClient) performSubscription(event response.EventID, filter interface{}) (string, error) {
+	var (
+		resp   string
+		params = request.NewRawParams(event.String())
+	)
 
+	if filter != nil {
+		params.Values = append(params.Values, filter)
+	}
 	if err := c.performRequest("subscribe", params, &resp); err != nil {
 		return "", err
 	}
-	c.subscriptions[resp] = true
+	cp := &SubscriptionCheckpoint{Event: event, Filter: filter}
+	c.subsLock.Lock()
+	if c.lastBlock != nil {
+		cp.Height = *c.lastBlock
+	}
+	c.subscriptions[resp] = cp
+	c.subsLock.Unlock()
 	return resp, nil
 }
 
 func (c *WSClient) performUnsubscription(id string) error {
 	var resp bool
 
-	if !c.subscriptions[id] {
+	c.subsLock.RLock()
+	_, ok := c.subscriptions[id]
+	c.subsLock.RUnlock()
+	if !ok {
 		return errors.New("no subscription with this ID")
 	}
 	if err := c.performRequest("unsubscribe", request.NewRawParams(id), &resp); err != nil {
@@ -247,7 +299,9 @@ func (c *WSClient) performUnsubscription(id string) error {
 	if !resp {
 		return errors.New("unsubscribe method returned false result")
 	}
+	c.subsLock.Lock()
 	delete(c.subscriptions, id)
+	c.subsLock.Unlock()
 	return nil
 }
 
@@ -255,22 +309,22 @@ func (c *WSClient) performUnsubscription(id string) error {
 // of client. It can filtered by primary consensus node index, nil value doesn't
 // add any filters.
 func (c *WSClient) SubscribeForNewBlocks(primary *int) (string, error) {
-	params := request.NewRawParams("block_added")
+	var filter interface{}
 	if primary != nil {
-		params.Values = append(params.Values, request.BlockFilter{Primary: *primary})
+		filter = request.BlockFilter{Primary: *primary}
 	}
-	return c.performSubscription(params)
+	return c.performSubscription(response.BlockEventID, filter)
 }
 
 // SubscribeForNewTransactions adds subscription for new transaction events to
 // this instance of client. It can be filtered by sender and/or signer, nil
 // value is treated as missing filter.
 func (c *WSClient) SubscribeForNewTransactions(sender *util.Uint160, signer *util.Uint160) (string, error) {
-	params := request.NewRawParams("transaction_added")
+	var filter interface{}
 	if sender != nil || signer != nil {
-		params.Values = append(params.Values, request.TxFilter{Sender: sender, Signer: signer})
+		filter = request.TxFilter{Sender: sender, Signer: signer}
 	}
-	return c.performSubscription(params)
+	return c.performSubscription(response.TransactionEventID, filter)
 }
 
 // SubscribeForExecutionNotifications adds subscription for notifications
@@ -278,11 +332,11 @@ func (c *WSClient) SubscribeForNewTransactions(sender *util.Uint160, signer *uti
 // filtered by contract's hash (that emits notifications), nil value puts no such
 // restrictions.
 func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, name *string) (string, error) {
-	params := request.NewRawParams("notification_from_execution")
+	var filter interface{}
 	if contract != nil || name != nil {
-		params.Values = append(params.Values, request.NotificationFilter{Contract: contract, Name: name})
+		filter = request.NotificationFilter{Contract: contract, Name: name}
 	}
-	return c.performSubscription(params)
+	return c.performSubscription(response.NotificationEventID, filter)
 }
 
 // SubscribeForTransactionExecutions adds subscription for application execution
@@ -290,14 +344,14 @@ func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, na
 // be filtered by state (HALT/FAULT) to check for successful or failing
 // transactions, nil value means no filtering.
 func (c *WSClient) SubscribeForTransactionExecutions(state *string) (string, error) {
-	params := request.NewRawParams("transaction_executed")
+	var filter interface{}
 	if state != nil {
 		if *state != "HALT" && *state != "FAULT" {
 			return "", errors.New("bad state parameter")
 		}
-		params.Values = append(params.Values, request.ExecutionFilter{State: *state})
+		filter = request.ExecutionFilter{State: *state}
 	}
-	return c.performSubscription(params)
+	return c.performSubscription(response.ExecutionEventID, filter)
 }
 
 // Unsubscribe removes subscription for given event stream.
@@ -307,7 +361,13 @@ func (c *WSClient) Unsubscribe(id string) error {
 
 // UnsubscribeAll removes all active subscriptions of current client.
 func (c *WSClient) UnsubscribeAll() error {
+	c.subsLock.RLock()
+	ids := make([]string, 0, len(c.subscriptions))
 	for id := range c.subscriptions {
+		ids = append(ids, id)
+	}
+	c.subsLock.RUnlock()
+	for _, id := range ids {
 		err := c.performUnsubscription(id)
 		if err != nil {
 			return err
@@ -315,3 +375,189 @@ func (c *WSClient) UnsubscribeAll() error {
 	}
 	return nil
 }
+
+// Checkpoints returns current state of all active subscriptions. It can be
+// used even after connection loss to restore subscriptions with some other
+// client via Restore.
+func (c *WSClient) Checkpoints() []SubscriptionCheckpoint {
+	c.subsLock.RLock()
+	defer c.subsLock.RUnlock()
+	res := make([]SubscriptionCheckpoint, 0, len(c.subscriptions))
+	for _, cp := range c.subscriptions {
+		res = append(res, *cp)
+	}
+	return res
+}
+
+// Restore subscribes for the same events with the same filters as specified
+// in checkpoints and then returns all matching events missed since
+// checkpoints' heights up to the current chain height. Missed events are
+// fetched with regular RPC calls (so the client must be initialized with
+// Init) and are returned in the same order as the server sends them.
+// maxBlocks limits the number of blocks to be processed, if more blocks were
+// missed an error is returned (subscriptions are restored in this case
+// anyway). Some events can be received both via Notifications channel and
+// returned from Restore, so Notifications channel should already be read
+// from when Restore is called.
+func (c *WSClient) Restore(checkpoints []SubscriptionCheckpoint, maxBlocks uint32) ([]Notification, error) {
+	if len(checkpoints) == 0 {
+		return nil, nil
+	}
+	var from = checkpoints[0].Height
+	for _, cp := range checkpoints {
+		id, err := c.performSubscription(cp.Event, cp.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore %s subscription: %w", cp.Event, err)
+		}
+		c.subsLock.Lock()
+		if sub, ok := c.subscriptions[id]; ok && sub.Height < cp.Height {
+			sub.Height = cp.Height
+		}
+		c.subsLock.Unlock()
+		if cp.Height < from {
+			from = cp.Height
+		}
+	}
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block count: %w", err)
+	}
+	if count == 0 || count-1 <= from {
+		return nil, nil
+	}
+	if missed := count - 1 - from; missed > maxBlocks {
+		return nil, fmt.Errorf("too many blocks to replay: %d > %d", missed, maxBlocks)
+	}
+	var res []Notification
+	for i := from + 1; i < count; i++ {
+		evs, err := c.replayBlock(i, checkpoints)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, evs...)
+	}
+	return res, nil
+}
+
+// replayBlock returns all events for the block with the specified index
+// matching given checkpoints.
+func (c *WSClient) replayBlock(index uint32, checkpoints []SubscriptionCheckpoint) ([]Notification, error) {
+	var (
+		res      []Notification
+		needLogs bool
+		cps      = make([]SubscriptionCheckpoint, 0, len(checkpoints))
+	)
+	for _, cp := range checkpoints {
+		if cp.Height < index {
+			cps = append(cps, cp)
+			needLogs = needLogs || cp.Event == response.NotificationEventID || cp.Event == response.ExecutionEventID
+		}
+	}
+	add := func(event response.EventID, val interface{}) {
+		n := Notification{Type: event, Value: val}
+		for _, cp := range cps {
+			if cp.matches(n) {
+				res = append(res, n)
+				return
+			}
+		}
+	}
+	addExecution := func(aer *state.AppExecResult, withEvents bool) {
+		add(response.ExecutionEventID, aer)
+		if withEvents {
+			for i := range aer.Events {
+				add(response.NotificationEventID, &aer.Events[i])
+			}
+		}
+	}
+	b, err := c.GetBlockByIndex(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", index, err)
+	}
+	var blockAERs = make(map[trigger.Type]*state.AppExecResult)
+	if needLogs {
+		log, err := c.GetApplicationLog(b.Hash(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application log for block %d: %w", index, err)
+		}
+		for _, e := range log.Executions {
+			blockAERs[e.Trigger] = &state.AppExecResult{Container: log.Container, Execution: e}
+		}
+		if aer, ok := blockAERs[trigger.OnPersist]; ok {
+			addExecution(aer, true)
+		}
+	}
+	for _, tx := range b.Transactions {
+		if needLogs {
+			log, err := c.GetApplicationLog(tx.Hash(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get application log for transaction %s: %w", tx.Hash().StringLE(), err)
+			}
+			for _, e := range log.Executions {
+				aer := &state.AppExecResult{Container: log.Container, Execution: e}
+				addExecution(aer, aer.VMState == vm.HaltState)
+			}
+		}
+		add(response.TransactionEventID, tx)
+	}
+	if aer, ok := blockAERs[trigger.PostPersist]; ok {
+		addExecution(aer, true)
+	}
+	add(response.BlockEventID, b)
+	return res, nil
+}
+
+// matches checks whether event matches checkpoint's event type and filter
+// the same way the server does.
+func (cp SubscriptionCheckpoint) matches(n Notification) bool {
+	if n.Type != cp.Event {
+		return false
+	}
+	if cp.Filter == nil {
+		return true
+	}
+	switch n.Type {
+	case response.BlockEventID:
+		filt, ok := cp.Filter.(request.BlockFilter)
+		b, okV := n.Value.(*block.Block)
+		if !ok || !okV {
+			return false
+		}
+		return int(b.PrimaryIndex) == filt.Primary
+	case response.TransactionEventID:
+		filt, ok := cp.Filter.(request.TxFilter)
+		tx, okV := n.Value.(*transaction.Transaction)
+		if !ok || !okV {
+			return false
+		}
+		senderOK := filt.Sender == nil || tx.Sender().Equals(*filt.Sender)
+		signerOK := true
+		if filt.Signer != nil {
+			signerOK = false
+			for i := range tx.Signers {
+				if tx.Signers[i].Account.Equals(*filt.Signer) {
+					signerOK = true
+					break
+				}
+			}
+		}
+		return senderOK && signerOK
+	case response.NotificationEventID:
+		filt, ok := cp.Filter.(request.NotificationFilter)
+		notification, okV := n.Value.(*state.NotificationEvent)
+		if !ok || !okV {
+			return false
+		}
+		hashOk := filt.Contract == nil || notification.ScriptHash.Equals(*filt.Contract)
+		nameOk := filt.Name == nil || notification.Name == *filt.Name
+		return hashOk && nameOk
+	case response.ExecutionEventID:
+		filt, ok := cp.Filter.(request.ExecutionFilter)
+		aer, okV := n.Value.(*state.AppExecResult)
+		if !ok || !okV {
+			return false
+		}
+		return aer.VMState.String() == filt.State
+	}
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
@@ -73,13 +74,13 @@ func TestWSClientUnsubscription(t *testing.T) {
 	var cases = map[string]responseCheck{
 		"good": {`{"jsonrpc": "2.0", "id": 1, "result": true}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = new(SubscriptionCheckpoint)
 			err := wsc.Unsubscribe("0")
 			require.NoError(t, err)
 		}},
 		"all": {`{"jsonrpc": "2.0", "id": 1, "result": true}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = new(SubscriptionCheckpoint)
 			err := wsc.UnsubscribeAll()
 			require.NoError(t, err)
 			require.Equal(t, 0, len(wsc.subscriptions))
@@ -90,13 +91,13 @@ func TestWSClientUnsubscription(t *testing.T) {
 		}},
 		"error returned": {`{"jsonrpc": "2.0", "id": 1, "error":{"code":-32602,"message":"Invalid Params"}}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = new(SubscriptionCheckpoint)
 			err := wsc.Unsubscribe("0")
 			require.Error(t, err)
 		}},
 		"false returned": {`{"jsonrpc": "2.0", "id": 1, "result": false}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = new(SubscriptionCheckpoint)
 			err := wsc.Unsubscribe("0")
 			require.Error(t, err)
 		}},
@@ -338,6 +339,21 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 	}
 }
 
+func TestSubscriptionCheckpointMatchesUnexpectedValue(t *testing.T) {
+	checkpoints := []SubscriptionCheckpoint{
+		{Event: response.BlockEventID, Filter: request.BlockFilter{}},
+		{Event: response.TransactionEventID, Filter: request.TxFilter{}},
+		{Event: response.NotificationEventID, Filter: request.NotificationFilter{}},
+		{Event: response.ExecutionEventID, Filter: request.ExecutionFilter{State: "HALT"}},
+	}
+	for _, cp := range checkpoints {
+		require.False(t, cp.matches(Notification{Type: cp.Event, Value: "unexpected"}), cp.Event)
+		// Filter of a wrong type doesn't panic either.
+		cp.Filter = 42
+		require.False(t, cp.matches(Notification{Type: cp.Event}), cp.Event)
+	}
+}
+
 func TestNewWS(t *testing.T) {
 	srv := initTestServer(t, "")
 
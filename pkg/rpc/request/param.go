@@ -227,6 +227,24 @@ func (p Param) GetSignerWithWitness() (SignerWithWitness, error) {
 	return c, nil
 }
 
+// NewSignersWithWitnesses combines given signers with the corresponding
+// witnesses into a slice of SignerWithWitness suitable for RPC requests.
+// Witnesses are optional, but if they're present, their number must match
+// the number of signers.
+func NewSignersWithWitnesses(signers []transaction.Signer, witnesses []transaction.Witness) ([]SignerWithWitness, error) {
+	if witnesses != nil && len(witnesses) != len(signers) {
+		return nil, fmt.Errorf("number of witnesses should match number of signers, got %d vs %d", len(witnesses), len(signers))
+	}
+	res := make([]SignerWithWitness, len(signers))
+	for i := range signers {
+		res[i].Signer = signers[i]
+		if witnesses != nil {
+			res[i].Witness = witnesses[i]
+		}
+	}
+	return res, nil
+}
+
 // GetSignersWithWitnesses returns a slice of SignerWithWitness with CalledByEntry
 // scope from array of Uint160 or array of serialized transaction.Signer stored
 // in the parameter.
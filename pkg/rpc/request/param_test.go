@@ -322,6 +322,55 @@ func TestParamGetSigner(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestSignerWithWitnessJSON(t *testing.T) {
+	s := transaction.Signer{
+		Account:          util.Uint160{1, 2, 3, 4},
+		Scopes:           transaction.CustomContracts,
+		AllowedContracts: []util.Uint160{{1, 2, 3}, {4, 5, 6}},
+	}
+	expected, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	sw := SignerWithWitness{Signer: s}
+	actual, err := json.Marshal(&sw)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(actual))
+
+	var p Param
+	require.NoError(t, json.Unmarshal(expected, &p))
+	actualSW, err := p.GetSignerWithWitness()
+	require.NoError(t, err)
+	require.Equal(t, sw, actualSW)
+}
+
+func TestNewSignersWithWitnesses(t *testing.T) {
+	signers := []transaction.Signer{
+		{Account: util.Uint160{1, 2, 3}, Scopes: transaction.CalledByEntry},
+		{Account: util.Uint160{4, 5, 6}, Scopes: transaction.Global},
+	}
+	t.Run("without witnesses", func(t *testing.T) {
+		actual, err := NewSignersWithWitnesses(signers, nil)
+		require.NoError(t, err)
+		require.Equal(t, []SignerWithWitness{{Signer: signers[0]}, {Signer: signers[1]}}, actual)
+	})
+	t.Run("with witnesses", func(t *testing.T) {
+		witnesses := []transaction.Witness{
+			{InvocationScript: []byte{1}, VerificationScript: []byte{2}},
+			{InvocationScript: []byte{3}, VerificationScript: []byte{4}},
+		}
+		actual, err := NewSignersWithWitnesses(signers, witnesses)
+		require.NoError(t, err)
+		require.Equal(t, []SignerWithWitness{
+			{Signer: signers[0], Witness: witnesses[0]},
+			{Signer: signers[1], Witness: witnesses[1]},
+		}, actual)
+	})
+	t.Run("bad witnesses number", func(t *testing.T) {
+		_, err := NewSignersWithWitnesses(signers, []transaction.Witness{{}})
+		require.Error(t, err)
+	})
+}
+
 func TestParamGetSigners(t *testing.T) {
 	u1 := util.Uint160{1, 2, 3, 4}
 	u2 := util.Uint160{5, 6, 7, 8}
@@ -64,6 +64,12 @@ func NewMethodNotFoundError(data string, cause error) *Error {
 	return NewError(-32601, http.StatusMethodNotAllowed, "Method not found", data, cause)
 }
 
+// NewMethodDisabledError creates a new error with code -32601 for methods
+// disabled in the server configuration.
+func NewMethodDisabledError(data string) *Error {
+	return NewError(-32601, http.StatusForbidden, "Method disabled", data, nil)
+}
+
 // NewInvalidParamsError creates a new error with
 // code -32602.
 func NewInvalidParamsError(data string, cause error) *Error {
@@ -76,6 +82,18 @@ func NewInternalServerError(data string, cause error) *Error {
 	return NewError(-32603, http.StatusInternalServerError, "Internal error", data, cause)
 }
 
+// NewTimeoutError creates a new error with
+// code -32001.
+func NewTimeoutError(data string, cause error) *Error {
+	return NewError(-32001, http.StatusServiceUnavailable, "Request timeout", data, cause)
+}
+
+// NewServerBusyError creates a new error with
+// code -32002.
+func NewServerBusyError(data string) *Error {
+	return NewError(-32002, http.StatusServiceUnavailable, "Server busy", data, nil)
+}
+
 // NewRPCError creates a new error with
 // code -100.
 func NewRPCError(message string, data string, cause error) *Error {
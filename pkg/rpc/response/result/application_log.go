@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -68,6 +69,24 @@ func (l *ApplicationLog) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// InvokedContracts returns a sorted list of unique contracts invoked during
+// all executions included into the log.
+func (l ApplicationLog) InvokedContracts() []util.Uint160 {
+	var res []util.Uint160
+	for i := range l.Executions {
+		for _, h := range l.Executions[i].Invocations {
+			n := sort.Search(len(res), func(j int) bool { return !res[j].Less(h) })
+			if n < len(res) && res[n].Equals(h) {
+				continue
+			}
+			res = append(res, util.Uint160{})
+			copy(res[n+1:], res[n:])
+			res[n] = h
+		}
+	}
+	return res
+}
+
 // NewApplicationLog creates ApplicationLog from a set of several application execution results
 // including only the results with the specified trigger.
 func NewApplicationLog(hash util.Uint256, aers []state.AppExecResult, trig trigger.Type) ApplicationLog {
@@ -0,0 +1,13 @@
+package result
+
+// BlockWithLogs is a verbose block along with application logs of its
+// transactions and block-level (OnPersist and PostPersist) executions
+// returned by getblockwithlogs RPC.
+type BlockWithLogs struct {
+	Block Block `json:"block"`
+	// BlockLog contains OnPersist and PostPersist executions of the block.
+	BlockLog ApplicationLog `json:"blocklog"`
+	// TransactionLogs contains application logs of block's transactions in
+	// the same order as transactions are included into the block.
+	TransactionLogs []ApplicationLog `json:"txlogs"`
+}
@@ -0,0 +1,14 @@
+package result
+
+// ConsensusState is a result of `getconsensusstate` RPC call.
+type ConsensusState struct {
+	// Height is the index of the block being agreed on.
+	Height uint32 `json:"height"`
+	// View is the current view number.
+	View byte `json:"view"`
+	// Primary is the index of the primary node of the current view.
+	Primary uint `json:"primary"`
+	// NextBlockTime is the expected timestamp of the next block (in
+	// milliseconds, like block timestamps).
+	NextBlockTime uint64 `json:"nextblocktime"`
+}
@@ -0,0 +1,14 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ContractInfo is a short deployed contract description returned by
+// listcontracts RPC.
+type ContractInfo struct {
+	Hash          util.Uint160 `json:"hash"`
+	ID            int32        `json:"id"`
+	Name          string       `json:"name"`
+	UpdateCounter uint16       `json:"updatecounter"`
+}
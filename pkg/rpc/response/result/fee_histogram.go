@@ -0,0 +1,74 @@
+package result
+
+import "math"
+
+// FeeHistogram represents a result of getfeehistogram RPC call. It contains
+// fee per byte distribution of transactions currently in the mempool and fee
+// statistics of the recent blocks.
+type FeeHistogram struct {
+	// Height is the current blockchain height.
+	Height uint32 `json:"height"`
+	// MaxTransactionsPerBlock is the maximum number of transactions that can
+	// be included into a single block.
+	MaxTransactionsPerBlock int `json:"maxtransactionsperblock"`
+	// Mempool contains mempooled transactions grouped by fee per byte and
+	// sorted by fee per byte in descending order.
+	Mempool []FeeBucket `json:"mempool"`
+	// Blocks contains fee statistics for the recent blocks starting from the
+	// latest one.
+	Blocks []BlockFees `json:"blocks"`
+}
+
+// FeeBucket is a number of transactions with the same fee per byte.
+type FeeBucket struct {
+	FeePerByte int64 `json:"feeperbyte"`
+	Count      int   `json:"count"`
+}
+
+// BlockFees contains fee statistics of a single block.
+type BlockFees struct {
+	Index uint32 `json:"index"`
+	// TxCount is the number of transactions in the block.
+	TxCount int `json:"txcount"`
+	// MinFeePerByte is the minimum fee per byte of transactions included
+	// into the block, it's 0 for empty blocks.
+	MinFeePerByte int64 `json:"minfeeperbyte"`
+}
+
+// InclusionProbability estimates the probability for transaction with the
+// given fee per byte to be included into one of the next k blocks. It's a
+// heuristic based on the number of mempooled transactions that are going to
+// be included before this one and on the share of recent blocks that would
+// have accepted such transaction (that either weren't full or included
+// transactions with lower or equal fee per byte).
+func (h *FeeHistogram) InclusionProbability(feePerByte int64, k int) float64 {
+	if k <= 0 {
+		return 0
+	}
+	// Transactions with the same fee per byte are considered to be ahead.
+	var ahead int
+	for _, b := range h.Mempool {
+		if b.FeePerByte >= feePerByte {
+			ahead += b.Count
+		}
+	}
+	var tries = k
+	if h.MaxTransactionsPerBlock > 0 {
+		tries = k - ahead/h.MaxTransactionsPerBlock
+		if tries <= 0 {
+			return 0
+		}
+	}
+	if len(h.Blocks) == 0 {
+		return 1
+	}
+	var accepting int
+	for _, b := range h.Blocks {
+		full := h.MaxTransactionsPerBlock > 0 && b.TxCount >= h.MaxTransactionsPerBlock
+		if !full || b.MinFeePerByte <= feePerByte {
+			accepting++
+		}
+	}
+	pBlock := float64(accepting) / float64(len(h.Blocks))
+	return 1 - math.Pow(1-pBlock, float64(tries))
+}
@@ -0,0 +1,60 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeHistogram_InclusionProbability(t *testing.T) {
+	h := &FeeHistogram{
+		MaxTransactionsPerBlock: 2,
+		Mempool: []FeeBucket{
+			{FeePerByte: 100, Count: 2},
+			{FeePerByte: 50, Count: 3},
+			{FeePerByte: 10, Count: 1},
+		},
+		Blocks: []BlockFees{
+			{Index: 4, TxCount: 2, MinFeePerByte: 60},
+			{Index: 3, TxCount: 2, MinFeePerByte: 40},
+			{Index: 2, TxCount: 1, MinFeePerByte: 100},
+			{Index: 1, TxCount: 2, MinFeePerByte: 20},
+		},
+	}
+
+	t.Run("fee ordering", func(t *testing.T) {
+		var fees = []int64{1, 10, 20, 40, 50, 60, 100, 200}
+		for _, k := range []int{1, 3, 5} {
+			prev := -1.0
+			for _, fee := range fees {
+				p := h.InclusionProbability(fee, k)
+				require.True(t, p >= 0 && p <= 1)
+				require.True(t, p >= prev, "fee %d, %d blocks", fee, k)
+				prev = p
+			}
+		}
+	})
+	t.Run("blocks ordering", func(t *testing.T) {
+		for _, fee := range []int64{10, 50, 200} {
+			prev := -1.0
+			for k := 1; k < 10; k++ {
+				p := h.InclusionProbability(fee, k)
+				require.True(t, p >= prev, "fee %d, %d blocks", fee, k)
+				prev = p
+			}
+		}
+	})
+	t.Run("queue doesn't fit", func(t *testing.T) {
+		// 6 transactions are ahead, that's 3 full blocks.
+		require.Equal(t, 0.0, h.InclusionProbability(1, 3))
+		require.True(t, h.InclusionProbability(1, 4) > 0)
+		require.Equal(t, 0.0, h.InclusionProbability(200, 0))
+	})
+	t.Run("high fee", func(t *testing.T) {
+		require.Equal(t, 1.0, h.InclusionProbability(200, 1))
+	})
+	t.Run("no blocks", func(t *testing.T) {
+		h := &FeeHistogram{MaxTransactionsPerBlock: 2}
+		require.Equal(t, 1.0, h.InclusionProbability(0, 1))
+	})
+}
@@ -3,9 +3,12 @@ package result
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/iterator"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
@@ -13,15 +16,31 @@ import (
 // Invoke represents code invocation result and is used by several RPC calls
 // that invoke functions, scripts and generic bytecode.
 type Invoke struct {
-	State                  string
-	GasConsumed            int64
-	Script                 []byte
-	Stack                  []stackitem.Item
-	FaultException         string
-	Transaction            *transaction.Transaction
+	State          string
+	GasConsumed    int64
+	Script         []byte
+	Stack          []stackitem.Item
+	FaultException string
+	Transaction    *transaction.Transaction
+	GasBreakdown   *GasBreakdown
+	// Invocations is a sorted list of contracts invoked during execution
+	// (directly or via other contracts).
+	Invocations            []util.Uint160
 	maxIteratorResultItems int
 }
 
+// GasBreakdown shows how GAS was spent during invocation. Every category
+// includes fixed prices and dynamic fees of the corresponding instructions:
+// storage is spent by System.Storage.* syscalls, syscalls category has all the
+// other syscalls (including native contract calls) and opcodes category
+// contains the price of all the other instructions executed. All categories
+// sum up to the total GAS consumed.
+type GasBreakdown struct {
+	Opcodes  int64 `json:"opcodes,string"`
+	Storage  int64 `json:"storage,string"`
+	Syscalls int64 `json:"syscalls,string"`
+}
+
 // NewInvoke returns new Invoke structure with the given fields set.
 func NewInvoke(vm *vm.VM, script []byte, faultException string, maxIteratorResultItems int) *Invoke {
 	return &Invoke{
@@ -30,10 +49,26 @@ func NewInvoke(vm *vm.VM, script []byte, faultException string, maxIteratorResul
 		Script:                 script,
 		Stack:                  vm.Estack().ToArray(),
 		FaultException:         faultException,
+		Invocations:            invokedContracts(vm, hash.Hash160(script)),
 		maxIteratorResultItems: maxIteratorResultItems,
 	}
 }
 
+// invokedContracts returns a sorted list of contracts invoked by the VM
+// excluding the entry script.
+func invokedContracts(vm *vm.VM, entry util.Uint160) []util.Uint160 {
+	var res []util.Uint160
+	for h := range vm.Invocations {
+		if !h.Equals(entry) {
+			res = append(res, h)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Less(res[j])
+	})
+	return res
+}
+
 type invokeAux struct {
 	State          string          `json:"state"`
 	GasConsumed    int64           `json:"gasconsumed,string"`
@@ -41,6 +76,8 @@ type invokeAux struct {
 	Stack          json.RawMessage `json:"stack"`
 	FaultException string          `json:"exception,omitempty"`
 	Transaction    []byte          `json:"tx,omitempty"`
+	GasBreakdown   *GasBreakdown   `json:"gasbreakdown,omitempty"`
+	Invocations    []util.Uint160  `json:"invocations,omitempty"`
 }
 
 type iteratorAux struct {
@@ -110,6 +147,8 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		Stack:          st,
 		FaultException: r.FaultException,
 		Transaction:    txbytes,
+		GasBreakdown:   r.GasBreakdown,
+		Invocations:    r.Invocations,
 	})
 }
 
@@ -165,5 +204,7 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	r.State = aux.State
 	r.FaultException = aux.FaultException
 	r.Transaction = tx
+	r.GasBreakdown = aux.GasBreakdown
+	r.Invocations = aux.Invocations
 	return nil
 }
@@ -8,12 +8,13 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
 func TestInvoke_MarshalJSON(t *testing.T) {
-	tx := transaction.New([]byte{1, 2, 3, 4}, 0)
+	tx := transaction.New([]byte{byte(opcode.PUSH1), byte(opcode.RET)}, 0)
 	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
 	tx.Scripts = []transaction.Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
 	_ = tx.Size()
@@ -45,3 +46,28 @@ func TestInvoke_MarshalJSON(t *testing.T) {
 	require.NoError(t, json.Unmarshal(data, actual))
 	require.Equal(t, result, actual)
 }
+
+func TestInvoke_MarshalJSONGasBreakdown(t *testing.T) {
+	result := &Invoke{
+		State:        "HALT",
+		GasConsumed:  600,
+		Script:       []byte{10},
+		Stack:        []stackitem.Item{},
+		GasBreakdown: &GasBreakdown{Opcodes: 100, Storage: 200, Syscalls: 300},
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	expected := `{
+		"state":"HALT",
+		"gasconsumed":"600",
+		"script":"` + base64.StdEncoding.EncodeToString(result.Script) + `",
+		"stack":[],
+		"gasbreakdown":{"opcodes":"100","storage":"200","syscalls":"300"}
+}`
+	require.JSONEq(t, expected, string(data))
+
+	actual := new(Invoke)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, result, actual)
+}
@@ -0,0 +1,16 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// TransactionTrace is a result of transaction re-execution against historical
+// state returned by tracetransaction RPC.
+type TransactionTrace struct {
+	TxHash    util.Uint256    `json:"txid"`
+	Execution state.Execution `json:"execution"`
+	// Trace contains the last executed instructions.
+	Trace []vm.TraceStep `json:"trace"`
+}
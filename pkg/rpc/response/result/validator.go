@@ -2,12 +2,22 @@ package result
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 // Validator used for the representation of
-// state.Validator on the RPC Server.
+// state.Validator on the RPC Server. Votes are NEO-weighted, that is they're
+// the sum of NEO balances of accounts voting for this validator.
 type Validator struct {
 	PublicKey keys.PublicKey `json:"publickey"`
 	Votes     int64          `json:"votes,string"`
 	Active    bool           `json:"active"`
+	// Voters is only returned by verbose request.
+	Voters []Voter `json:"voters,omitempty"`
+}
+
+// Voter is an account voting for the validator with its NEO balance.
+type Voter struct {
+	Account util.Uint160 `json:"account"`
+	Votes   int64        `json:"votes,string"`
 }
@@ -13,5 +13,8 @@ type (
 		UserAgent string        `json:"useragent"`
 		// StateRootInHeader is true if state root is contained in block header.
 		StateRootInHeader bool `json:"staterootinheader,omitempty"`
+		// AttributeFeeBase is the base of transaction attributes network fee
+		// escalation.
+		AttributeFeeBase int64 `json:"attributefeebase,omitempty"`
 	}
 )
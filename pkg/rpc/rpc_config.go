@@ -1,21 +1,62 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 )
 
 type (
 	// Config is an RPC service configuration information.
 	Config struct {
-		Address              string `yaml:"Address"`
-		Enabled              bool   `yaml:"Enabled"`
-		EnableCORSWorkaround bool   `yaml:"EnableCORSWorkaround"`
+		Address string `yaml:"Address"`
+		// CORS contains CORS headers settings for browser clients.
+		CORS CORSConfig `yaml:"CORS"`
+		// DisabledMethods is a list of RPC methods that are not allowed to
+		// be called, "Method disabled" error is returned for them.
+		DisabledMethods      []string `yaml:"DisabledMethods"`
+		Enabled              bool     `yaml:"Enabled"`
+		EnableCORSWorkaround bool     `yaml:"EnableCORSWorkaround"`
+		// EnableForceBlock enables forceblock call, it only works for
+		// servers bound to loopback address.
+		EnableForceBlock bool `yaml:"EnableForceBlock"`
+		// EnableSubmitConsensus enables submitconsensus call, it only
+		// works for servers bound to loopback address.
+		EnableSubmitConsensus bool `yaml:"EnableSubmitConsensus"`
+		// EnableTraceTransaction enables tracetransaction call which
+		// re-executes historical transactions.
+		EnableTraceTransaction bool `yaml:"EnableTraceTransaction"`
 		// MaxGasInvoke is a maximum amount of gas which
 		// can be spent during RPC call.
-		MaxGasInvoke           fixedn.Fixed8 `yaml:"MaxGasInvoke"`
-		MaxIteratorResultItems int           `yaml:"MaxIteratorResultItems"`
-		Port                   uint16        `yaml:"Port"`
-		TLSConfig              TLSConfig     `yaml:"TLSConfig"`
+		MaxGasInvoke fixedn.Fixed8 `yaml:"MaxGasInvoke"`
+		// MaxConcurrentInvocations limits the number of scripts
+		// (invokefunction, invokescript and similar calls) executed
+		// concurrently, excessive requests are queued or rejected
+		// depending on RejectExcessInvocations. No limit is applied
+		// if it's not set.
+		MaxConcurrentInvocations int    `yaml:"MaxConcurrentInvocations"`
+		MaxIteratorResultItems   int    `yaml:"MaxIteratorResultItems"`
+		Port                     uint16 `yaml:"Port"`
+		// RejectExcessInvocations makes the server reject script
+		// invocations exceeding MaxConcurrentInvocations limit with
+		// "Server busy" error instead of queueing them.
+		RejectExcessInvocations bool `yaml:"RejectExcessInvocations"`
+		// RequestTimeout is a maximum duration of a single script
+		// invocation (invokefunction, invokescript and similar calls),
+		// no timeout is applied if it's not set.
+		RequestTimeout time.Duration `yaml:"RequestTimeout"`
+		TLSConfig      TLSConfig     `yaml:"TLSConfig"`
+	}
+
+	// CORSConfig describes CORS settings of the RPC server. No CORS headers
+	// are sent if AllowedOrigins is empty.
+	CORSConfig struct {
+		// AllowedOrigins is a list of origins allowed to make cross-origin
+		// requests, "*" allows any origin.
+		AllowedOrigins []string `yaml:"AllowedOrigins"`
+		// AllowedMethods is a list of HTTP methods allowed for cross-origin
+		// requests, "GET, POST, OPTIONS" is used if it's empty.
+		AllowedMethods []string `yaml:"AllowedMethods"`
 	}
 
 	// TLSConfig describes SSL/TLS configuration.
@@ -3,18 +3,31 @@ package server
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -69,6 +82,91 @@ func TestClient_NEP17(t *testing.T) {
 		require.NoError(t, err)
 		require.EqualValues(t, 877, b)
 	})
+	t.Run("GetNEP17Balance", func(t *testing.T) {
+		acc := testchain.PrivateKeyByID(0).GetScriptHash()
+		b, err := c.GetNEP17Balance(h, acc)
+		require.NoError(t, err)
+		require.Equal(t, h, b.Asset)
+		require.Equal(t, "877", b.Amount)
+		require.NotEqual(t, uint32(0), b.LastUpdated)
+
+		neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+		require.NoError(t, err)
+		expected, err := c.NEP17BalanceOf(neoHash, acc)
+		require.NoError(t, err)
+		b, err = c.GetNEP17Balance(neoHash, acc)
+		require.NoError(t, err)
+		require.Equal(t, neoHash, b.Asset)
+		require.Equal(t, strconv.FormatInt(expected, 10), b.Amount)
+		bal, lub := chain.GetGoverningTokenBalance(acc)
+		require.Equal(t, bal.String(), b.Amount)
+		require.Equal(t, lub, b.LastUpdated)
+
+		b, err = c.GetNEP17Balance(neoHash, util.Uint160{1, 2, 3})
+		require.NoError(t, err)
+		require.Equal(t, "0", b.Amount)
+		require.Equal(t, uint32(0), b.LastUpdated)
+
+		_, err = c.GetNEP17Balance(util.Uint160{1, 2, 3}, acc)
+		require.Error(t, err)
+	})
+}
+
+func TestClient_GetAddressHistory(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := testchain.PrivateKeyByID(0).GetScriptHash()
+	check := func(t *testing.T, from, to uint32) {
+		var in, out int
+		require.NoError(t, chain.ForEachNEP17Transfer(acc, func(tr *state.NEP17Transfer) (bool, error) {
+			if tr.Block >= from && tr.Block <= to {
+				if tr.Amount.Sign() > 0 {
+					in++
+				} else {
+					out++
+				}
+			}
+			return true, nil
+		}))
+
+		h, err := c.GetAddressHistory(address.Uint160ToString(acc), from, to)
+		require.NoError(t, err)
+		require.Equal(t, in+out, len(h))
+		var actualIn int
+		for i := range h {
+			require.True(t, h[i].Index >= from && h[i].Index <= to)
+			if i > 0 {
+				require.True(t, h[i-1].Index <= h[i].Index)
+			}
+			if h[i].Incoming {
+				actualIn++
+			}
+		}
+		require.Equal(t, in, actualIn)
+	}
+	t.Run("whole chain", func(t *testing.T) {
+		check(t, 0, chain.BlockHeight())
+	})
+	t.Run("range", func(t *testing.T) {
+		check(t, 3, 10)
+	})
+	t.Run("single block", func(t *testing.T) {
+		check(t, 5, 5)
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := c.GetAddressHistory(address.Uint160ToString(acc), 10, 3)
+		require.Error(t, err)
+	})
+	t.Run("missing block", func(t *testing.T) {
+		_, err := c.GetAddressHistory(address.Uint160ToString(acc), 0, chain.BlockHeight()+1)
+		require.Error(t, err)
+	})
 }
 
 func TestAddNetworkFeeCalculateNetworkFee(t *testing.T) {
@@ -390,6 +488,61 @@ func TestCalculateNetworkFee(t *testing.T) {
 		})
 	})
 }
+
+func TestCalculateNetworkFeeAttributes(t *testing.T) {
+	const base = 1_000_000
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, false, false, func(cfg *config.Config) {
+		cfg.ProtocolConfiguration.ReservedAttributes = true
+		cfg.ProtocolConfiguration.AttributeFeeBase = base
+	})
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc0 := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	newTx := func(n int) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 10
+		tx.Signers = []transaction.Signer{{Account: acc0.PrivateKey().GetScriptHash()}}
+		for i := 0; i < n; i++ {
+			tx.Attributes = append(tx.Attributes, transaction.Attribute{
+				Type:  transaction.ReservedLowerBound + 3 + transaction.AttrType(i),
+				Value: &transaction.Reserved{Value: []byte{1}},
+			})
+		}
+		// we need to fill standard verification scripts to use CalculateNetworkFee.
+		tx.Scripts = []transaction.Witness{{VerificationScript: acc0.GetVerificationScript()}}
+		return tx
+	}
+
+	noAttrsFee, err := c.CalculateNetworkFee(newTx(0))
+	require.NoError(t, err)
+	for _, n := range []int{1, 3} {
+		tx := newTx(n)
+		netFee, err := c.CalculateNetworkFee(tx)
+		require.NoError(t, err)
+		sizeDelta := int64(io.GetVarSize(tx) - io.GetVarSize(newTx(0)))
+		require.Equal(t, noAttrsFee+sizeDelta*chain.FeePerByte()+transaction.AttributesFee(base, tx.Attributes), netFee)
+
+		tx.Scripts = nil
+		tx.NetworkFee = netFee
+		require.NoError(t, acc0.SignTx(testchain.Network(), tx))
+		require.NoError(t, chain.VerifyTx(tx))
+
+		tx = newTx(n)
+		tx.Scripts = nil
+		tx.NetworkFee = netFee - 1
+		require.NoError(t, acc0.SignTx(testchain.Network(), tx))
+		require.Error(t, chain.VerifyTx(tx))
+	}
+}
+
 func TestSignAndPushInvocationTx(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -638,6 +791,95 @@ func TestSignAndPushP2PNotaryRequest(t *testing.T) {
 	})
 }
 
+func TestClient_NotaryDeposit(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	from := acc.PrivateKey().GetScriptHash()
+	amount := int64(2 * transaction.NotaryServiceFeePerKey)
+
+	t.Run("other account", func(t *testing.T) {
+		to := util.Uint160{1, 2, 3}
+		tx, err := c.CreateNotaryDepositTx(acc, &to, amount, chain.BlockHeight()+100, 0)
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, chain.UtilityTokenHash(), "transfer", callflag.All,
+			from, chain.GetNotaryContractScriptHash(), amount, []interface{}{to, int64(chain.BlockHeight() + 100)})
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+		require.NoError(t, w.Err)
+		require.Equal(t, w.Bytes(), tx.Script)
+
+		require.NoError(t, acc.SignTx(testchain.Network(), tx))
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+		require.Equal(t, big.NewInt(amount), chain.GetNotaryBalance(to))
+	})
+	t.Run("self", func(t *testing.T) {
+		balance := chain.GetNotaryBalance(from)
+		till := chain.GetNotaryDepositExpiration(from) + 10
+		h, err := c.NotaryDeposit(acc, nil, amount, till, 0)
+		require.NoError(t, err)
+		tx, ok := chain.GetMemPool().TryGetValue(h)
+		require.True(t, ok)
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+		require.Equal(t, new(big.Int).Add(balance, big.NewInt(amount)), chain.GetNotaryBalance(from))
+		require.Equal(t, till, chain.GetNotaryDepositExpiration(from))
+	})
+}
+
+func TestClient_CreateNotaryAssistedTx(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	cosigners := []client.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.PrivateKey().GetScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}}
+	tx, err := c.CreateNotaryAssistedTx([]byte{byte(opcode.RET)}, -1, 1, cosigners)
+	require.NoError(t, err)
+
+	require.Equal(t, []transaction.Attribute{{
+		Type:  transaction.NotaryAssistedT,
+		Value: &transaction.NotaryAssisted{NKeys: 1},
+	}}, tx.Attributes)
+	require.Equal(t, []transaction.Signer{
+		cosigners[0].Signer,
+		{Account: chain.GetNotaryContractScriptHash(), Scopes: transaction.None},
+	}, tx.Signers)
+	require.Equal(t, 2, len(tx.Scripts))
+	require.Equal(t, acc.GetVerificationScript(), tx.Scripts[0].VerificationScript)
+	require.Empty(t, tx.Scripts[0].InvocationScript)
+	require.Empty(t, tx.Scripts[1].VerificationScript)
+	notaryFee, err := c.CalculateNotaryFee(1)
+	require.NoError(t, err)
+	require.True(t, tx.NetworkFee > notaryFee)
+
+	// Main transaction is accepted as a part of notary request.
+	tx.Scripts[0].InvocationScript = append([]byte{byte(opcode.PUSHDATA1), 64}, acc.PrivateKey().SignHashable(uint32(testchain.Network()), tx)...)
+	_, err = c.SignAndPushP2PNotaryRequest(tx, []byte{byte(opcode.RET)}, -1, 0, 6, acc)
+	require.NoError(t, err)
+
+	t.Run("no cosigners", func(t *testing.T) {
+		_, err := c.CreateNotaryAssistedTx([]byte{byte(opcode.RET)}, -1, 1, nil)
+		require.Error(t, err)
+	})
+}
+
 func TestCalculateNotaryFee(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -666,6 +908,115 @@ func TestPing(t *testing.T) {
 	require.Error(t, c.Ping())
 }
 
+func TestClient_GetBlockApplicationLog(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	b, err := chain.GetBlock(chain.GetHeaderHash(1))
+	require.NoError(t, err)
+	require.True(t, len(b.Transactions) > 0)
+
+	log, err := c.GetBlockApplicationLog(b.Hash())
+	require.NoError(t, err)
+	require.Equal(t, b.Hash(), log.Container)
+	require.Equal(t, 2, len(log.Executions))
+	require.Equal(t, trigger.OnPersist, log.Executions[0].Trigger)
+	require.Equal(t, trigger.PostPersist, log.Executions[1].Trigger)
+
+	// Fees of all block transactions are burnt by GAS contract in OnPersist.
+	var burnt int
+	for _, e := range log.Executions[0].Events {
+		require.Equal(t, "Transfer", e.Name)
+		require.Equal(t, chain.UtilityTokenHash(), e.ScriptHash)
+		arr := e.Item.Value().([]stackitem.Item)
+		if arr[1].Type() == stackitem.AnyT {
+			burnt++
+		}
+	}
+	require.Equal(t, len(b.Transactions), burnt)
+
+	_, err = c.GetBlockApplicationLog(b.Transactions[0].Hash())
+	require.Error(t, err)
+	_, err = c.GetBlockApplicationLog(util.Uint256{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestClient_GetNextValidatorsAddress(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	// Fresh chain has no registered candidates, so standby validators are used.
+	vals, err := chain.GetNextBlockValidators()
+	require.NoError(t, err)
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(vals)
+	require.NoError(t, err)
+
+	actual, err := c.GetNextValidatorsAddress()
+	require.NoError(t, err)
+	require.Equal(t, hash.Hash160(script), actual)
+}
+
+func TestClient_GetNextBlockValidatorsVerbose(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	from := acc.Contract.ScriptHash()
+	pub := acc.PrivateKey().PublicKey()
+
+	gasTx, err := testchain.NewTransferFromOwner(chain, chain.UtilityTokenHash(), from, 1010_00000000, 0, chain.BlockHeight()+1)
+	require.NoError(t, err)
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, gasTx)))
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, chain.GoverningTokenHash(), "registerCandidate", callflag.All, pub.Bytes())
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	emit.AppCall(w.BinWriter, chain.GoverningTokenHash(), "vote", callflag.All, from, pub.Bytes())
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	require.NoError(t, w.Err)
+	// Registration price exceeds MaxGasInvoke, so system fee is set manually.
+	tx := transaction.New(w.Bytes(), 1001_00000000)
+	tx.Signers = []transaction.Signer{{Account: from, Scopes: transaction.CalledByEntry}}
+	tx.ValidUntilBlock = chain.BlockHeight() + 1
+	require.NoError(t, c.AddNetworkFee(tx, 0, acc))
+	require.NoError(t, acc.SignTx(testchain.Network(), tx))
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+	aer, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vm.HaltState, aer[0].VMState)
+
+	balance, _ := chain.GetGoverningTokenBalance(from)
+	require.True(t, balance.Sign() > 0)
+
+	vals, err := c.GetNextBlockValidators()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(vals))
+	require.Equal(t, *pub, vals[0].PublicKey)
+	require.Equal(t, balance.Int64(), vals[0].Votes)
+	require.Nil(t, vals[0].Voters)
+
+	vals, err = c.GetNextBlockValidatorsVerbose()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(vals))
+	require.Equal(t, balance.Int64(), vals[0].Votes)
+	require.Equal(t, []result.Voter{{Account: from, Votes: balance.Int64()}}, vals[0].Voters)
+}
+
 func TestCreateTxFromScript(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -695,6 +1046,65 @@ func TestCreateTxFromScript(t *testing.T) {
 	})
 }
 
+func TestCreateTxFromScriptAutoScope(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	from := acc.Contract.ScriptHash()
+	check := func(t *testing.T, tx *transaction.Transaction) {
+		require.NoError(t, acc.SignTx(testchain.Network(), tx))
+		require.NoError(t, chain.VerifyTx(tx))
+		v := chain.GetTestVM(trigger.Application, tx, nil)
+		v.LoadScriptWithFlags(tx.Script, callflag.All)
+		require.NoError(t, v.Run())
+	}
+
+	t.Run("transfer", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, chain.UtilityTokenHash(), "transfer", callflag.All, from, util.Uint160{1, 2, 3}, int64(1000), nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+		require.NoError(t, w.Err)
+
+		// Sender scope from cosigners is ignored.
+		tx, err := c.CreateTxFromScriptAutoScope(w.Bytes(), acc, -1, 0, []client.SignerAccount{{
+			Signer:  transaction.Signer{Account: from, Scopes: transaction.Global},
+			Account: acc,
+		}})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(tx.Signers))
+		require.Equal(t, from, tx.Signers[0].Account)
+		require.Equal(t, transaction.CalledByEntry, tx.Signers[0].Scopes)
+		check(t, tx)
+	})
+	t.Run("multiple contracts", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, chain.UtilityTokenHash(), "transfer", callflag.All, from, util.Uint160{1, 2, 3}, int64(1000), nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+		emit.AppCall(w.BinWriter, chain.GoverningTokenHash(), "transfer", callflag.All, from, util.Uint160{1, 2, 3}, int64(1), nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+		require.NoError(t, w.Err)
+
+		tx, err := c.CreateTxFromScriptAutoScope(w.Bytes(), acc, -1, 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(tx.Signers))
+		require.Equal(t, transaction.CustomContracts, tx.Signers[0].Scopes)
+		expected := []util.Uint160{chain.UtilityTokenHash(), chain.GoverningTokenHash()}
+		sort.Slice(expected, func(i, j int) bool { return expected[i].Less(expected[j]) })
+		require.Equal(t, expected, tx.Signers[0].AllowedContracts)
+		check(t, tx)
+	})
+	t.Run("fault", func(t *testing.T) {
+		_, err := c.CreateTxFromScriptAutoScope([]byte{byte(opcode.ABORT)}, acc, -1, 0, nil)
+		require.Error(t, err)
+	})
+}
+
 func TestCreateNEP17TransferTx(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -719,49 +1129,140 @@ func TestCreateNEP17TransferTx(t *testing.T) {
 	require.NoError(t, v.Run())
 }
 
-func TestInvokeVerify(t *testing.T) {
+func TestClient_WaitForHeight(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
 	defer func() { _ = rpcSrv.Shutdown() }()
 
 	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
 	require.NoError(t, err)
-	require.NoError(t, c.Init())
 
-	contract, err := util.Uint160DecodeStringLE(verifyContractHash)
+	t.Run("already reached", func(t *testing.T) {
+		require.NoError(t, c.WaitForHeight(chain.BlockHeight(), time.Second))
+	})
+	t.Run("reached", func(t *testing.T) {
+		target := chain.BlockHeight() + 1
+		b := testchain.NewBlock(t, chain, 1, 0)
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = chain.AddBlock(b)
+		}()
+		require.NoError(t, c.WaitForHeight(target, 5*time.Second))
+		require.Equal(t, target, chain.BlockHeight())
+	})
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		require.Error(t, c.WaitForHeight(chain.BlockHeight()+10, 300*time.Millisecond))
+		require.True(t, time.Since(start) >= 300*time.Millisecond)
+	})
+}
+
+func TestClient_SendAndWait(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{RequestTimeout: 10 * time.Second})
 	require.NoError(t, err)
+	require.NoError(t, c.Init())
 
-	t.Run("positive, with signer", func(t *testing.T) {
-		res, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}})
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	gasContractHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+	newTx := func(t *testing.T) *transaction.Transaction {
+		tx, err := c.CreateNEP17TransferTx(acc, util.Uint160{}, gasContractHash, 1000, 0, nil, nil)
 		require.NoError(t, err)
-		require.Equal(t, "HALT", res.State)
-		require.Equal(t, 1, len(res.Stack))
-		require.True(t, res.Stack[0].Value().(bool))
-	})
+		require.NoError(t, acc.SignTx(testchain.Network(), tx))
+		return tx
+	}
 
-	t.Run("positive, with signer and witness", func(t *testing.T) {
-		res, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}}, transaction.Witness{InvocationScript: []byte{byte(opcode.PUSH1), byte(opcode.RET)}})
+	t.Run("persisted", func(t *testing.T) {
+		tx := newTx(t)
+		b := testchain.NewBlock(t, chain, 1, 0, tx)
+		errCh := make(chan error, 1)
+		go func() {
+			for i := 0; !chain.GetMemPool().ContainsKey(tx.Hash()); i++ {
+				if i == 100 {
+					errCh <- errors.New("transaction is not in the mempool")
+					return
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			errCh <- chain.AddBlock(b)
+		}()
+		aer, err := c.SendAndWait(tx, 5*time.Second)
+		require.NoError(t, <-errCh)
 		require.NoError(t, err)
-		require.Equal(t, "HALT", res.State)
-		require.Equal(t, 1, len(res.Stack))
-		require.True(t, res.Stack[0].Value().(bool))
+		require.Equal(t, tx.Hash(), aer.Container)
+		require.Equal(t, 1, len(aer.Executions))
+		require.Equal(t, vm.HaltState, aer.Executions[0].VMState)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		tx := newTx(t)
+		_, err := c.SendAndWait(tx, time.Second)
+		require.Error(t, err)
+		require.True(t, chain.GetMemPool().ContainsKey(tx.Hash()))
+	})
+	t.Run("relay error", func(t *testing.T) {
+		tx := newTx(t)
+		tx.Scripts[0].InvocationScript[10] ^= 0xff
+		_, err := c.SendAndWait(tx, time.Second)
+		require.Error(t, err)
 	})
+}
 
-	t.Run("error, invalid witness number", func(t *testing.T) {
-		_, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}}, transaction.Witness{InvocationScript: []byte{byte(opcode.PUSH1), byte(opcode.RET)}}, transaction.Witness{InvocationScript: []byte{byte(opcode.RET)}})
+func TestClient_CancelTransaction(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	gasContractHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+	target, err := c.CreateNEP17TransferTx(acc, util.Uint160{}, gasContractHash, 1000, 0, nil, nil)
+	require.NoError(t, err)
+	_, err = c.SignAndPushTx(target, acc, nil)
+	require.NoError(t, err)
+	require.True(t, chain.GetMemPool().ContainsKey(target.Hash()))
+
+	t.Run("not a signer", func(t *testing.T) {
+		other := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(1))
+		_, err := c.CancelTransaction(other, target.Hash(), 0)
+		require.Error(t, err)
+	})
+	t.Run("unknown target", func(t *testing.T) {
+		_, err := c.CancelTransaction(acc, util.Uint256{1, 2, 3}, 0)
 		require.Error(t, err)
 	})
 
-	t.Run("false", func(t *testing.T) {
-		res, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: util.Uint160{}}})
+	h, err := c.CancelTransaction(acc, target.Hash(), 100)
+	require.NoError(t, err)
+	mp := chain.GetMemPool()
+	require.False(t, mp.ContainsKey(target.Hash()))
+	tx, ok := mp.TryGetValue(h)
+	require.True(t, ok)
+	attrs := tx.GetAttributes(transaction.ConflictsT)
+	require.Equal(t, 1, len(attrs))
+	require.Equal(t, target.Hash(), attrs[0].Value.(*transaction.Conflicts).Hash)
+	require.True(t, tx.NetworkFee >= target.NetworkFee+100)
+	require.True(t, tx.HasSigner(acc.Contract.ScriptHash()))
+
+	t.Run("persisted target", func(t *testing.T) {
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+		other, err := c.CreateNEP17TransferTx(acc, util.Uint160{}, gasContractHash, 1000, 0, nil, nil)
 		require.NoError(t, err)
-		require.Equal(t, "HALT", res.State)
-		require.Equal(t, 1, len(res.Stack))
-		require.False(t, res.Stack[0].Value().(bool))
+		require.NoError(t, acc.SignTx(testchain.Network(), other))
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, other)))
+		_, err = c.CancelTransaction(acc, other.Hash(), 0)
+		require.Error(t, err)
 	})
 }
 
-func TestClient_GetNativeContracts(t *testing.T) {
+func TestClient_ClaimGAS(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
 	defer func() { _ = rpcSrv.Shutdown() }()
@@ -770,12 +1271,23 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, c.Init())
 
-	cs, err := c.GetNativeContracts()
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	accHash := acc.Contract.ScriptHash()
+	h, err := c.ClaimGAS(acc)
 	require.NoError(t, err)
-	require.Equal(t, chain.GetNatives(), cs)
+
+	tx, ok := chain.GetMemPool().TryGetValue(h)
+	require.True(t, ok)
+	require.Equal(t, accHash, tx.Sender())
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, chain.GoverningTokenHash(), "transfer", callflag.All, accHash, accHash, int64(0), nil)
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	require.NoError(t, w.Err)
+	require.Equal(t, w.Bytes(), tx.Script)
 }
 
-func TestClient_NEP11(t *testing.T) {
+func TestClient_RecommendFees(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
 	defer func() { _ = rpcSrv.Shutdown() }()
@@ -784,34 +1296,462 @@ func TestClient_NEP11(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, c.Init())
 
-	h, err := chain.GetNativeContractScriptHash(nativenames.NameService)
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	tx, err := c.CreateNEP17TransferTx(acc, util.Uint160{1, 2, 3}, chain.UtilityTokenHash(), 1000, 0, nil, nil)
 	require.NoError(t, err)
-	acc := testchain.PrivateKeyByID(0).GetScriptHash()
 
-	t.Run("Decimals", func(t *testing.T) {
-		d, err := c.NEP11Decimals(h)
-		require.NoError(t, err)
-		require.EqualValues(t, 0, d) // non-divisible
+	sysFee, netFee, err := c.RecommendFees(tx.Script, tx.Signers, []transaction.Witness{{
+		VerificationScript: acc.GetVerificationScript(),
+	}})
+	require.NoError(t, err)
+	require.True(t, sysFee > 0)
+	require.True(t, netFee > 0)
+	require.Equal(t, tx.SystemFee, sysFee)
+	require.Equal(t, tx.NetworkFee, netFee)
+
+	t.Run("no witness", func(t *testing.T) {
+		_, _, err := c.RecommendFees(tx.Script, tx.Signers, nil)
+		require.Error(t, err)
 	})
-	t.Run("TotalSupply", func(t *testing.T) {
-		s, err := c.NEP11TotalSupply(h)
-		require.NoError(t, err)
-		require.EqualValues(t, 1, s) // the only `neo.com` of acc0
+	t.Run("too many witnesses", func(t *testing.T) {
+		_, _, err := c.RecommendFees(tx.Script, tx.Signers, make([]transaction.Witness, 2))
+		require.Error(t, err)
 	})
-	t.Run("Symbol", func(t *testing.T) {
-		sym, err := c.NEP11Symbol(h)
-		require.NoError(t, err)
-		require.Equal(t, "NNS", sym)
+	t.Run("bad script", func(t *testing.T) {
+		_, _, err := c.RecommendFees([]byte{byte(opcode.ABORT)}, tx.Signers, nil)
+		require.Error(t, err)
 	})
-	t.Run("TokenInfo", func(t *testing.T) {
-		tok, err := c.NEP11TokenInfo(h)
-		require.NoError(t, err)
-		require.Equal(t, &wallet.Token{
-			Name:     nativenames.NameService,
-			Hash:     h,
-			Decimals: 0,
-			Symbol:   "NNS",
-			Standard: manifest.NEP11StandardName,
+}
+
+func TestCreateNEP17TransferTxWithNonce(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	gasContractHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+
+	newTx := func(t *testing.T, nonce uint32) *transaction.Transaction {
+		tx, err := c.CreateNEP17TransferTxWithNonce(acc, util.Uint160{}, gasContractHash, 1000, 0, nil, nil, nonce)
+		require.NoError(t, err)
+		require.Equal(t, nonce, tx.Nonce)
+		require.NoError(t, acc.SignTx(testchain.Network(), tx))
+		return tx
+	}
+	tx1 := newTx(t, 42)
+	tx2 := newTx(t, 42)
+	require.Equal(t, tx1.Hash(), tx2.Hash())
+	require.NoError(t, chain.VerifyTx(tx1))
+
+	tx3 := newTx(t, 43)
+	require.NotEqual(t, tx1.Hash(), tx3.Hash())
+}
+
+func TestInvokeVerify(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	contract, err := util.Uint160DecodeStringLE(verifyContractHash)
+	require.NoError(t, err)
+
+	t.Run("positive, with signer", func(t *testing.T) {
+		res, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}})
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.Equal(t, 1, len(res.Stack))
+		require.True(t, res.Stack[0].Value().(bool))
+	})
+
+	t.Run("positive, with signer and witness", func(t *testing.T) {
+		res, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}}, transaction.Witness{InvocationScript: []byte{byte(opcode.PUSH1), byte(opcode.RET)}})
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.Equal(t, 1, len(res.Stack))
+		require.True(t, res.Stack[0].Value().(bool))
+	})
+
+	t.Run("error, invalid witness number", func(t *testing.T) {
+		_, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()}}, transaction.Witness{InvocationScript: []byte{byte(opcode.PUSH1), byte(opcode.RET)}}, transaction.Witness{InvocationScript: []byte{byte(opcode.RET)}})
+		require.Error(t, err)
+	})
+
+	t.Run("false", func(t *testing.T) {
+		res, err := c.InvokeContractVerify(contract, smartcontract.Params{}, []transaction.Signer{{Account: util.Uint160{}}})
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.Equal(t, 1, len(res.Stack))
+		require.False(t, res.Stack[0].Value().(bool))
+	})
+}
+
+func TestClient_NetworkFeeForWitnesses(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc0 := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	acc1 := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(1))
+	require.NoError(t, acc1.ConvertMultisig(2, keys.PublicKeys{
+		testchain.PrivateKeyByID(0).PublicKey(),
+		testchain.PrivateKeyByID(1).PublicKey(),
+		testchain.PrivateKeyByID(2).PublicKey(),
+	}))
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.ValidUntilBlock = 20
+	tx.Signers = []transaction.Signer{
+		{Account: acc0.Contract.ScriptHash(), Scopes: transaction.CalledByEntry},
+		{Account: acc1.Contract.ScriptHash(), Scopes: transaction.None},
+	}
+	scripts := [][]byte{acc0.Contract.Script, acc1.Contract.Script}
+
+	actual, err := c.NetworkFeeForWitnesses(tx, scripts)
+	require.NoError(t, err)
+
+	tx.Scripts = []transaction.Witness{
+		{VerificationScript: acc0.Contract.Script},
+		{VerificationScript: acc1.Contract.Script},
+	}
+	expected, err := c.CalculateNetworkFee(tx)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	sigFee, sigSize := fee.Calculate(chain.GetBaseExecFee(), acc0.Contract.Script)
+	multiFee, multiSize := fee.Calculate(chain.GetBaseExecFee(), acc1.Contract.Script)
+	tx.Scripts = nil
+	size := io.GetVarSize(tx) + sigSize + multiSize
+	require.Equal(t, sigFee+multiFee+int64(size)*chain.FeePerByte(), actual)
+
+	t.Run("contract", func(t *testing.T) {
+		h, err := util.Uint160DecodeStringLE(verifyContractHash)
+		require.NoError(t, err)
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 10
+		tx.Signers = []transaction.Signer{
+			{Account: acc0.Contract.ScriptHash(), Scopes: transaction.CalledByEntry},
+			{Account: h, Scopes: transaction.Global},
+		}
+		actual, err := c.NetworkFeeForWitnesses(tx, [][]byte{acc0.Contract.Script, nil})
+		require.NoError(t, err)
+		tx.Scripts = []transaction.Witness{{VerificationScript: acc0.Contract.Script}, {}}
+		expected, err := c.CalculateNetworkFee(tx)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		tx.Scripts = nil
+		_, err := c.NetworkFeeForWitnesses(tx, scripts[:1])
+		require.Error(t, err)
+		_, err = c.NetworkFeeForWitnesses(tx, [][]byte{scripts[0], {byte(opcode.PUSH1)}})
+		require.Error(t, err)
+	})
+}
+
+func TestClient_NotaryAssistedNetworkFee(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	newTx := func(attrs ...transaction.Attribute) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = 20
+		tx.Signers = []transaction.Signer{{
+			Account: acc.Contract.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}}
+		tx.Attributes = attrs
+		return tx
+	}
+	for _, nKeys := range []uint8{0, 1, 5} {
+		attr := transaction.Attribute{
+			Type:  transaction.NotaryAssistedT,
+			Value: &transaction.NotaryAssisted{NKeys: nKeys},
+		}
+		plain, assisted := newTx(), newTx(attr)
+		sizeDelta := int64(io.GetVarSize(assisted) - io.GetVarSize(plain))
+		attrFee := (int64(nKeys) + 1) * transaction.NotaryServiceFeePerKey
+		require.Equal(t, int64(0), c.CalculateNotaryAssistedFee(plain))
+		require.Equal(t, attrFee, c.CalculateNotaryAssistedFee(assisted))
+
+		require.NoError(t, c.AddNetworkFee(plain, 0, acc))
+		require.NoError(t, c.AddNetworkFee(assisted, c.CalculateNotaryAssistedFee(assisted), acc))
+		require.Equal(t, attrFee+sizeDelta*chain.FeePerByte(), assisted.NetworkFee-plain.NetworkFee)
+
+		plainFee, err := c.NetworkFeeForWitnesses(newTx(), [][]byte{acc.Contract.Script})
+		require.NoError(t, err)
+		assistedFee, err := c.NetworkFeeForWitnesses(newTx(attr), [][]byte{acc.Contract.Script})
+		require.NoError(t, err)
+		require.Equal(t, sizeDelta*chain.FeePerByte(), assistedFee-plainFee)
+		require.Equal(t, assisted.NetworkFee, assistedFee+attrFee)
+
+		notaryFee, err := c.CalculateNotaryFee(nKeys)
+		require.NoError(t, err)
+		witnessFee, err := c.CalculateNotaryWitnessFee()
+		require.NoError(t, err)
+		require.Equal(t, notaryFee, witnessFee+attrFee)
+	}
+}
+
+func TestClient_GetBlockHeaders(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	hs, err := c.GetBlockHeaders(3, 5)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(hs))
+	for i, h := range hs {
+		require.Equal(t, uint32(3+i), h.Index)
+		require.Equal(t, chain.GetHeaderHash(3+i), h.Hash())
+		if i > 0 {
+			require.Equal(t, hs[i-1].Hash(), h.PrevHash)
+		}
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		height := chain.HeaderHeight()
+		hs, err := c.GetBlockHeaders(height-1, 10)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(hs))
+		require.Equal(t, height, hs[1].Index)
+	})
+}
+
+func TestClient_GetBlockWithLogs(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	var checked bool
+	for i := uint32(1); i <= chain.BlockHeight(); i++ {
+		b, err := chain.GetBlock(chain.GetHeaderHash(int(i)))
+		require.NoError(t, err)
+		if len(b.Transactions) < 2 {
+			continue
+		}
+		res, err := c.GetBlockWithLogsByIndex(i)
+		require.NoError(t, err)
+		require.Equal(t, b.Hash(), res.Block.Hash())
+		require.Equal(t, len(b.Transactions), len(res.Block.Transactions))
+
+		blockLog, err := c.GetApplicationLog(b.Hash(), nil)
+		require.NoError(t, err)
+		require.Equal(t, *blockLog, res.BlockLog)
+
+		require.Equal(t, len(b.Transactions), len(res.TransactionLogs))
+		for j, tx := range b.Transactions {
+			txLog, err := c.GetApplicationLog(tx.Hash(), nil)
+			require.NoError(t, err)
+			require.Equal(t, *txLog, res.TransactionLogs[j])
+		}
+
+		byHash, err := c.GetBlockWithLogsByHash(b.Hash())
+		require.NoError(t, err)
+		require.Equal(t, res, byHash)
+		checked = true
+		break
+	}
+	require.True(t, checked, "no block with several transactions found")
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := c.GetBlockWithLogsByIndex(chain.BlockHeight() + 1)
+		require.Error(t, err)
+	})
+}
+
+func TestClient_VerifyBalance(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := testchain.PrivateKeyByID(0).GetScriptHash()
+	neoBalance, _ := chain.GetGoverningTokenBalance(acc)
+	gasBalance := chain.GetUtilityTokenBalance(acc)
+	require.True(t, neoBalance.Sign() > 0)
+	require.True(t, gasBalance.Sign() > 0)
+
+	t.Run("match", func(t *testing.T) {
+		ok, err := c.VerifyBalance(acc, chain.GoverningTokenHash(), neoBalance)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = c.VerifyBalance(acc, chain.UtilityTokenHash(), gasBalance)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		ok, err := c.VerifyBalance(acc, chain.GoverningTokenHash(), new(big.Int).Add(neoBalance, big.NewInt(1)))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+	t.Run("proof", func(t *testing.T) {
+		h, err := c.GetStateHeight()
+		require.NoError(t, err)
+		require.Equal(t, chain.BlockHeight(), h.BlockHeight)
+		root, err := c.GetStateRootByHeight(h.BlockHeight)
+		require.NoError(t, err)
+		expected, err := chain.GetStateModule().GetStateRoot(h.BlockHeight)
+		require.NoError(t, err)
+		require.Equal(t, expected.Root, root.Root)
+
+		proof, err := c.GetProof(root.Root, chain.GoverningTokenHash(), append([]byte{20}, acc.BytesBE()...))
+		require.NoError(t, err)
+		val, err := c.VerifyProof(root.Root, proof)
+		require.NoError(t, err)
+		require.NotNil(t, val)
+	})
+	t.Run("unknown token", func(t *testing.T) {
+		_, err := c.VerifyBalance(acc, util.Uint160{1, 2, 3}, neoBalance)
+		require.Error(t, err)
+	})
+	t.Run("no balance", func(t *testing.T) {
+		_, err := c.VerifyBalance(util.Uint160{1, 2, 3}, chain.GoverningTokenHash(), big.NewInt(0))
+		require.Error(t, err)
+	})
+}
+
+func TestClient_TraceTransaction(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	h, err := util.Uint256DecodeStringLE(deploymentTxHash)
+	require.NoError(t, err)
+	res, err := c.TraceTransaction(h, 0)
+	require.NoError(t, err)
+
+	appLog, err := c.GetApplicationLog(h, nil)
+	require.NoError(t, err)
+	require.Equal(t, appLog.Executions[0], res.Execution)
+	require.Equal(t, h, res.TxHash)
+	require.NotEmpty(t, res.Trace)
+	require.Equal(t, opcode.RET, res.Trace[len(res.Trace)-1].Opcode)
+}
+
+func TestClient_GetNativeContracts(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	cs, err := c.GetNativeContracts()
+	require.NoError(t, err)
+	require.Equal(t, chain.GetNatives(), cs)
+}
+
+func TestClient_ListContracts(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	css, err := chain.ListContracts()
+	require.NoError(t, err)
+
+	res, err := c.ListContracts(0)
+	require.NoError(t, err)
+	require.Equal(t, len(css), len(res))
+	for i := range res {
+		require.Equal(t, css[i].Hash, res[i].Hash)
+		require.Equal(t, css[i].ID, res[i].ID)
+		require.Equal(t, css[i].Manifest.Name, res[i].Name)
+		require.Equal(t, css[i].UpdateCounter, res[i].UpdateCounter)
+		if i > 0 {
+			require.True(t, res[i-1].ID < res[i].ID)
+		}
+	}
+	var hashes []util.Uint160
+	for i := range res {
+		hashes = append(hashes, res[i].Hash)
+	}
+	for _, s := range []string{testContractHash, verifyContractHash} {
+		h, err := util.Uint160DecodeStringLE(s)
+		require.NoError(t, err)
+		require.Contains(t, hashes, h)
+	}
+
+	res, err = c.ListContracts(1)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res))
+}
+
+func TestClient_NEP11(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	h, err := chain.GetNativeContractScriptHash(nativenames.NameService)
+	require.NoError(t, err)
+	acc := testchain.PrivateKeyByID(0).GetScriptHash()
+
+	t.Run("Decimals", func(t *testing.T) {
+		d, err := c.NEP11Decimals(h)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, d) // non-divisible
+	})
+	t.Run("TotalSupply", func(t *testing.T) {
+		s, err := c.NEP11TotalSupply(h)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, s) // the only `neo.com` of acc0
+	})
+	t.Run("Symbol", func(t *testing.T) {
+		sym, err := c.NEP11Symbol(h)
+		require.NoError(t, err)
+		require.Equal(t, "NNS", sym)
+	})
+	t.Run("TokenInfo", func(t *testing.T) {
+		tok, err := c.NEP11TokenInfo(h)
+		require.NoError(t, err)
+		require.Equal(t, &wallet.Token{
+			Name:     nativenames.NameService,
+			Hash:     h,
+			Decimals: 0,
+			Symbol:   "NNS",
+			Standard: manifest.NEP11StandardName,
 		}, tok)
 	})
 	t.Run("BalanceOf", func(t *testing.T) {
@@ -874,3 +1814,381 @@ func TestClient_NNS(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestClient_GetUnclaimedGasBatch(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	addrs := []string{
+		address.Uint160ToString(testchain.MultisigScriptHash()),
+		testchain.PrivateKeyByID(0).Address(),
+		address.Uint160ToString(util.Uint160{1, 2, 3}),
+	}
+	check := func(t *testing.T, c *client.Client) {
+		res, err := c.GetUnclaimedGasBatch(addrs)
+		require.NoError(t, err)
+		require.Equal(t, len(addrs), len(res))
+		for _, addr := range addrs {
+			expected, err := c.GetUnclaimedGas(addr)
+			require.NoError(t, err)
+			require.Equal(t, 0, expected.Unclaimed.Cmp(res[addr]), addr)
+		}
+		require.Equal(t, 1, res[addrs[0]].Sign())
+		require.Equal(t, 0, res[addrs[2]].Sign())
+
+		_, err = c.GetUnclaimedGasBatch([]string{addrs[0], "bad address"})
+		require.Error(t, err)
+	}
+
+	t.Run("HTTP", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+		check(t, c)
+	})
+	t.Run("WS", func(t *testing.T) {
+		url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+		wsc, err := client.NewWS(context.Background(), url, client.Options{})
+		require.NoError(t, err)
+		defer wsc.Close()
+		require.NoError(t, wsc.Init())
+		check(t, &wsc.Client)
+	})
+}
+
+func TestClient_Batch(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	b1, err := chain.GetBlock(chain.GetHeaderHash(1))
+	require.NoError(t, err)
+	require.True(t, len(b1.Transactions) > 0)
+	txHash := b1.Transactions[0].Hash()
+
+	check := func(t *testing.T, c *client.Client) {
+		batch := c.Batch().
+			GetBlockByIndex(1).
+			GetRawTransaction(txHash).
+			GetRawTransaction(util.Uint256{1, 2, 3}).
+			GetBlockHash(1).
+			GetApplicationLog(txHash, nil).
+			GetRawTransactionVerbose(txHash).
+			GetBlockByIndexVerbose(chain.BlockHeight() + 100)
+		require.Equal(t, 7, batch.Len())
+		res, err := batch.Execute(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 7, len(res))
+
+		require.NoError(t, res[0].Err)
+		require.Equal(t, b1.Hash(), res[0].Value.(*block.Block).Hash())
+
+		require.NoError(t, res[1].Err)
+		require.Equal(t, txHash, res[1].Value.(*transaction.Transaction).Hash())
+
+		require.Error(t, res[2].Err)
+		require.Nil(t, res[2].Value)
+
+		require.NoError(t, res[3].Err)
+		require.Equal(t, b1.Hash(), res[3].Value.(util.Uint256))
+
+		require.NoError(t, res[4].Err)
+		require.Equal(t, txHash, res[4].Value.(*result.ApplicationLog).Container)
+
+		require.NoError(t, res[5].Err)
+		require.Equal(t, txHash, res[5].Value.(*result.TransactionOutputRaw).Hash())
+
+		var rpcErr *response.Error
+		require.True(t, errors.As(res[6].Err, &rpcErr), res[6].Err)
+		require.NotEqual(t, int64(0), rpcErr.Code)
+		require.Nil(t, res[6].Value)
+
+		res, err = c.Batch().Execute(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, len(res))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = c.Batch().GetBlockHash(1).Execute(ctx)
+		require.True(t, errors.Is(err, context.Canceled), err)
+	}
+
+	t.Run("HTTP", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+		check(t, c)
+
+		// More calls than fit into a single batch request.
+		batch := c.Batch()
+		for i := 0; i < 150; i++ {
+			batch.GetBlockHash(uint32(i % int(chain.BlockHeight()+1)))
+		}
+		res, err := batch.Execute(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 150, len(res))
+		for i := range res {
+			require.NoError(t, res[i].Err)
+			require.Equal(t, chain.GetHeaderHash(i%int(chain.BlockHeight()+1)), res[i].Value.(util.Uint256))
+		}
+	})
+	t.Run("HTTP, cancelled in progress", func(t *testing.T) {
+		done := make(chan struct{})
+		slowSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		}))
+		defer slowSrv.Close()
+		defer close(done)
+
+		c, err := client.New(context.Background(), slowSrv.URL, client.Options{})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err = c.Batch().GetBlockHash(1).Execute(ctx)
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+		require.True(t, time.Since(start) < time.Second)
+	})
+	t.Run("WS", func(t *testing.T) {
+		url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+		wsc, err := client.NewWS(context.Background(), url, client.Options{})
+		require.NoError(t, err)
+		defer wsc.Close()
+		require.NoError(t, wsc.Init())
+		check(t, &wsc.Client)
+	})
+}
+
+func TestClient_InvokeGasBreakdown(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{GasBreakdown: true})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	check := func(t *testing.T, res *result.Invoke) {
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		gb := res.GasBreakdown
+		require.NotNil(t, gb)
+		require.Equal(t, res.GasConsumed, gb.Opcodes+gb.Storage+gb.Syscalls)
+	}
+
+	t.Run("storage", func(t *testing.T) {
+		res, err := c.InvokeFunction(h, "putValue", []smartcontract.Parameter{
+			{Type: smartcontract.ByteArrayType, Value: []byte("gb")},
+			{Type: smartcontract.ByteArrayType, Value: []byte("value")},
+		}, nil)
+		require.NoError(t, err)
+		check(t, res)
+		require.True(t, res.GasBreakdown.Opcodes > 0)
+		require.True(t, res.GasBreakdown.Storage > 0)
+		require.True(t, res.GasBreakdown.Syscalls > 0) // System.Contract.Call
+	})
+	t.Run("native", func(t *testing.T) {
+		acc := testchain.PrivateKeyByID(0).GetScriptHash()
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, chain.UtilityTokenHash(), "transfer", callflag.All, acc, util.Uint160{1, 2, 3}, int64(1), nil)
+		require.NoError(t, w.Err)
+		res, err := c.InvokeScript(w.Bytes(), []transaction.Signer{{Account: acc, Scopes: transaction.CalledByEntry}})
+		require.NoError(t, err)
+		check(t, res)
+		require.True(t, res.GasBreakdown.Opcodes > 0)
+		require.Equal(t, int64(0), res.GasBreakdown.Storage)
+		require.True(t, res.GasBreakdown.Syscalls > 0)
+	})
+	t.Run("not requested", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+		res, err := c.InvokeFunction(h, "symbol", []smartcontract.Parameter{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.Nil(t, res.GasBreakdown)
+	})
+}
+
+func TestClient_InvokeWithWitnesses(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	gasContractHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+
+	// GAS transfer checks sender's witness, estimated system fee matches
+	// the real one.
+	tx, err := c.CreateNEP17TransferTx(acc, util.Uint160{1, 2, 3}, gasContractHash, 1000, 0, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, acc.SignTx(testchain.Network(), tx))
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+
+	aers, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	require.Equal(t, vm.HaltState, aers[0].VMState)
+	require.Equal(t, tx.SystemFee, aers[0].GasConsumed)
+
+	// Explicitly passed witnesses are accepted by the server.
+	signers := []transaction.Signer{{Account: acc.Contract.ScriptHash(), Scopes: transaction.CalledByEntry}}
+	res, err := c.InvokeScript(tx.Script, signers, transaction.Witness{VerificationScript: acc.Contract.Script})
+	require.NoError(t, err)
+	require.Equal(t, "HALT", res.State, res.FaultException)
+
+	_, err = c.InvokeScript(tx.Script, signers, transaction.Witness{}, transaction.Witness{})
+	require.Error(t, err)
+}
+
+func TestWSClient_Restore(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+	newWSClient := func(t *testing.T) *client.WSClient {
+		wsc, err := client.NewWS(context.Background(), url, client.Options{})
+		require.NoError(t, err)
+		require.NoError(t, wsc.Init())
+		go func() {
+			for range wsc.Notifications {
+			}
+		}()
+		return wsc
+	}
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+
+	blocks := getTestBlocks(t)
+	const disconnectAt = 5
+	require.True(t, len(blocks) > disconnectAt)
+
+	wsc := newWSClient(t)
+	_, err = wsc.SubscribeForNewBlocks(nil)
+	require.NoError(t, err)
+	_, err = wsc.SubscribeForExecutionNotifications(&h, nil)
+	require.NoError(t, err)
+	for _, b := range blocks[:disconnectAt] {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	require.Eventually(t, func() bool {
+		for _, cp := range wsc.Checkpoints() {
+			if cp.Height != disconnectAt {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+	wsc.Close()
+	checkpoints := wsc.Checkpoints()
+	require.Equal(t, 2, len(checkpoints))
+	// Wait for the server to drop disconnected subscriber.
+	require.Eventually(t, func() bool {
+		rpcSrv.subsLock.RLock()
+		defer rpcSrv.subsLock.RUnlock()
+		return len(rpcSrv.subscribers) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Events for these blocks are missed by the subscriber.
+	var expectedNotifications int
+	for _, b := range blocks[disconnectAt:] {
+		require.NoError(t, chain.AddBlock(b))
+		aers, err := chain.GetAppExecResults(b.Hash(), trigger.All)
+		require.NoError(t, err)
+		for _, tx := range b.Transactions {
+			txAERs, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+			require.NoError(t, err)
+			if txAERs[0].VMState == vm.HaltState {
+				aers = append(aers, txAERs...)
+			}
+		}
+		for _, aer := range aers {
+			for _, ev := range aer.Events {
+				if ev.ScriptHash.Equals(h) {
+					expectedNotifications++
+				}
+			}
+		}
+	}
+	require.NotEqual(t, 0, expectedNotifications)
+
+	t.Run("too many blocks", func(t *testing.T) {
+		wsc := newWSClient(t)
+		defer wsc.Close()
+		_, err := wsc.Restore(checkpoints, uint32(len(blocks)-disconnectAt-1))
+		require.Error(t, err)
+	})
+	t.Run("good", func(t *testing.T) {
+		wsc := newWSClient(t)
+		defer wsc.Close()
+		evs, err := wsc.Restore(checkpoints, uint32(len(blocks)))
+		require.NoError(t, err)
+		var (
+			notifications int
+			nextBlock     = uint32(disconnectAt + 1)
+		)
+		for _, ev := range evs {
+			switch ev.Type {
+			case response.BlockEventID:
+				require.Equal(t, nextBlock, ev.Value.(*block.Block).Index)
+				nextBlock++
+			case response.NotificationEventID:
+				require.Equal(t, h, ev.Value.(*state.NotificationEvent).ScriptHash)
+				notifications++
+			default:
+				t.Fatalf("unexpected event: %s", ev.Type)
+			}
+		}
+		require.Equal(t, uint32(len(blocks)+1), nextBlock)
+		require.Equal(t, expectedNotifications, notifications)
+		require.Equal(t, evs[len(evs)-1].Type, response.BlockEventID)
+		for _, cp := range wsc.Checkpoints() {
+			require.True(t, cp.Height >= disconnectAt)
+		}
+	})
+}
+
+func TestClient_GetRawMemPoolBySender(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	mp := chain.GetMemPool()
+	senderA := util.Uint160{1, 2, 3}
+	senderB := util.Uint160{4, 5, 6}
+	expected := make([]util.Uint256, 0)
+	for i := 0; i < 6; i++ {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		if i%3 == 0 {
+			tx.Signers = []transaction.Signer{{Account: senderA}}
+			expected = append(expected, tx.Hash())
+		} else {
+			tx.Signers = []transaction.Signer{{Account: senderB}}
+		}
+		require.NoError(t, mp.Add(tx, &FeerStub{}))
+	}
+
+	actual, err := c.GetRawMemPoolBySender(senderA)
+	require.NoError(t, err)
+	require.ElementsMatch(t, expected, actual)
+
+	all, err := c.GetRawMemPool()
+	require.NoError(t, err)
+	require.Equal(t, 6, len(all))
+
+	actual, err = c.GetRawMemPoolBySender(util.Uint160{7, 8, 9})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(actual))
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// storageSyscalls contains IDs of syscalls accounted as storage operations.
+var storageSyscalls = map[uint32]bool{}
+
+func init() {
+	for _, name := range []string{
+		interopnames.SystemStorageAsReadOnly,
+		interopnames.SystemStorageDelete,
+		interopnames.SystemStorageFind,
+		interopnames.SystemStorageGet,
+		interopnames.SystemStorageGetContext,
+		interopnames.SystemStorageGetReadOnlyContext,
+		interopnames.SystemStoragePut,
+	} {
+		storageSyscalls[interopnames.ToID([]byte(name))] = true
+	}
+}
+
+// trackGas wraps price getter and syscall handler of the given VM to account
+// GAS spent by different categories of instructions in the returned
+// GasBreakdown. Syscalls can execute nested instructions (like when native
+// contracts call other contracts), these are accounted by their own category.
+func trackGas(v *vm.VM) *result.GasBreakdown {
+	var (
+		gb       = new(result.GasBreakdown)
+		getPrice = v.GetPriceGetter()
+		handler  = v.SyscallHandler
+		total    = func() int64 { return gb.Opcodes + gb.Storage + gb.Syscalls }
+	)
+	v.SetPriceGetter(func(op opcode.Opcode, param []byte) int64 {
+		var price int64
+		if getPrice != nil {
+			price = getPrice(op, param)
+		}
+		gb.Opcodes += price
+		return price
+	})
+	v.SyscallHandler = func(v *vm.VM, id uint32) error {
+		gasBefore, accountedBefore := v.GasConsumed(), total()
+		err := handler(v, id)
+		spent := v.GasConsumed() - gasBefore - (total() - accountedBefore)
+		if storageSyscalls[id] {
+			gb.Storage += spent
+		} else {
+			gb.Syscalls += spent
+		}
+		return err
+	}
+	return gb
+}
@@ -18,14 +18,21 @@ func incCounter(name string) {
 
 func init() {
 	for call := range rpcHandlers {
-		ctr := prometheus.NewCounter(
-			prometheus.CounterOpts{
-				Help:      fmt.Sprintf("Number of calls to %s rpc endpoint", call),
-				Name:      fmt.Sprintf("%s_called", call),
-				Namespace: "neogo",
-			},
-		)
-		prometheus.MustRegister(ctr)
-		rpcCounter[call] = ctr
+		regCounter(call)
 	}
+	for call := range rpcContextHandlers {
+		regCounter(call)
+	}
+}
+
+func regCounter(call string) {
+	ctr := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      fmt.Sprintf("Number of calls to %s rpc endpoint", call),
+			Name:      fmt.Sprintf("%s_called", call),
+			Namespace: "neogo",
+		},
+	)
+	prometheus.MustRegister(ctr)
+	rpcCounter[call] = ctr
 }
@@ -11,7 +11,9 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -36,6 +39,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -56,6 +60,9 @@ type (
 		log              *zap.Logger
 		https            *http.Server
 		shutdown         chan struct{}
+		// invokeSem limits the number of concurrent script invocations,
+		// it's nil if there is no limit.
+		invokeSem chan struct{}
 
 		subsLock         sync.RWMutex
 		subscribers      map[*subscriber]bool
@@ -90,22 +97,59 @@ const (
 
 	// Maximum number of elements for get*transfers requests.
 	maxTransfersLimit = 1000
+
+	// Maximum number of headers returned by getblockheaders.
+	maxBlockHeadersCount = 2000
+
+	// Maximum number of hashes accepted by getapplicationlogs.
+	maxApplicationLogsCount = 100
+
+	// Maximum (and default) number of contracts returned by listcontracts.
+	maxListContractsLimit = 100
+
+	// Default and maximum number of recent blocks for getfeehistogram
+	// requests.
+	defaultFeeHistogramBlocks = 10
+	maxFeeHistogramBlocks     = 100
+
+	// Default and maximum timeouts (in seconds) for sendandwait requests.
+	defaultSendAndWaitTimeout = 60
+	maxSendAndWaitTimeout     = 300
+
+	// Interval between persisted transaction checks for sendandwait requests.
+	sendAndWaitPollInterval = 100 * time.Millisecond
+
+	// Time to wait for the forced block to be persisted.
+	forceBlockTimeout = 10 * time.Second
+
+	// Default and maximum number of instructions returned by tracetransaction.
+	defaultTraceSteps = 1000
+	maxTraceSteps     = 10000
+
+	// HTTP methods allowed for cross-origin requests by default.
+	defaultCORSMethods = "GET, POST, OPTIONS"
 )
 
 var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *response.Error){
-	"calculatenetworkfee":    (*Server).calculateNetworkFee,
+	"forceblock":             (*Server).forceBlock,
 	"getapplicationlog":      (*Server).getApplicationLog,
+	"getapplicationlogs":     (*Server).getApplicationLogs,
 	"getbestblockhash":       (*Server).getBestBlockHash,
 	"getblock":               (*Server).getBlock,
 	"getblockcount":          (*Server).getBlockCount,
 	"getblockhash":           (*Server).getBlockHash,
 	"getblockheader":         (*Server).getBlockHeader,
 	"getblockheadercount":    (*Server).getBlockHeaderCount,
+	"getblockheaders":        (*Server).getBlockHeaders,
 	"getblocksysfee":         (*Server).getBlockSysFee,
+	"getblockwithlogs":       (*Server).getBlockWithLogs,
 	"getcommittee":           (*Server).getCommittee,
 	"getconnectioncount":     (*Server).getConnectionCount,
+	"getconsensusstate":      (*Server).getConsensusState,
 	"getcontractstate":       (*Server).getContractState,
+	"getfeehistogram":        (*Server).getFeeHistogram,
 	"getnativecontracts":     (*Server).getNativeContracts,
+	"getnep17balance":        (*Server).getNEP17Balance,
 	"getnep17balances":       (*Server).getNEP17Balances,
 	"getnep17transfers":      (*Server).getNEP17Transfers,
 	"getpeers":               (*Server).getPeers,
@@ -119,17 +163,28 @@ var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *respon
 	"getunclaimedgas":        (*Server).getUnclaimedGas,
 	"getnextblockvalidators": (*Server).getNextBlockValidators,
 	"getversion":             (*Server).getVersion,
-	"invokefunction":         (*Server).invokeFunction,
-	"invokescript":           (*Server).invokescript,
-	"invokecontractverify":   (*Server).invokeContractVerify,
+	"listcontracts":          (*Server).listContracts,
+	"sendandwait":            (*Server).sendAndWait,
 	"sendrawtransaction":     (*Server).sendrawtransaction,
 	"submitblock":            (*Server).submitBlock,
+	"submitconsensus":        (*Server).submitConsensus,
 	"submitnotaryrequest":    (*Server).submitNotaryRequest,
 	"submitoracleresponse":   (*Server).submitOracleResponse,
 	"validateaddress":        (*Server).validateAddress,
 	"verifyproof":            (*Server).verifyProof,
 }
 
+// rpcContextHandlers are handlers that run scripts and can be cancelled via
+// context, RequestTimeout is applied to them.
+var rpcContextHandlers = map[string]func(*Server, context.Context, request.Params) (interface{}, *response.Error){
+	"calculatenetworkfee":  (*Server).calculateNetworkFee,
+	"invokecontractverify": (*Server).invokeContractVerify,
+	"invokefunction":       (*Server).invokeFunction,
+	"invokescript":         (*Server).invokescript,
+	"tracetransaction":     (*Server).traceTransaction,
+	"verifytransaction":    (*Server).verifyTransaction,
+}
+
 var rpcWsHandlers = map[string]func(*Server, request.Params, *subscriber) (interface{}, *response.Error){
 	"subscribe":   (*Server).subscribe,
 	"unsubscribe": (*Server).unsubscribe,
@@ -160,6 +215,10 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 	if orc != nil {
 		orc.SetBroadcaster(broadcaster.New(orc.MainCfg, log))
 	}
+	var invokeSem chan struct{}
+	if conf.MaxConcurrentInvocations > 0 {
+		invokeSem = make(chan struct{}, conf.MaxConcurrentInvocations)
+	}
 	return Server{
 		Server:           httpServer,
 		chain:            chain,
@@ -171,6 +230,7 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		oracle:           orc,
 		https:            tlsServer,
 		shutdown:         make(chan struct{}),
+		invokeSem:        invokeSem,
 
 		subscribers: make(map[*subscriber]bool),
 		// These are NOT buffered to preserve original order of events.
@@ -253,6 +313,11 @@ func (s *Server) Shutdown() error {
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	req := request.NewRequest()
 
+	if s.setCORSHeaders(w, httpRequest) && httpRequest.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
 		// s.subscribers modification 20 lines below, but it's tiny
@@ -335,10 +400,25 @@ func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
 
 	incCounter(req.Method)
 
+	if s.isMethodDisabled(req.Method) {
+		return s.packResponse(req, nil, response.NewMethodDisabledError(fmt.Sprintf("Method '%s' is disabled", req.Method)))
+	}
 	resErr = response.NewMethodNotFoundError(fmt.Sprintf("Method '%s' not supported", req.Method), nil)
 	handler, ok := rpcHandlers[req.Method]
 	if ok {
 		res, resErr = handler(s, *reqParams)
+	} else if handler, ok := rpcContextHandlers[req.Method]; ok {
+		if resErr := s.acquireInvocationSlot(); resErr != nil {
+			return s.packResponse(req, nil, resErr)
+		}
+		defer s.releaseInvocationSlot()
+		ctx := context.Background()
+		if s.config.RequestTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.config.RequestTimeout)
+			defer cancel()
+		}
+		res, resErr = handler(s, ctx, *reqParams)
 	} else if sub != nil {
 		handler, ok := rpcWsHandlers[req.Method]
 		if ok {
@@ -348,6 +428,47 @@ func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
 	return s.packResponse(req, res, resErr)
 }
 
+// acquireInvocationSlot waits for the script invocation to be allowed by
+// MaxConcurrentInvocations limit or returns an error if it's to be rejected.
+// Waiting time doesn't count towards RequestTimeout.
+func (s *Server) acquireInvocationSlot() *response.Error {
+	if s.invokeSem == nil {
+		return nil
+	}
+	select {
+	case s.invokeSem <- struct{}{}:
+		return nil
+	default:
+	}
+	if s.config.RejectExcessInvocations {
+		return response.NewServerBusyError("too many concurrent invocations")
+	}
+	select {
+	case s.invokeSem <- struct{}{}:
+		return nil
+	case <-s.shutdown:
+		return response.NewInternalServerError("server is shutting down", nil)
+	}
+}
+
+// releaseInvocationSlot frees the slot taken by acquireInvocationSlot.
+func (s *Server) releaseInvocationSlot() {
+	if s.invokeSem != nil {
+		<-s.invokeSem
+	}
+}
+
+// isMethodDisabled checks whether the given method is disabled in the server
+// configuration.
+func (s *Server) isMethodDisabled(method string) bool {
+	for _, m := range s.config.DisabledMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan response.AbstractResult, subChan <-chan *websocket.PreparedMessage) {
 	pingTicker := time.NewTicker(wsPingPeriod)
 eventloop:
@@ -485,6 +606,9 @@ func (s *Server) getBlock(reqParams request.Params) (interface{}, *response.Erro
 
 	block, err := s.chain.GetBlock(hash)
 	if err != nil {
+		if errors.Is(err, core.ErrBlockPruned) {
+			return nil, response.NewRPCError("Block is pruned", fmt.Sprintf("block %s data is not available", hash.StringLE()), err)
+		}
 		return nil, response.NewInternalServerError(fmt.Sprintf("Problem locating block with hash: %s", hash), err)
 	}
 
@@ -496,6 +620,39 @@ func (s *Server) getBlock(reqParams request.Params) (interface{}, *response.Erro
 	return writer.Bytes(), nil
 }
 
+// getBlockWithLogs returns verbose block specified by its hash or index along
+// with application logs of all of its transactions and the block itself.
+func (s *Server) getBlockWithLogs(reqParams request.Params) (interface{}, *response.Error) {
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	block, err := s.chain.GetBlock(hash)
+	if err != nil {
+		if errors.Is(err, core.ErrBlockPruned) {
+			return nil, response.NewRPCError("Block is pruned", fmt.Sprintf("block %s data is not available", hash.StringLE()), err)
+		}
+		return nil, response.NewInternalServerError(fmt.Sprintf("Problem locating block with hash: %s", hash), err)
+	}
+	aers, err := s.chain.GetAppExecResults(hash, trigger.All)
+	if err != nil {
+		return nil, response.NewInternalServerError(fmt.Sprintf("failed to get block %s application log", hash.StringLE()), err)
+	}
+	res := result.BlockWithLogs{
+		Block:           result.NewBlock(block, s.chain),
+		BlockLog:        result.NewApplicationLog(hash, aers, trigger.All),
+		TransactionLogs: make([]result.ApplicationLog, len(block.Transactions)),
+	}
+	for i, tx := range block.Transactions {
+		aers, err := s.chain.GetAppExecResults(tx.Hash(), trigger.All)
+		if err != nil {
+			return nil, response.NewInternalServerError(fmt.Sprintf("failed to get transaction %s application log", tx.Hash().StringLE()), err)
+		}
+		res.TransactionLogs[i] = result.NewApplicationLog(tx.Hash(), aers, trigger.All)
+	}
+	return res, nil
+}
+
 func (s *Server) getBlockHash(reqParams request.Params) (interface{}, *response.Error) {
 	param := reqParams.ValueWithType(0, request.NumberT)
 	if param == nil {
@@ -520,6 +677,7 @@ func (s *Server) getVersion(_ request.Params) (interface{}, *response.Error) {
 		Nonce:             s.coreServer.ID(),
 		UserAgent:         s.coreServer.UserAgent,
 		StateRootInHeader: s.chain.GetConfig().StateRootInHeader,
+		AttributeFeeBase:  s.chain.GetConfig().AttributeFeeBase,
 	}, nil
 }
 
@@ -531,11 +689,28 @@ func (s *Server) getPeers(_ request.Params) (interface{}, *response.Error) {
 	return peers, nil
 }
 
+// getRawMempool returns hashes of verified mempooled transactions, optionally
+// filtered by sender.
 func (s *Server) getRawMempool(reqParams request.Params) (interface{}, *response.Error) {
 	verbose := reqParams.Value(0).GetBoolean()
+	var (
+		filter bool
+		sender util.Uint160
+	)
+	if len(reqParams) > 1 {
+		var err error
+		sender, err = reqParams[1].GetUint160FromAddressOrHex()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+		}
+		filter = true
+	}
 	mp := s.chain.GetMemPool()
 	hashList := make([]util.Uint256, 0)
 	for _, item := range mp.GetVerifiedTransactions() {
+		if filter && !item.Sender().Equals(sender) {
+			continue
+		}
 		hashList = append(hashList, item.Hash())
 	}
 	if !verbose {
@@ -547,6 +722,53 @@ func (s *Server) getRawMempool(reqParams request.Params) (interface{}, *response
 	}, nil
 }
 
+// getFeeHistogram returns fee per byte distribution of mempooled transactions
+// along with fee statistics of the specified number of recent blocks.
+func (s *Server) getFeeHistogram(reqParams request.Params) (interface{}, *response.Error) {
+	var numBlocks = defaultFeeHistogramBlocks
+	if len(reqParams) > 0 {
+		n, err := reqParams[0].GetInt()
+		if err != nil || n <= 0 || n > maxFeeHistogramBlocks {
+			return nil, response.ErrInvalidParams
+		}
+		numBlocks = n
+	}
+	maxTx := int(s.chain.GetConfig().MaxTransactionsPerBlock)
+	if maxTx == 0 {
+		maxTx = block.MaxTransactionsPerBlock
+	}
+	res := result.FeeHistogram{
+		Height:                  s.chain.BlockHeight(),
+		MaxTransactionsPerBlock: maxTx,
+		Mempool:                 []result.FeeBucket{},
+		Blocks:                  []result.BlockFees{},
+	}
+	counts := make(map[int64]int)
+	for _, tx := range s.chain.GetMemPool().GetVerifiedTransactions() {
+		counts[tx.FeePerByte()]++
+	}
+	for fpb, n := range counts {
+		res.Mempool = append(res.Mempool, result.FeeBucket{FeePerByte: fpb, Count: n})
+	}
+	sort.Slice(res.Mempool, func(i, j int) bool {
+		return res.Mempool[i].FeePerByte > res.Mempool[j].FeePerByte
+	})
+	for i := 0; i < numBlocks && uint32(i) <= res.Height; i++ {
+		b, err := s.chain.GetBlock(s.chain.GetHeaderHash(int(res.Height) - i))
+		if err != nil {
+			return nil, response.NewInternalServerError(fmt.Sprintf("failed to get block %d", int(res.Height)-i), err)
+		}
+		bf := result.BlockFees{Index: b.Index, TxCount: len(b.Transactions)}
+		for j, tx := range b.Transactions {
+			if fpb := tx.FeePerByte(); j == 0 || fpb < bf.MinFeePerByte {
+				bf.MinFeePerByte = fpb
+			}
+		}
+		res.Blocks = append(res.Blocks, bf)
+	}
+	return res, nil
+}
+
 func (s *Server) validateAddress(reqParams request.Params) (interface{}, *response.Error) {
 	param := reqParams.Value(0)
 	if param == nil {
@@ -556,7 +778,7 @@ func (s *Server) validateAddress(reqParams request.Params) (interface{}, *respon
 }
 
 // calculateNetworkFee calculates network fee for the transaction.
-func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *response.Error) {
+func (s *Server) calculateNetworkFee(ctx context.Context, reqParams request.Params) (interface{}, *response.Error) {
 	if len(reqParams) < 1 {
 		return 0, response.ErrInvalidParams
 	}
@@ -580,7 +802,7 @@ func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *re
 	for i, signer := range tx.Signers {
 		var verificationScript []byte
 		for _, w := range tx.Scripts {
-			if w.VerificationScript != nil && hash.Hash160(w.VerificationScript).Equals(signer.Account) {
+			if w.VerificationScript != nil && smartcontract.ScriptHash(w.VerificationScript).Equals(signer.Account) {
 				// then it's a standard sig/multisig witness
 				verificationScript = w.VerificationScript
 				break
@@ -588,7 +810,7 @@ func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *re
 		}
 		if verificationScript == nil { // then it still might be a contract-based verification
 			verificationErr := fmt.Sprintf("contract verification for signer #%d failed", i)
-			res, respErr := s.runScriptInVM(trigger.Verification, tx.Scripts[i].InvocationScript, signer.Account, tx)
+			res, respErr := s.runScriptInVM(ctx, trigger.Verification, tx.Scripts[i].InvocationScript, signer.Account, tx, false)
 			if respErr != nil && errors.Is(respErr.Cause, core.ErrUnknownVerificationContract) {
 				// it's neither a contract-based verification script nor a standard witness attached to
 				// the tx, so the user did not provide enough data to calculate fee for that witness =>
@@ -628,6 +850,7 @@ func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *re
 		netFee += fee
 		size += sizeDelta
 	}
+	netFee += transaction.AttributesFee(s.chain.GetConfig().AttributeFeeBase, tx.Attributes)
 	fee := s.chain.GetPolicer().FeePerByte()
 	netFee += int64(size) * fee
 	return netFee, nil
@@ -640,16 +863,9 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 		return nil, response.ErrInvalidParams
 	}
 
-	trig := trigger.All
-	if len(reqParams) > 1 {
-		trigString := reqParams.ValueWithType(1, request.StringT)
-		if trigString == nil {
-			return nil, response.ErrInvalidParams
-		}
-		trig, err = trigger.FromString(trigString.String())
-		if err != nil {
-			return nil, response.ErrInvalidParams
-		}
+	trig, respErr := getTriggerParam(reqParams, 1)
+	if respErr != nil {
+		return nil, respErr
 	}
 
 	appExecResults, err := s.chain.GetAppExecResults(hash, trigger.All)
@@ -659,6 +875,57 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 	return result.NewApplicationLog(hash, appExecResults, trig), nil
 }
 
+// getApplicationLogs returns application logs for an array of hashes, nulls
+// are returned for unknown ones.
+func (s *Server) getApplicationLogs(reqParams request.Params) (interface{}, *response.Error) {
+	hashes, err := reqParams.Value(0).GetArray()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if len(hashes) > maxApplicationLogsCount {
+		return nil, response.NewInvalidParamsError(fmt.Sprintf("too many hashes: %d > %d", len(hashes), maxApplicationLogsCount), nil)
+	}
+	trig, respErr := getTriggerParam(reqParams, 1)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	res := make([]*result.ApplicationLog, len(hashes))
+	for i := range hashes {
+		hash, err := hashes[i].GetUint256()
+		if err != nil {
+			return nil, response.WrapErrorWithData(response.ErrInvalidParams, fmt.Errorf("hash #%d: %w", i, err))
+		}
+		appExecResults, err := s.chain.GetAppExecResults(hash, trigger.All)
+		if err != nil {
+			if errors.Is(err, storage.ErrKeyNotFound) {
+				continue
+			}
+			return nil, response.NewInternalServerError(fmt.Sprintf("failed to get application log for %s", hash.StringLE()), err)
+		}
+		log := result.NewApplicationLog(hash, appExecResults, trig)
+		res[i] = &log
+	}
+	return res, nil
+}
+
+// getTriggerParam returns optional trigger parameter with the given index,
+// trigger.All is returned if there is no such parameter.
+func getTriggerParam(reqParams request.Params, index int) (trigger.Type, *response.Error) {
+	if len(reqParams) <= index {
+		return trigger.All, nil
+	}
+	trigString := reqParams.ValueWithType(index, request.StringT)
+	if trigString == nil {
+		return 0, response.ErrInvalidParams
+	}
+	trig, err := trigger.FromString(trigString.String())
+	if err != nil {
+		return 0, response.ErrInvalidParams
+	}
+	return trig, nil
+}
+
 func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Error) {
 	u, err := ps.Value(0).GetUint160FromAddressOrHex()
 	if err != nil {
@@ -687,6 +954,35 @@ func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Err
 	return bs, nil
 }
 
+// getNEP17Balance returns the balance of the specified token for the specified
+// account. It uses tracked NEP17 balances (the same ones used for
+// getnep17balances), so no VM invocation is performed.
+func (s *Server) getNEP17Balance(ps request.Params) (interface{}, *response.Error) {
+	h, respErr := s.contractScriptHashFromParam(ps.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	u, err := ps.Value(1).GetUint160FromAddressOrHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	cs := s.chain.GetContractState(h)
+	if cs == nil {
+		return nil, response.NewRPCError("Unknown contract", "", nil)
+	}
+	res := &result.NEP17Balance{
+		Asset:  h,
+		Amount: "0",
+	}
+	if as := s.chain.GetNEP17Balances(u); as != nil {
+		if bal, ok := as.Trackers[cs.ID]; ok {
+			res.Amount = bal.Balance.String()
+			res.LastUpdated = bal.LastUpdatedBlock
+		}
+	}
+	return res, nil
+}
+
 func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, int, error) {
 	var start, end uint64
 	var limit, page int
@@ -1029,6 +1325,9 @@ func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *resp
 		return nil, response.NewRPCError("Unknown transaction", err.Error(), err)
 	}
 	if reqParams.Value(1).GetBoolean() {
+		if reqParams.Value(2).GetBoolean() {
+			return tx, nil
+		}
 		if height == math.MaxUint32 {
 			return result.NewTransactionOutputRaw(tx, nil, nil, s.chain), nil
 		}
@@ -1081,6 +1380,88 @@ func (s *Server) getNativeContracts(_ request.Params) (interface{}, *response.Er
 	return s.chain.GetNatives(), nil
 }
 
+// listContracts returns a page of deployed (non-native) contracts sorted by ID.
+// It accepts optional page number and page size.
+func (s *Server) listContracts(ps request.Params) (interface{}, *response.Error) {
+	var page, limit = 0, maxListContractsLimit
+	if p := ps.Value(0); p != nil {
+		n, err := p.GetInt()
+		if err != nil || n < 0 {
+			return nil, response.ErrInvalidParams
+		}
+		page = n
+	}
+	if p := ps.Value(1); p != nil {
+		n, err := p.GetInt()
+		if err != nil || n <= 0 || n > maxListContractsLimit {
+			return nil, response.ErrInvalidParams
+		}
+		limit = n
+	}
+	css, err := s.chain.ListContracts()
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to list contracts", err)
+	}
+	res := []result.ContractInfo{}
+	// Check the page first to avoid overflows in the offset calculation.
+	if page > len(css)/limit {
+		return res, nil
+	}
+	start, end := page*limit, len(css)
+	if end-start > limit {
+		end = start + limit
+	}
+	for i := start; i < end; i++ {
+		res = append(res, result.ContractInfo{
+			Hash:          css[i].Hash,
+			ID:            css[i].ID,
+			Name:          css[i].Manifest.Name,
+			UpdateCounter: css[i].UpdateCounter,
+		})
+	}
+	return res, nil
+}
+
+// traceTransaction re-executes transaction against the state it was executed
+// with and returns its execution result along with executed instructions.
+// It's disabled by default as it can be expensive.
+func (s *Server) traceTransaction(ctx context.Context, ps request.Params) (interface{}, *response.Error) {
+	if !s.config.EnableTraceTransaction {
+		return nil, response.NewInternalServerError("tracetransaction is disabled", nil)
+	}
+	txHash, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var steps = defaultTraceSteps
+	if p := ps.Value(1); p != nil {
+		n, err := p.GetInt()
+		if err != nil || n <= 0 || n > maxTraceSteps {
+			return nil, response.ErrInvalidParams
+		}
+		steps = n
+	}
+	if _, _, err := s.chain.GetTransaction(txHash); err != nil {
+		err = fmt.Errorf("invalid transaction %s: %w", txHash, err)
+		return nil, response.NewRPCError("Unknown transaction", err.Error(), err)
+	}
+	aer, trace, err := s.chain.TraceTransaction(ctx, txHash, steps)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, response.NewTimeoutError("transaction tracing timeout", err)
+		}
+		if errors.Is(err, core.ErrStateUnavailable) {
+			return nil, response.NewRPCError("Historical state is not available", err.Error(), err)
+		}
+		return nil, response.NewInternalServerError("failed to trace transaction", err)
+	}
+	return result.TransactionTrace{
+		TxHash:    txHash,
+		Execution: aer.Execution,
+		Trace:     trace,
+	}, nil
+}
+
 // getBlockSysFee returns the system fees of the block, based on the specified index.
 func (s *Server) getBlockSysFee(reqParams request.Params) (interface{}, *response.Error) {
 	param := reqParams.ValueWithType(0, request.NumberT)
@@ -1133,6 +1514,47 @@ func (s *Server) getBlockHeader(reqParams request.Params) (interface{}, *respons
 	return buf.Bytes(), nil
 }
 
+// getBlockHeaders returns serialized headers starting from the given index,
+// the number of headers is limited by maxBlockHeadersCount and the current
+// header height.
+func (s *Server) getBlockHeaders(reqParams request.Params) (interface{}, *response.Error) {
+	if len(reqParams) != 2 {
+		return nil, response.ErrInvalidParams
+	}
+	start, err := reqParams[0].GetInt()
+	if err != nil || start < 0 {
+		return nil, response.ErrInvalidParams
+	}
+	count, err := reqParams[1].GetInt()
+	if err != nil || count <= 0 {
+		return nil, response.ErrInvalidParams
+	}
+	height := int(s.chain.HeaderHeight())
+	if start > height {
+		return nil, invalidBlockHeightError(0, start)
+	}
+	if count > maxBlockHeadersCount {
+		count = maxBlockHeadersCount
+	}
+	if count > height-start+1 {
+		count = height - start + 1
+	}
+	res := make([][]byte, 0, count)
+	for i := start; i < start+count; i++ {
+		h, err := s.chain.GetHeader(s.chain.GetHeaderHash(i))
+		if err != nil {
+			return nil, response.NewInternalServerError(fmt.Sprintf("failed to get header %d", i), err)
+		}
+		buf := io.NewBufBinWriter()
+		h.EncodeBinary(buf.BinWriter)
+		if buf.Err != nil {
+			return nil, response.NewInternalServerError("encoding error", buf.Err)
+		}
+		res = append(res, buf.Bytes())
+	}
+	return res, nil
+}
+
 // getUnclaimedGas returns unclaimed GAS amount of the specified address.
 func (s *Server) getUnclaimedGas(ps request.Params) (interface{}, *response.Error) {
 	u, err := ps.ValueWithType(0, request.StringT).GetUint160FromAddressOrHex()
@@ -1156,8 +1578,9 @@ func (s *Server) getUnclaimedGas(ps request.Params) (interface{}, *response.Erro
 	}, nil
 }
 
-// getNextBlockValidators returns validators for the next block with voting status.
-func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *response.Error) {
+// getNextBlockValidators returns validators for the next block with voting
+// status, verbose request also returns voters of every validator.
+func (s *Server) getNextBlockValidators(reqParams request.Params) (interface{}, *response.Error) {
 	var validators keys.PublicKeys
 
 	validators, err := s.chain.GetNextBlockValidators()
@@ -1176,6 +1599,23 @@ func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *respons
 			Active:    validators.Contains(v.Key),
 		})
 	}
+	if reqParams.Value(0).GetBoolean() {
+		voters, err := s.chain.GetVoters()
+		if err != nil {
+			return nil, response.NewRPCError("can't get voters", "", err)
+		}
+		for _, v := range voters {
+			for i := range res {
+				if res[i].PublicKey.Equal(v.Candidate) {
+					res[i].Voters = append(res[i].Voters, result.Voter{
+						Account: v.Account,
+						Votes:   v.Votes.Int64(),
+					})
+					break
+				}
+			}
+		}
+	}
 	return res, nil
 }
 
@@ -1189,7 +1629,7 @@ func (s *Server) getCommittee(_ request.Params) (interface{}, *response.Error) {
 }
 
 // invokeFunction implements the `invokeFunction` RPC call.
-func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *response.Error) {
+func (s *Server) invokeFunction(ctx context.Context, reqParams request.Params) (interface{}, *response.Error) {
 	scriptHash, responseErr := s.contractScriptHashFromParam(reqParams.Value(0))
 	if responseErr != nil {
 		return nil, responseErr
@@ -1197,12 +1637,13 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 	tx := &transaction.Transaction{}
 	checkWitnessHashesIndex := len(reqParams)
 	if checkWitnessHashesIndex > 3 {
-		signers, _, err := reqParams[3].GetSignersWithWitnesses()
+		signers, witnesses, err := reqParams[3].GetSignersWithWitnesses()
 		if err != nil {
 			return nil, response.ErrInvalidParams
 		}
 		tx.Signers = signers
-		checkWitnessHashesIndex--
+		tx.Scripts = witnesses
+		checkWitnessHashesIndex = 3
 	}
 	if len(tx.Signers) == 0 {
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
@@ -1212,11 +1653,11 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 		return nil, response.NewInternalServerError("can't create invocation script", err)
 	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	return s.runScriptInVM(ctx, trigger.Application, script, util.Uint160{}, tx, reqParams.Value(4).GetBoolean())
 }
 
 // invokescript implements the `invokescript` RPC call.
-func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.Error) {
+func (s *Server) invokescript(ctx context.Context, reqParams request.Params) (interface{}, *response.Error) {
 	if len(reqParams) < 1 {
 		return nil, response.ErrInvalidParams
 	}
@@ -1228,21 +1669,22 @@ func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.
 
 	tx := &transaction.Transaction{}
 	if len(reqParams) > 1 {
-		signers, _, err := reqParams[1].GetSignersWithWitnesses()
+		signers, witnesses, err := reqParams[1].GetSignersWithWitnesses()
 		if err != nil {
 			return nil, response.ErrInvalidParams
 		}
 		tx.Signers = signers
+		tx.Scripts = witnesses
 	}
 	if len(tx.Signers) == 0 {
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
 	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	return s.runScriptInVM(ctx, trigger.Application, script, util.Uint160{}, tx, reqParams.Value(2).GetBoolean())
 }
 
 // invokeContractVerify implements the `invokecontractverify` RPC call.
-func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *response.Error) {
+func (s *Server) invokeContractVerify(ctx context.Context, reqParams request.Params) (interface{}, *response.Error) {
 	scriptHash, responseErr := s.contractScriptHashFromParam(reqParams.Value(0))
 	if responseErr != nil {
 		return nil, responseErr
@@ -1271,20 +1713,23 @@ func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *r
 		}
 		tx.Signers = signers
 		tx.Scripts = witnesses
-	} else { // fill the only known signer - the contract with `verify` method
+	}
+	if len(tx.Signers) == 0 { // fill the only known signer - the contract with `verify` method
 		tx.Signers = []transaction.Signer{{Account: scriptHash}}
 		tx.Scripts = []transaction.Witness{{InvocationScript: invocationScript, VerificationScript: []byte{}}}
 	}
 
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx)
+	return s.runScriptInVM(ctx, trigger.Verification, invocationScript, scriptHash, tx, reqParams.Value(3).GetBoolean())
 }
 
 // runScriptInVM runs given script in a new test VM and returns the invocation
 // result. The script is either a simple script in case of `application` trigger
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
-// contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction) (*result.Invoke, *response.Error) {
+// contractScriptHash should be specified. Execution is aborted with timeout
+// error if ctx deadline is exceeded. GAS consumption breakdown is added to the
+// result if withGasBreakdown is set.
+func (s *Server) runScriptInVM(ctx context.Context, t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, withGasBreakdown bool) (*result.Invoke, *response.Error) {
 	// When transferring funds, script execution does no auto GAS claim,
 	// because it depends on persisting tx height.
 	// This is why we provide block here.
@@ -1317,14 +1762,28 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 			return nil, response.NewInternalServerError("can't prepare verification VM", err)
 		}
 	} else {
+		// Transactions with bigger system fee won't be accepted anyway.
+		sysFeeLimit := s.chain.GetConfig().MaxBlockSystemFee
+		if vm.GasLimit > sysFeeLimit {
+			vm.GasLimit = sysFeeLimit
+		}
 		vm.LoadScriptWithFlags(script, callflag.All)
 	}
-	err = vm.Run()
+	var gb *result.GasBreakdown
+	if withGasBreakdown {
+		gb = trackGas(vm)
+	}
+	err = vm.RunWithContext(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, response.NewTimeoutError("script execution timeout", err)
+	}
 	var faultException string
 	if err != nil {
 		faultException = err.Error()
 	}
-	return result.NewInvoke(vm, script, faultException, s.config.MaxIteratorResultItems), nil
+	res := result.NewInvoke(vm, script, faultException, s.config.MaxIteratorResultItems)
+	res.GasBreakdown = gb
+	return res, nil
 }
 
 // submitBlock broadcasts a raw block over the NEO network.
@@ -1343,6 +1802,15 @@ func (s *Server) submitBlock(reqParams request.Params) (interface{}, *response.E
 	if err != nil {
 		switch {
 		case errors.Is(err, core.ErrInvalidBlockIndex) || errors.Is(err, core.ErrAlreadyExists):
+			if b.Index <= s.chain.BlockHeight() {
+				known := s.chain.GetHeaderHash(int(b.Index))
+				if !known.Equals(b.Hash()) {
+					return nil, response.WrapErrorWithData(response.ErrAlreadyExists,
+						fmt.Errorf("conflicting block %s already exists at height %d", known.StringLE(), b.Index))
+				}
+				// Resubmitting the same block is not an error.
+				break
+			}
 			return nil, response.WrapErrorWithData(response.ErrAlreadyExists, err)
 		default:
 			return nil, response.WrapErrorWithData(response.ErrValidationFailed, err)
@@ -1353,6 +1821,93 @@ func (s *Server) submitBlock(reqParams request.Params) (interface{}, *response.E
 	}, nil
 }
 
+// submitConsensus passes consensus payload to the consensus service and
+// broadcasts it over the NEO network. It's intended to be used by local
+// recovery tools only, so it must be explicitly enabled and RPC server must be
+// bound to loopback address.
+func (s *Server) submitConsensus(ps request.Params) (interface{}, *response.Error) {
+	if !s.config.EnableSubmitConsensus {
+		return nil, response.NewInternalServerError("submitconsensus is disabled", nil)
+	}
+	if !isLoopbackAddress(s.config.Address) {
+		return nil, response.NewInternalServerError("submitconsensus is only allowed for loopback RPC address", nil)
+	}
+	bytePayload, err := ps.ValueWithType(0, request.StringT).GetBytesBase64()
+	if err != nil {
+		return nil, response.NewInvalidParamsError("missing parameter or not base64", err)
+	}
+	e := payload.NewExtensible()
+	r := io.NewBinReaderFromBuf(bytePayload)
+	e.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, response.NewInvalidParamsError("can't decode consensus payload", r.Err)
+	}
+	if err := s.coreServer.SubmitConsensusPayload(e); err != nil {
+		return nil, response.WrapErrorWithData(response.ErrValidationFailed, err)
+	}
+	return &result.RelayResult{
+		Hash: e.Hash(),
+	}, nil
+}
+
+// getConsensusState returns the state of the current consensus round.
+func (s *Server) getConsensusState(_ request.Params) (interface{}, *response.Error) {
+	st := s.coreServer.GetConsensusState()
+	if st.Height == 0 {
+		return nil, response.NewInternalServerError("consensus service is not running", nil)
+	}
+	return result.ConsensusState{
+		Height:        st.Height,
+		View:          st.View,
+		Primary:       st.Primary,
+		NextBlockTime: uint64(st.NextBlockTime.UnixNano() / int64(time.Millisecond)),
+	}, nil
+}
+
+// forceBlock makes the consensus node propose a new block with the current
+// mempool contents and waits for it to be persisted. It's intended to be used
+// in tests and private single-node networks, so it must be explicitly enabled
+// and RPC server must be bound to loopback address.
+func (s *Server) forceBlock(_ request.Params) (interface{}, *response.Error) {
+	if !s.config.EnableForceBlock {
+		return nil, response.NewInternalServerError("forceblock is disabled", nil)
+	}
+	if !isLoopbackAddress(s.config.Address) {
+		return nil, response.NewInternalServerError("forceblock is only allowed for loopback RPC address", nil)
+	}
+	height := s.chain.BlockHeight()
+	if err := s.coreServer.ForceBlock(); err != nil {
+		return nil, response.NewInternalServerError("can't force block", err)
+	}
+	ticker := time.NewTicker(sendAndWaitPollInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(forceBlockTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if s.chain.BlockHeight() > height {
+				return &result.RelayResult{
+					Hash: s.chain.GetHeaderHash(int(height + 1)),
+				}, nil
+			}
+		case <-timer.C:
+			return nil, response.NewRPCError("Timeout", "block is not persisted in time", nil)
+		case <-s.shutdown:
+			return nil, response.NewInternalServerError("server is shutting down", nil)
+		}
+	}
+}
+
+// isLoopbackAddress checks whether given host is a loopback one.
+func isLoopbackAddress(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // submitNotaryRequest broadcasts P2PNotaryRequest over the NEO network.
 func (s *Server) submitNotaryRequest(ps request.Params) (interface{}, *response.Error) {
 	if !s.chain.P2PSigExtensionsEnabled() {
@@ -1384,7 +1939,7 @@ func getRelayResult(err error, hash util.Uint256) (interface{}, *response.Error)
 		return nil, response.WrapErrorWithData(response.ErrAlreadyExists, err)
 	case errors.Is(err, core.ErrOOM):
 		return nil, response.WrapErrorWithData(response.ErrOutOfMemory, err)
-	case errors.Is(err, core.ErrPolicy):
+	case errors.Is(err, core.ErrPolicy), errors.Is(err, network.ErrTxRejected):
 		return nil, response.WrapErrorWithData(response.ErrPolicyFail, err)
 	default:
 		return nil, response.WrapErrorWithData(response.ErrValidationFailed, err)
@@ -1438,6 +1993,76 @@ func (s *Server) sendrawtransaction(reqParams request.Params) (interface{}, *res
 	return getRelayResult(s.coreServer.RelayTxn(tx), tx.Hash())
 }
 
+// sendAndWait implements the `sendandwait` RPC call. It relays transaction the
+// same way sendrawtransaction does and then waits for it to be persisted
+// returning its application log (or an error if it's not persisted within the
+// specified number of seconds).
+func (s *Server) sendAndWait(reqParams request.Params) (interface{}, *response.Error) {
+	if len(reqParams) < 1 {
+		return nil, response.NewInvalidParamsError("not enough parameters", nil)
+	}
+	byteTx, err := reqParams[0].GetBytesBase64()
+	if err != nil {
+		return nil, response.NewInvalidParamsError("not base64", err)
+	}
+	tx, err := transaction.NewTransactionFromBytes(byteTx)
+	if err != nil {
+		return nil, response.NewInvalidParamsError("can't decode transaction", err)
+	}
+	timeout := defaultSendAndWaitTimeout
+	if len(reqParams) > 1 {
+		timeout, err = reqParams[1].GetInt()
+		if err != nil || timeout <= 0 || timeout > maxSendAndWaitTimeout {
+			return nil, response.NewInvalidParamsError(fmt.Sprintf("timeout should be in 1-%d range", maxSendAndWaitTimeout), err)
+		}
+	}
+	if _, respErr := getRelayResult(s.coreServer.RelayTxn(tx), tx.Hash()); respErr != nil {
+		return nil, respErr
+	}
+
+	h := tx.Hash()
+	ticker := time.NewTicker(sendAndWaitPollInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(time.Duration(timeout) * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			aers, err := s.chain.GetAppExecResults(h, trigger.Application)
+			if err == nil {
+				return result.NewApplicationLog(h, aers, trigger.All), nil
+			}
+		case <-timer.C:
+			return nil, response.NewRPCError("Timeout", fmt.Sprintf("transaction %s is not persisted in %d seconds", h.StringLE(), timeout), nil)
+		case <-s.shutdown:
+			return nil, response.NewInternalServerError("server is shutting down", nil)
+		}
+	}
+}
+
+// verifyTransaction implements the `verifytransaction` RPC call. It performs
+// the same checks as sendrawtransaction does, but doesn't add transaction to the
+// mempool and doesn't relay it. If verification succeeds transaction script is
+// test-invoked and the result of this invocation is returned.
+func (s *Server) verifyTransaction(ctx context.Context, reqParams request.Params) (interface{}, *response.Error) {
+	if len(reqParams) < 1 {
+		return nil, response.NewInvalidParamsError("not enough parameters", nil)
+	}
+	byteTx, err := reqParams[0].GetBytesBase64()
+	if err != nil {
+		return nil, response.NewInvalidParamsError("not base64", err)
+	}
+	tx, err := transaction.NewTransactionFromBytes(byteTx)
+	if err != nil {
+		return nil, response.NewInvalidParamsError("can't decode transaction", err)
+	}
+	if err := s.chain.VerifyTx(tx); err != nil {
+		_, respErr := getRelayResult(err, tx.Hash())
+		return nil, respErr
+	}
+	return s.runScriptInVM(ctx, trigger.Application, tx.Script, util.Uint160{}, tx, false)
+}
+
 // subscribe handles subscription requests from websocket clients.
 func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface{}, *response.Error) {
 	streamName, err := reqParams.Value(0).GetString()
@@ -1723,6 +2348,41 @@ func (s *Server) logRequestError(r *request.Request, jsonErr *response.Error) {
 	s.log.Error("Error encountered with rpc request", logFields...)
 }
 
+// setCORSHeaders sets CORS headers for the given request if it's allowed by
+// the configuration and returns true if they were set.
+func (s *Server) setCORSHeaders(w http.ResponseWriter, r *http.Request) bool {
+	if s.config.EnableCORSWorkaround {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+		return true
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	var allowed string
+	for _, o := range s.config.CORS.AllowedOrigins {
+		if o == "*" || o == origin {
+			allowed = o
+			break
+		}
+	}
+	if allowed == "" {
+		return false
+	}
+	methods := defaultCORSMethods
+	if len(s.config.CORS.AllowedMethods) != 0 {
+		methods = strings.Join(s.config.CORS.AllowedMethods, ", ")
+	}
+	w.Header().Set("Access-Control-Allow-Origin", allowed)
+	w.Header().Set("Access-Control-Allow-Methods", methods)
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	if allowed != "*" {
+		w.Header().Add("Vary", "Origin")
+	}
+	return true
+}
+
 // writeHTTPErrorResponse writes an error response to the ResponseWriter.
 func (s *Server) writeHTTPErrorResponse(r *request.In, w http.ResponseWriter, jsonErr *response.Error) {
 	resp := s.packResponse(r, nil, jsonErr)
@@ -1734,17 +2394,13 @@ func (s *Server) writeHTTPServerResponse(r *request.Request, w http.ResponseWrit
 	resp.RunForErrors(func(jsonErr *response.Error) {
 		s.logRequestError(r, jsonErr)
 	})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	if r.In != nil {
 		resp := resp.(response.Abstract)
 		if resp.Error != nil {
 			w.WriteHeader(resp.Error.HTTPCode)
 		}
 	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	if s.config.EnableCORSWorkaround {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
-	}
 
 	encoder := json.NewEncoder(w)
 	err := encoder.Encode(resp)
@@ -29,10 +29,17 @@ const (
 )
 
 func getUnitTestChain(t *testing.T, enableOracle bool, enableNotary bool) (*core.Blockchain, *oracle.Oracle, config.Config, *zap.Logger) {
+	return getUnitTestChainWithCustomConfig(t, enableOracle, enableNotary, nil)
+}
+
+func getUnitTestChainWithCustomConfig(t *testing.T, enableOracle bool, enableNotary bool, customCfg func(*config.Config)) (*core.Blockchain, *oracle.Oracle, config.Config, *zap.Logger) {
 	net := netmode.UnitTestNet
 	configPath := "../../../config"
 	cfg, err := config.Load(configPath, net)
 	require.NoError(t, err, "could not load config")
+	if customCfg != nil {
+		customCfg(&cfg)
+	}
 
 	memoryStore := storage.NewMemoryStore()
 	logger := zaptest.NewLogger(t)
@@ -98,7 +105,11 @@ func getTestBlocks(t *testing.T) []*block.Block {
 }
 
 func initClearServerWithServices(t *testing.T, needOracle bool, needNotary bool) (*core.Blockchain, *Server, *httptest.Server) {
-	chain, orc, cfg, logger := getUnitTestChain(t, needOracle, needNotary)
+	return initClearServerWithCustomConfig(t, needOracle, needNotary, nil)
+}
+
+func initClearServerWithCustomConfig(t *testing.T, needOracle bool, needNotary bool, customCfg func(*config.Config)) (*core.Blockchain, *Server, *httptest.Server) {
+	chain, orc, cfg, logger := getUnitTestChainWithCustomConfig(t, needOracle, needNotary, customCfg)
 
 	serverConfig := network.NewServerConfig(cfg)
 	server, err := network.NewServer(serverConfig, chain, logger)
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
@@ -19,14 +20,19 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
@@ -39,6 +45,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 type executor struct {
@@ -141,6 +148,70 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"getapplicationlogs": {
+		{
+			name:   "positive, present and absent",
+			params: `[["` + deploymentTxHash + `", "d24cc1d52b5c0216cbf3835bb5bac8ccf32639fa1ab6627ec4e2b9f33f7ec02f", "` + genesisBlockHash + `"]]`,
+			result: func(e *executor) interface{} {
+				var res []*result.ApplicationLog
+				return &res
+			},
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*[]*result.ApplicationLog)
+				require.True(t, ok)
+				require.Equal(t, 3, len(*res))
+				assert.Equal(t, deploymentTxHash, (*res)[0].Container.StringLE())
+				assert.Equal(t, 1, len((*res)[0].Executions))
+				assert.Equal(t, trigger.Application, (*res)[0].Executions[0].Trigger)
+				assert.Nil(t, (*res)[1])
+				assert.Equal(t, genesisBlockHash, (*res)[2].Container.StringLE())
+				assert.Equal(t, 2, len((*res)[2].Executions))
+			},
+		},
+		{
+			name:   "positive, with trigger",
+			params: `[["` + genesisBlockHash + `", "` + deploymentTxHash + `"], "OnPersist"]`,
+			result: func(e *executor) interface{} {
+				var res []*result.ApplicationLog
+				return &res
+			},
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*[]*result.ApplicationLog)
+				require.True(t, ok)
+				require.Equal(t, 2, len(*res))
+				require.Equal(t, 1, len((*res)[0].Executions))
+				assert.Equal(t, trigger.OnPersist, (*res)[0].Executions[0].Trigger)
+				assert.Equal(t, 0, len((*res)[1].Executions))
+			},
+		},
+		{
+			name:   "positive, empty",
+			params: `[[]]`,
+			result: func(e *executor) interface{} {
+				return &[]*result.ApplicationLog{}
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "not an array",
+			params: `["` + deploymentTxHash + `"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `[["` + deploymentTxHash + `", "notahash"]]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid trigger",
+			params: `[["` + deploymentTxHash + `"], "Unknown"]`,
+			fail:   true,
+		},
+	},
 	"getcontractstate": {
 		{
 			name:   "positive, by hash",
@@ -500,6 +571,129 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getblockheaders": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "no count",
+			params: `[1]`,
+			fail:   true,
+		},
+		{
+			name:   "zero count",
+			params: `[1, 0]`,
+			fail:   true,
+		},
+		{
+			name:   "negative start",
+			params: `[-1, 5]`,
+			fail:   true,
+		},
+		{
+			name:   "start after height",
+			params: `[100500, 5]`,
+			fail:   true,
+		},
+	},
+	"getblockwithlogs": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "bad hash",
+			params: `["notahash"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown index",
+			params: `[100500]`,
+			fail:   true,
+		},
+	},
+	"tracetransaction": {
+		{
+			name:   "positive",
+			params: `["` + deploymentTxHash + `", 5]`,
+			result: func(e *executor) interface{} { return &result.TransactionTrace{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				actual := res.(*result.TransactionTrace)
+				h, err := util.Uint256DecodeStringLE(deploymentTxHash)
+				require.NoError(t, err)
+				aers, err := e.chain.GetAppExecResults(h, trigger.Application)
+				require.NoError(t, err)
+				require.Equal(t, h, actual.TxHash)
+				require.Equal(t, aers[0].VMState, actual.Execution.VMState)
+				require.Equal(t, aers[0].GasConsumed, actual.Execution.GasConsumed)
+				require.Equal(t, len(aers[0].Events), len(actual.Execution.Events))
+				require.Equal(t, 5, len(actual.Trace))
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown transaction",
+			params: `["` + util.Uint256{1, 2, 3}.StringLE() + `"]`,
+			fail:   true,
+		},
+		{
+			name:   "too many steps",
+			params: `["` + deploymentTxHash + `", 100500]`,
+			fail:   true,
+		},
+	},
+	"listcontracts": {
+		{
+			name:   "paged",
+			params: `[1, 2]`,
+			result: func(e *executor) interface{} {
+				res := []result.ContractInfo{}
+				return &res
+			},
+			check: func(t *testing.T, e *executor, res interface{}) {
+				css, err := e.chain.ListContracts()
+				require.NoError(t, err)
+				require.True(t, len(css) > 2)
+				expected := css[2:]
+				if len(expected) > 2 {
+					expected = expected[:2]
+				}
+				actual := *res.(*[]result.ContractInfo)
+				require.Equal(t, len(expected), len(actual))
+				for i := range expected {
+					require.Equal(t, expected[i].Hash, actual[i].Hash)
+				}
+			},
+		},
+		{
+			name:   "huge page",
+			params: `["9223372036854775807", 2]`,
+			result: func(e *executor) interface{} {
+				res := []result.ContractInfo{}
+				return &res
+			},
+			check: func(t *testing.T, e *executor, res interface{}) {
+				require.Equal(t, 0, len(*res.(*[]result.ContractInfo)))
+			},
+		},
+		{
+			name:   "negative page",
+			params: `[-1]`,
+			fail:   true,
+		},
+		{
+			name:   "too big limit",
+			params: `[0, 101]`,
+			fail:   true,
+		},
+	},
 	"getblocksysfee": {
 		{
 			name:   "positive",
@@ -530,6 +724,54 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"getfeehistogram": {
+		{
+			name:   "positive",
+			params: "[3]",
+			result: func(e *executor) interface{} { return &result.FeeHistogram{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				h, ok := res.(*result.FeeHistogram)
+				require.True(t, ok)
+				height := e.chain.BlockHeight()
+				require.Equal(t, height, h.Height)
+				var mempooled int
+				for i, bucket := range h.Mempool {
+					if i > 0 {
+						require.True(t, h.Mempool[i-1].FeePerByte > bucket.FeePerByte)
+					}
+					mempooled += bucket.Count
+				}
+				require.Equal(t, e.chain.GetMemPool().Count(), mempooled)
+				require.Equal(t, 3, len(h.Blocks))
+				for i, bf := range h.Blocks {
+					b, err := e.chain.GetBlock(e.chain.GetHeaderHash(int(height) - i))
+					require.NoError(t, err)
+					require.Equal(t, b.Index, bf.Index)
+					require.Equal(t, len(b.Transactions), bf.TxCount)
+				}
+			},
+		},
+		{
+			name:   "default number of blocks",
+			params: "[]",
+			result: func(e *executor) interface{} { return &result.FeeHistogram{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				h, ok := res.(*result.FeeHistogram)
+				require.True(t, ok)
+				require.Equal(t, defaultFeeHistogramBlocks, len(h.Blocks))
+			},
+		},
+		{
+			name:   "invalid number of blocks",
+			params: "[0]",
+			fail:   true,
+		},
+		{
+			name:   "too many blocks",
+			params: "[1000]",
+			fail:   true,
+		},
+	},
 	"getcommittee": {
 		{
 			params: "[]",
@@ -908,6 +1150,33 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"sendandwait": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid string",
+			params: `["notabase64%"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid tx",
+			params: `["AnADQSAADA2KcAAAAAABDiEgAAAAAAgBYAAAHunqIsJ+NL0BSPxBCOCPdOj1BIsgEAYBDAAwDodkgXAAAADBQRJlu0FyUAQb4E6PokDjj1fB5WmwwU7p6iLCfjS9AUj8QQjgj3To9QSLIUwB8MCHRyYW5zZmVyDBT1Y+pAvCg9TQ4FxI6jBbPyoHNA70FifVtSOQFCDEBRp0p08GFA2rYC/Xrol8DIhXEMfVMbUJEYer1RqZSatmTjUJE9fnZtDGkQEX/zQ7yOhbnIPAZIrllUTuUBskhUKAwhArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43CQVbnsyc=kgcmF3IGNvbnRyYWNw=="]`,
+			fail:   true,
+		},
+		{
+			name:   "zero timeout",
+			params: `["ADQSAADA2KcAAAAAABDiEgAAAAAAgBYAAAHunqIsJ+NL0BSPxBCOCPdOj1BIsgEAYBDAAwDodkgXAAAADBQRJlu0FyUAQb4E6PokDjj1fB5WmwwU7p6iLCfjS9AUj8QQjgj3To9QSLIUwB8MCHRyYW5zZmVyDBT1Y+pAvCg9TQ4FxI6jBbPyoHNA70FifVtSOQFCDEBRp0p08GFA2rYC/Xrol8DIhXEMfVMbUJEYer1RqZSatmTjUJE9fnZtDGkQEX/zQ7yOhbnIPAZIrllUTuUBskhUKAwhArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43CQVbnsyc=", 0]`,
+			fail:   true,
+		},
+		{
+			name:   "too big timeout",
+			params: `["ADQSAADA2KcAAAAAABDiEgAAAAAAgBYAAAHunqIsJ+NL0BSPxBCOCPdOj1BIsgEAYBDAAwDodkgXAAAADBQRJlu0FyUAQb4E6PokDjj1fB5WmwwU7p6iLCfjS9AUj8QQjgj3To9QSLIUwB8MCHRyYW5zZmVyDBT1Y+pAvCg9TQ4FxI6jBbPyoHNA70FifVtSOQFCDEBRp0p08GFA2rYC/Xrol8DIhXEMfVMbUJEYer1RqZSatmTjUJE9fnZtDGkQEX/zQ7yOhbnIPAZIrllUTuUBskhUKAwhArNiK/QBe9/jF8WK7V9MdT8ga324lgRvp9d0u8S/f43CQVbnsyc=", 1000]`,
+			fail:   true,
+		},
+	},
 	"sendrawtransaction": {
 		{
 			name:   "positive",
@@ -1040,6 +1309,391 @@ func TestSubmitOracle(t *testing.T) {
 	t.Run("Valid", runCase(t, false, pubStr, `1`, txSigStr, msgSigStr))
 }
 
+func TestRequestTimeout(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	rpcSrv.config.MaxGasInvoke = fixedn.Fixed8(math.MaxInt64)
+	rpcSrv.config.RequestTimeout = 100 * time.Millisecond
+
+	// JMP 0 is an endless loop.
+	script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.JMP), 0})
+	req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script)
+	start := time.Now()
+	body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+	require.True(t, time.Since(start) < time.Second)
+
+	var resp response.Raw
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.NotNil(t, resp.Error)
+	require.Equal(t, response.NewTimeoutError("", nil).Code, resp.Error.Code)
+
+	t.Run("not exceeded", func(t *testing.T) {
+		script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.PUSH1)})
+		req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script)
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+	})
+}
+
+func TestInvokeGasLimit(t *testing.T) {
+	const maxBlockSysFee = 1_0000_0000
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, false, false, func(cfg *config.Config) {
+		cfg.ProtocolConfiguration.MaxBlockSystemFee = maxBlockSysFee
+	})
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	// JMP 0 is an endless loop, so it consumes all GAS available.
+	script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.JMP), 0})
+	req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script)
+	check := func(t *testing.T, maxGasInvoke fixedn.Fixed8, limit int64) {
+		rpcSrv.config.MaxGasInvoke = maxGasInvoke
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, body, false), res))
+		require.Equal(t, "FAULT", res.State)
+		// Execution stops at the first instruction exceeding the limit.
+		jmpPrice := fee.Opcode(chain.GetPolicer().GetBaseExecFee(), opcode.JMP)
+		require.True(t, res.GasConsumed > limit && res.GasConsumed <= limit+jmpPrice, res.GasConsumed)
+	}
+	t.Run("below MaxBlockSystemFee", func(t *testing.T) {
+		check(t, fixedn.Fixed8(maxBlockSysFee/2), maxBlockSysFee/2)
+	})
+	t.Run("at MaxBlockSystemFee", func(t *testing.T) {
+		check(t, fixedn.Fixed8(maxBlockSysFee), maxBlockSysFee)
+	})
+	t.Run("above MaxBlockSystemFee", func(t *testing.T) {
+		check(t, fixedn.Fixed8(maxBlockSysFee*10), maxBlockSysFee)
+	})
+}
+
+func TestMaxConcurrentInvocations(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	const count = 3
+	rpcSrv.invokeSem = make(chan struct{}, 1)
+
+	script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.PUSH1)})
+	req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script)
+	type invokeResult struct {
+		resp response.Raw
+		err  error
+	}
+	// invokeConcurrently doesn't wait for the results, they're delivered via
+	// the channel returned to be checked in the test goroutine.
+	invokeConcurrently := func() <-chan invokeResult {
+		results := make(chan invokeResult, count)
+		for i := 0; i < count; i++ {
+			go func() {
+				var res invokeResult
+				cl := http.Client{Timeout: 5 * time.Second}
+				resp, err := cl.Post(httpSrv.URL, "application/json", strings.NewReader(req))
+				if err == nil {
+					res.err = json.NewDecoder(resp.Body).Decode(&res.resp)
+					resp.Body.Close()
+				} else {
+					res.err = err
+				}
+				results <- res
+			}()
+		}
+		return results
+	}
+	// The only slot is occupied by the test itself, so that no invocation
+	// can proceed until it's released.
+	occupySlot := func() { rpcSrv.invokeSem <- struct{}{} }
+	releaseSlot := func() { <-rpcSrv.invokeSem }
+
+	t.Run("queue", func(t *testing.T) {
+		occupySlot()
+		results := invokeConcurrently()
+		select {
+		case res := <-results:
+			releaseSlot()
+			t.Fatalf("invocation is not queued: %+v", res)
+		case <-time.After(200 * time.Millisecond):
+		}
+		releaseSlot()
+		for i := 0; i < count; i++ {
+			res := <-results
+			require.NoError(t, res.err)
+			require.Nil(t, res.resp.Error)
+		}
+	})
+	t.Run("reject", func(t *testing.T) {
+		rpcSrv.config.RejectExcessInvocations = true
+		occupySlot()
+		results := invokeConcurrently()
+		busyCode := response.NewServerBusyError("").Code
+		for i := 0; i < count; i++ {
+			res := <-results
+			require.NoError(t, res.err)
+			require.NotNil(t, res.resp.Error)
+			require.Equal(t, busyCode, res.resp.Error.Code)
+		}
+		releaseSlot()
+
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+	})
+}
+
+func TestCORS(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	const origin = "https://dapp.example"
+	doRequest := func(t *testing.T, method, origin, body string) *http.Response {
+		req, err := http.NewRequest(method, httpSrv.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+	getBlockCount := `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+
+	t.Run("disabled", func(t *testing.T) {
+		resp := doRequest(t, "POST", origin, getBlockCount)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+		resp = doRequest(t, "OPTIONS", origin, "")
+		require.NotEqual(t, http.StatusNoContent, resp.StatusCode)
+		require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	rpcSrv.config.CORS.AllowedOrigins = []string{origin}
+	t.Run("preflight", func(t *testing.T) {
+		resp := doRequest(t, "OPTIONS", origin, "")
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Equal(t, origin, resp.Header.Get("Access-Control-Allow-Origin"))
+		require.Equal(t, defaultCORSMethods, resp.Header.Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "Content-Type", resp.Header.Get("Access-Control-Allow-Headers"))
+	})
+	t.Run("request", func(t *testing.T) {
+		resp := doRequest(t, "POST", origin, getBlockCount)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, origin, resp.Header.Get("Access-Control-Allow-Origin"))
+
+		resp = doRequest(t, "POST", origin, `{"jsonrpc": "2.0", "id": 1, "method": "unknown", "params": []}`)
+		require.NotEqual(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, origin, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+	t.Run("unknown origin", func(t *testing.T) {
+		resp := doRequest(t, "OPTIONS", "https://evil.example", "")
+		require.NotEqual(t, http.StatusNoContent, resp.StatusCode)
+		require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+		resp = doRequest(t, "POST", "", getBlockCount)
+		require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+	t.Run("any origin and custom methods", func(t *testing.T) {
+		rpcSrv.config.CORS.AllowedOrigins = []string{"*"}
+		rpcSrv.config.CORS.AllowedMethods = []string{"POST", "OPTIONS"}
+		resp := doRequest(t, "OPTIONS", "https://other.example", "")
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "POST, OPTIONS", resp.Header.Get("Access-Control-Allow-Methods"))
+	})
+}
+
+func TestDisabledMethods(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	rpcSrv.config.DisabledMethods = []string{"sendrawtransaction", "invokescript"}
+
+	for _, req := range []string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "sendrawtransaction", "params": ["AAAA"]}`,
+		`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["EQ=="]}`,
+	} {
+		body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+		var resp response.Raw
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, response.NewMethodDisabledError("").Code, resp.Error.Code)
+		require.Equal(t, response.NewMethodDisabledError("").Message, resp.Error.Message)
+	}
+
+	body := doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`, httpSrv.URL, t)
+	checkErrGetResult(t, body, false)
+}
+
+func TestTraceTransactionDisabled(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	rpcSrv.config.EnableTraceTransaction = false
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "tracetransaction", "params": ["` + deploymentTxHash + `"]}`
+	body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+	checkErrGetResult(t, body, true)
+}
+
+func TestSubmitConsensus(t *testing.T) {
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitconsensus", "params": %s}`
+
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer func() { _ = rpcSrv.Shutdown() }()
+
+	getPayload := func(t *testing.T, category string) string {
+		e := payload.NewExtensible()
+		e.Category = category
+		e.ValidBlockEnd = 10
+		e.Data = []byte{1, 2, 3}
+		e.Witness = transaction.Witness{InvocationScript: []byte{}, VerificationScript: []byte{}}
+		data, err := testserdes.EncodeBinary(e)
+		require.NoError(t, err)
+		return `["` + base64.StdEncoding.EncodeToString(data) + `"]`
+	}
+	runCase := func(t *testing.T, params string) {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, params), httpSrv.URL, t)
+		checkErrGetResult(t, body, true)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		runCase(t, getPayload(t, consensus.Category))
+	})
+
+	rpcSrv.config.EnableSubmitConsensus = true
+	t.Run("not a loopback address", func(t *testing.T) {
+		address := rpcSrv.config.Address
+		rpcSrv.config.Address = "0.0.0.0"
+		defer func() { rpcSrv.config.Address = address }()
+		runCase(t, getPayload(t, consensus.Category))
+	})
+	t.Run("missing payload", func(t *testing.T) {
+		runCase(t, "[]")
+	})
+	t.Run("not a base64", func(t *testing.T) {
+		runCase(t, `["not-a-base64$"]`)
+	})
+	t.Run("invalid payload", func(t *testing.T) {
+		runCase(t, `["`+base64.StdEncoding.EncodeToString([]byte{1, 2, 3})+`"]`)
+	})
+	t.Run("invalid category", func(t *testing.T) {
+		runCase(t, getPayload(t, "StateService"))
+	})
+	t.Run("not synchronized", func(t *testing.T) {
+		runCase(t, getPayload(t, consensus.Category))
+	})
+}
+
+func TestForceBlock(t *testing.T) {
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "forceblock", "params": []}`
+
+	t.Run("errors", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer func() { _ = rpcSrv.Shutdown() }()
+
+		runCase := func(t *testing.T) {
+			body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		}
+		t.Run("disabled", runCase)
+
+		rpcSrv.config.EnableForceBlock = true
+		t.Run("not a loopback address", func(t *testing.T) {
+			address := rpcSrv.config.Address
+			rpcSrv.config.Address = "0.0.0.0"
+			defer func() { rpcSrv.config.Address = address }()
+			runCase(t)
+		})
+		t.Run("consensus is not running", runCase)
+	})
+	t.Run("enabled", func(t *testing.T) {
+		chain, netSrv, httpSrv := initSingleNodeServer(t, func(cfg *config.Config) {
+			cfg.ApplicationConfiguration.RPC.EnableForceBlock = true
+		})
+
+		// Wait for consensus service to start.
+		require.Eventually(t, func() bool { return netSrv.ForceBlock() == nil }, 5*time.Second, 10*time.Millisecond)
+		require.Eventually(t, func() bool { return chain.BlockHeight() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false)
+		var rr result.RelayResult
+		require.NoError(t, json.Unmarshal(res, &rr))
+		require.Equal(t, uint32(2), chain.BlockHeight())
+		require.Equal(t, chain.GetHeaderHash(2), rr.Hash)
+	})
+}
+
+// initSingleNodeServer creates RPC server backed by a network server with
+// running consensus service of a single-validator network.
+func initSingleNodeServer(t *testing.T, f func(*config.Config)) (*core.Blockchain, *network.Server, *httptest.Server) {
+	cfg, err := config.LoadFile("../../../config/protocol.unit_testnet.single.yml")
+	require.NoError(t, err)
+	cfg.ProtocolConfiguration.SecondsPerBlock = 3600
+	cfg.ApplicationConfiguration.UnlockWallet = config.Wallet{
+		Path:     "../../consensus/testdata/wallet1.json",
+		Password: "one",
+	}
+	if f != nil {
+		f(&cfg)
+	}
+	logger := zaptest.NewLogger(t)
+	chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, logger)
+	require.NoError(t, err)
+	go chain.Run()
+	t.Cleanup(chain.Close)
+
+	netSrv, err := network.NewServer(network.NewServerConfig(cfg), chain, logger)
+	require.NoError(t, err)
+	go netSrv.Start(make(chan error, 2))
+	t.Cleanup(netSrv.Shutdown)
+
+	rpcSrv := New(chain, cfg.ApplicationConfiguration.RPC, netSrv, nil, logger)
+	rpcSrv.Start(make(chan error, 2))
+	t.Cleanup(func() { _ = rpcSrv.Shutdown() })
+	httpSrv := httptest.NewServer(http.HandlerFunc(rpcSrv.handleHTTPRequest))
+	t.Cleanup(httpSrv.Close)
+	return chain, netSrv, httpSrv
+}
+
+func TestGetConsensusState(t *testing.T) {
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getconsensusstate", "params": []}`
+
+	t.Run("not a consensus node", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer func() { _ = rpcSrv.Shutdown() }()
+
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		checkErrGetResult(t, body, true)
+	})
+	t.Run("consensus node", func(t *testing.T) {
+		chain, netSrv, httpSrv := initSingleNodeServer(t, nil)
+		require.Eventually(t, func() bool { return netSrv.GetConsensusState().Height != 0 }, 5*time.Second, 10*time.Millisecond)
+
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false)
+		var st result.ConsensusState
+		require.NoError(t, json.Unmarshal(res, &st))
+
+		genesis, err := chain.GetHeader(chain.CurrentBlockHash())
+		require.NoError(t, err)
+		require.Equal(t, result.ConsensusState{
+			Height:        1,
+			View:          0,
+			Primary:       0,
+			NextBlockTime: genesis.Timestamp + uint64(chain.GetConfig().SecondsPerBlock)*1000,
+		}, st)
+	})
+}
+
 func TestSubmitNotaryRequest(t *testing.T) {
 	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitnotaryrequest", "params": %s}`
 
@@ -1317,11 +1971,64 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 
 		t.Run("positive", func(t *testing.T) {
 			b := testchain.NewBlock(t, chain, 1, 0, newTx())
+			conflicting := testchain.NewBlock(t, chain, 1, 1)
 			body := doRPCCall(fmt.Sprintf(rpc, encodeBlock(t, b)), httpSrv.URL, t)
 			data := checkErrGetResult(t, body, false)
 			var res = new(result.RelayResult)
 			require.NoError(t, json.Unmarshal(data, res))
 			require.Equal(t, b.Hash(), res.Hash)
+
+			t.Run("same block", func(t *testing.T) {
+				body := doRPCCall(fmt.Sprintf(rpc, encodeBlock(t, b)), httpSrv.URL, t)
+				data := checkErrGetResult(t, body, false)
+				var res = new(result.RelayResult)
+				require.NoError(t, json.Unmarshal(data, res))
+				require.Equal(t, b.Hash(), res.Hash)
+			})
+			t.Run("conflicting block", func(t *testing.T) {
+				body := doRPCCall(fmt.Sprintf(rpc, encodeBlock(t, conflicting)), httpSrv.URL, t)
+				var resp response.Raw
+				require.NoError(t, json.Unmarshal(body, &resp))
+				require.NotNil(t, resp.Error)
+				require.Equal(t, response.ErrAlreadyExists.Code, resp.Error.Code)
+				require.True(t, strings.Contains(resp.Error.Data, b.Hash().StringLE()), resp.Error.Data)
+			})
+		})
+	})
+	t.Run("verifytransaction", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "verifytransaction", "params": ["%s"]}`
+		acc0 := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+		newTx := func(vub uint32) *transaction.Transaction {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			tx.Nonce = 42
+			tx.ValidUntilBlock = vub
+			tx.Signers = []transaction.Signer{{Account: acc0.PrivateKey().GetScriptHash()}}
+			size := io.GetVarSize(tx)
+			netFee, sizeDelta := fee.Calculate(chain.GetBaseExecFee(), acc0.Contract.Script)
+			tx.NetworkFee = netFee + int64(size+sizeDelta)*chain.FeePerByte()
+			require.NoError(t, acc0.SignTx(testchain.Network(), tx))
+			return tx
+		}
+
+		t.Run("positive", func(t *testing.T) {
+			tx := newTx(chain.BlockHeight() + 10)
+			body := doRPCCall(fmt.Sprintf(rpc, base64.StdEncoding.EncodeToString(tx.Bytes())), httpSrv.URL, t)
+			data := checkErrGetResult(t, body, false)
+			res := new(result.Invoke)
+			require.NoError(t, json.Unmarshal(data, res))
+			require.Equal(t, "HALT", res.State)
+			require.Equal(t, 1, len(res.Stack))
+			require.Equal(t, big.NewInt(1), res.Stack[0].Value())
+			require.False(t, chain.GetMemPool().ContainsKey(tx.Hash()))
+		})
+		t.Run("expired", func(t *testing.T) {
+			tx := newTx(chain.BlockHeight())
+			body := doRPCCall(fmt.Sprintf(rpc, base64.StdEncoding.EncodeToString(tx.Bytes())), httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		})
+		t.Run("invalid tx", func(t *testing.T) {
+			body := doRPCCall(fmt.Sprintf(rpc, "AnTXkgcmF3IGNvbnRyYWNw=="), httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
 		})
 	})
 	t.Run("getproof", func(t *testing.T) {
@@ -1409,6 +2116,22 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		assert.Equal(t, *block.Transactions[0], actual.Transaction)
 		assert.Equal(t, 15, actual.Confirmations)
 		assert.Equal(t, TXHash, actual.Transaction.Hash())
+		assert.Equal(t, block.Hash(), actual.Blockhash)
+		assert.Equal(t, block.Timestamp, actual.Timestamp)
+	})
+
+	t.Run("getrawtransaction 3 arguments, verbose, transaction only", func(t *testing.T) {
+		block, _ := chain.GetBlock(chain.GetHeaderHash(1))
+		TXHash := block.Transactions[0].Hash()
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getrawtransaction", "params": ["%s", 1, true]}"`, TXHash.StringLE())
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		txOut := checkErrGetResult(t, body, false)
+		require.NotContains(t, string(txOut), "blockhash")
+
+		actual := result.TransactionOutputRaw{}
+		require.NoErrorf(t, json.Unmarshal(txOut, &actual), "could not parse response: %s", txOut)
+		require.Equal(t, *block.Transactions[0], actual.Transaction)
+		require.Equal(t, result.TransactionMetadata{}, actual.TransactionMetadata)
 	})
 
 	t.Run("getblockheader_positive", func(t *testing.T) {
@@ -1488,6 +2211,37 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		assert.ElementsMatch(t, expected, actual)
 	})
 
+	t.Run("getrawmempool by sender", func(t *testing.T) {
+		mp := chain.GetMemPool()
+		senderA := util.Uint160{4, 5, 6}
+		senderB := util.Uint160{7, 8, 9}
+		expected := make([]util.Uint256, 0)
+		for i := 0; i < 4; i++ {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			if i%2 == 0 {
+				tx.Signers = []transaction.Signer{{Account: senderA}}
+				expected = append(expected, tx.Hash())
+			} else {
+				tx.Signers = []transaction.Signer{{Account: senderB}}
+			}
+			require.NoError(t, mp.Add(tx, &FeerStub{}))
+		}
+
+		for _, param := range []string{`"` + senderA.StringLE() + `"`, `"` + address.Uint160ToString(senderA) + `"`} {
+			rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getrawmempool", "params": [false, ` + param + `]}`
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false)
+
+			var actual []util.Uint256
+			require.NoErrorf(t, json.Unmarshal(res, &actual), "could not parse response: %s", res)
+			require.ElementsMatch(t, expected, actual)
+		}
+
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getrawmempool", "params": [false, "notahash"]}`
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		checkErrGetResult(t, body, true)
+	})
+
 	t.Run("getnep17transfers", func(t *testing.T) {
 		testNEP17T := func(t *testing.T, start, stop, limit, page int, sent, rcvd []int) {
 			ps := []string{`"` + testchain.PrivateKeyByID(0).Address() + `"`}
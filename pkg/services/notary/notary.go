@@ -18,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -368,7 +369,7 @@ func (n *Notary) verifyIncompleteWitnesses(tx *transaction.Transaction, nKeys ui
 			// then it's a contract verification (can be combined with anything)
 			continue
 		}
-		if !tx.Signers[i].Account.Equals(hash.Hash160(w.VerificationScript)) { // https://github.com/nspcc-dev/neo-go/pull/1658#discussion_r564265987
+		if !tx.Signers[i].Account.Equals(smartcontract.ScriptHash(w.VerificationScript)) { // https://github.com/nspcc-dev/neo-go/pull/1658#discussion_r564265987
 			return Unknown, 0, nil, fmt.Errorf("transaction should have valid verification script for signer #%d", i)
 		}
 		if nSigs, pubsBytes, ok = vm.ParseMultiSigContract(w.VerificationScript); ok {
@@ -7,9 +7,9 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
-	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -97,7 +97,7 @@ func (o *Oracle) CreateResponseTx(gasForResponse int64, height uint32, resp *tra
 			Scopes:  transaction.None,
 		},
 		{
-			Account: hash.Hash160(oracleSignContract),
+			Account: smartcontract.ScriptHash(oracleSignContract),
 			Scopes:  transaction.None,
 		},
 	}
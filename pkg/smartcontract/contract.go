@@ -5,11 +5,20 @@ import (
 	"sort"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 )
 
+// ScriptHash returns the script hash (account) of the given verification
+// script. It's the same as hash.Hash160 and exists to make it clear at call
+// sites that an account is being derived from a script.
+func ScriptHash(script []byte) util.Uint160 {
+	return hash.Hash160(script)
+}
+
 // CreateMultiSigRedeemScript creates an "m out of n" type verification script
 // where n is the length of publicKeys.
 func CreateMultiSigRedeemScript(m int, publicKeys keys.PublicKeys) ([]byte, error) {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -127,3 +128,17 @@ func TestCreateMajorityMultiSigRedeemScript(t *testing.T) {
 	addKey()
 	checkM(6)
 }
+
+func TestScriptHash(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+
+	script := pub.GetVerificationScript()
+	require.Equal(t, pub.GetScriptHash(), ScriptHash(script))
+	require.Equal(t, hash.Hash160(script), ScriptHash(script))
+
+	multi, err := CreateDefaultMultiSigRedeemScript(keys.PublicKeys{pub})
+	require.NoError(t, err)
+	require.Equal(t, hash.Hash160(multi), ScriptHash(multi))
+}
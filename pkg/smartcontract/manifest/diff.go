@@ -0,0 +1,127 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Diff returns a human-readable list of differences between old and new
+// manifests: added, removed and changed methods, events, permissions and
+// supported standards (along with the name change). Method offsets are not
+// compared as they're changed by almost any contract code update. An empty
+// list is returned for equivalent manifests.
+func Diff(old, new *Manifest) []string {
+	var res []string
+	if old.Name != new.Name {
+		res = append(res, fmt.Sprintf("name changed: %s -> %s", old.Name, new.Name))
+	}
+	res = append(res, diffMethods(old.ABI.Methods, new.ABI.Methods)...)
+	res = append(res, diffEvents(old.ABI.Events, new.ABI.Events)...)
+	res = append(res, diffPermissions(old.Permissions, new.Permissions)...)
+	res = append(res, diffStandards(old.SupportedStandards, new.SupportedStandards)...)
+	return res
+}
+
+// diffItems compares two sets of named items using eq for the items with the
+// same key and returns sorted differences of the given kind.
+func diffItems(kind string, old, new map[string]interface{}, eq func(a, b interface{}) bool) []string {
+	var res []string
+	for k, o := range old {
+		n, ok := new[k]
+		if !ok {
+			res = append(res, kind+" removed: "+k)
+		} else if !eq(o, n) {
+			res = append(res, kind+" changed: "+k)
+		}
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			res = append(res, kind+" added: "+k)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func diffMethods(old, new []Method) []string {
+	toMap := func(ms []Method) map[string]interface{} {
+		res := make(map[string]interface{}, len(ms))
+		for i := range ms {
+			res[fmt.Sprintf("%s/%d", ms[i].Name, len(ms[i].Parameters))] = ms[i]
+		}
+		return res
+	}
+	return diffItems("method", toMap(old), toMap(new), func(a, b interface{}) bool {
+		ma, mb := a.(Method), b.(Method)
+		return ma.ReturnType == mb.ReturnType && ma.Safe == mb.Safe &&
+			parametersEqual(ma.Parameters, mb.Parameters)
+	})
+}
+
+func diffEvents(old, new []Event) []string {
+	toMap := func(es []Event) map[string]interface{} {
+		res := make(map[string]interface{}, len(es))
+		for i := range es {
+			res[es[i].Name] = es[i]
+		}
+		return res
+	}
+	return diffItems("event", toMap(old), toMap(new), func(a, b interface{}) bool {
+		return parametersEqual(a.(Event).Parameters, b.(Event).Parameters)
+	})
+}
+
+func diffPermissions(old, new []Permission) []string {
+	toMap := func(ps []Permission) map[string]interface{} {
+		res := make(map[string]interface{}, len(ps))
+		for i := range ps {
+			data, _ := ps[i].Contract.MarshalJSON()
+			res[strings.Trim(string(data), `"`)] = ps[i].Methods
+		}
+		return res
+	}
+	return diffItems("permission", toMap(old), toMap(new), func(a, b interface{}) bool {
+		return wildStringsEqual(a.(WildStrings), b.(WildStrings))
+	})
+}
+
+func diffStandards(old, new []string) []string {
+	toMap := func(ss []string) map[string]interface{} {
+		res := make(map[string]interface{}, len(ss))
+		for _, s := range ss {
+			res[s] = nil
+		}
+		return res
+	}
+	return diffItems("standard", toMap(old), toMap(new), func(a, b interface{}) bool { return true })
+}
+
+func parametersEqual(a, b []Parameter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// wildStringsEqual checks whether two containers are equal irrespective of
+// the order of their elements.
+func wildStringsEqual(a, b WildStrings) bool {
+	if a.IsWildcard() || b.IsWildcard() {
+		return a.IsWildcard() == b.IsWildcard()
+	}
+	if len(a.Value) != len(b.Value) {
+		return false
+	}
+	for _, s := range a.Value {
+		if !b.Contains(s) {
+			return false
+		}
+	}
+	return true
+}
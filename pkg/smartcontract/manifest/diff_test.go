@@ -0,0 +1,69 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	h := util.Uint160{1, 2, 3}
+	newManifest := func() *Manifest {
+		m := NewManifest("Test")
+		m.ABI.Methods = []Method{{
+			Name:       "transfer",
+			Offset:     0,
+			Parameters: []Parameter{NewParameter("to", smartcontract.Hash160Type)},
+			ReturnType: smartcontract.BoolType,
+		}}
+		m.ABI.Events = []Event{{Name: "Transfer"}}
+		p := NewPermission(PermissionHash, h)
+		p.Methods.Restrict()
+		p.Methods.Add("a")
+		p.Methods.Add("b")
+		m.Permissions = []Permission{*p, *NewPermission(PermissionWildcard)}
+		m.SupportedStandards = []string{NEP17StandardName}
+		return m
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		old, m := newManifest(), newManifest()
+		m.ABI.Methods[0].Offset = 42
+		m.Permissions[0].Methods.Value = []string{"b", "a"}
+		require.Empty(t, Diff(old, m))
+	})
+	t.Run("added method, changed permission", func(t *testing.T) {
+		old, m := newManifest(), newManifest()
+		m.ABI.Methods = append(m.ABI.Methods, Method{Name: "symbol", ReturnType: smartcontract.StringType, Safe: true})
+		m.Permissions[0].Methods.Add("c")
+		require.Equal(t, []string{
+			"method added: symbol/0",
+			"permission changed: 0x" + h.StringLE(),
+		}, Diff(old, m))
+	})
+	t.Run("removed and changed", func(t *testing.T) {
+		old, m := newManifest(), newManifest()
+		m.Name = "Other"
+		m.ABI.Methods[0].Safe = true
+		m.ABI.Events[0].Parameters = []Parameter{NewParameter("amount", smartcontract.IntegerType)}
+		m.ABI.Events = append(m.ABI.Events, Event{Name: "Burn"})
+		m.Permissions = m.Permissions[:1]
+		m.SupportedStandards = []string{NEP17Payable}
+		require.Equal(t, []string{
+			"name changed: Test -> Other",
+			"method changed: transfer/1",
+			"event added: Burn",
+			"event changed: Transfer",
+			"permission removed: *",
+			"standard added: " + NEP17Payable,
+			"standard removed: " + NEP17StandardName,
+		}, Diff(old, m))
+	})
+	t.Run("wildcard methods", func(t *testing.T) {
+		old, m := newManifest(), newManifest()
+		m.Permissions[0].Methods.Value = nil
+		require.Equal(t, []string{"permission changed: 0x" + h.StringLE()}, Diff(old, m))
+	})
+}
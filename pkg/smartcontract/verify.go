@@ -0,0 +1,104 @@
+package smartcontract
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+const (
+	// verifyBaseExecFee is the default execution fee factor.
+	verifyBaseExecFee = 30
+	// verifyGasLimit is the default Policy contract MaxVerificationGas value.
+	verifyGasLimit = 50000000
+)
+
+var (
+	checkSigID      = interopnames.ToID([]byte(interopnames.SystemCryptoCheckSig))
+	checkMultisigID = interopnames.ToID([]byte(interopnames.SystemCryptoCheckMultisig))
+)
+
+// VerifyScripts runs witness invocation and verification scripts for the
+// container signed in the given network and checks whether they return true.
+// Scripts are executed by a standalone VM without any blockchain, so only
+// System.Crypto.CheckSig and System.Crypto.CheckMultisig syscalls are
+// available which is enough for standard signature and multisignature
+// witnesses. An error is returned if scripts are invalid or their execution
+// fails.
+func VerifyScripts(network uint32, verification, invocation []byte, container hash.Hashable) (bool, error) {
+	if err := vm.IsScriptCorrect(verification, nil); err != nil {
+		return false, fmt.Errorf("invalid verification script: %w", err)
+	}
+	if err := vm.IsScriptCorrect(invocation, nil); err != nil {
+		return false, fmt.Errorf("invalid invocation script: %w", err)
+	}
+	v := vm.New()
+	v.GasLimit = verifyGasLimit
+	v.SetPriceGetter(func(op opcode.Opcode, _ []byte) int64 {
+		return fee.Opcode(verifyBaseExecFee, op)
+	})
+	v.SyscallHandler = verifySyscallHandler(network, container)
+	v.LoadScriptWithFlags(verification, callflag.ReadOnly)
+	if len(invocation) != 0 {
+		v.LoadScript(invocation)
+	}
+	err := v.Run()
+	if v.HasFailed() {
+		return false, fmt.Errorf("vm execution has failed: %w", err)
+	}
+	if v.Estack().Len() != 1 {
+		return false, errors.New("expected exactly one returned value")
+	}
+	res, err := v.Estack().Pop().Item().TryBool()
+	if err != nil {
+		return false, errors.New("invalid return value")
+	}
+	return res, nil
+}
+
+// verifySyscallHandler returns VM syscall handler implementing signature
+// checks for the given container.
+func verifySyscallHandler(network uint32, container hash.Hashable) vm.SyscallHandler {
+	return func(v *vm.VM, id uint32) error {
+		switch id {
+		case checkSigID:
+			if !v.AddGas(verifyBaseExecFee * fee.ECDSAVerifyPrice) {
+				return errors.New("gas limit exceeded")
+			}
+			keyb := v.Estack().Pop().Bytes()
+			signature := v.Estack().Pop().Bytes()
+			pkey, err := keys.NewPublicKeyFromBytes(keyb, elliptic.P256())
+			if err != nil {
+				return err
+			}
+			v.Estack().PushVal(pkey.VerifyHashable(signature, network, container))
+		case checkMultisigID:
+			pkeys, err := v.Estack().PopSigElements()
+			if err != nil {
+				return fmt.Errorf("wrong parameters: %w", err)
+			}
+			if !v.AddGas(verifyBaseExecFee * fee.ECDSAVerifyPrice * int64(len(pkeys))) {
+				return errors.New("gas limit exceeded")
+			}
+			sigs, err := v.Estack().PopSigElements()
+			if err != nil {
+				return fmt.Errorf("wrong parameters: %w", err)
+			}
+			if len(pkeys) < len(sigs) {
+				return errors.New("more signatures than there are keys")
+			}
+			v.Estack().PushVal(vm.CheckMultisigPar(v, elliptic.P256(), hash.NetSha256(network, container).BytesBE(), pkeys, sigs))
+		default:
+			return fmt.Errorf("unsupported syscall %d", id)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package smartcontract
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+type testContainer util.Uint256
+
+func (c testContainer) Hash() util.Uint256 { return util.Uint256(c) }
+
+func getInvocationScript(t *testing.T, sigs ...[]byte) []byte {
+	w := io.NewBufBinWriter()
+	for _, sig := range sigs {
+		emit.Bytes(w.BinWriter, sig)
+	}
+	require.NoError(t, w.Err)
+	return w.Bytes()
+}
+
+func TestVerifyScripts(t *testing.T) {
+	const network = 42
+	container := testContainer{1, 2, 3}
+
+	privs := make([]*keys.PrivateKey, 3)
+	pubs := make(keys.PublicKeys, 3)
+	for i := range privs {
+		var err error
+		privs[i], err = keys.NewPrivateKey()
+		require.NoError(t, err)
+		pubs[i] = privs[i].PublicKey()
+	}
+
+	t.Run("signature", func(t *testing.T) {
+		verif := pubs[0].GetVerificationScript()
+
+		inv := getInvocationScript(t, privs[0].SignHashable(network, container))
+		ok, err := VerifyScripts(network, verif, inv, container)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		t.Run("wrong key", func(t *testing.T) {
+			inv := getInvocationScript(t, privs[1].SignHashable(network, container))
+			ok, err := VerifyScripts(network, verif, inv, container)
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+		t.Run("wrong network", func(t *testing.T) {
+			ok, err := VerifyScripts(network+1, verif, inv, container)
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+		t.Run("wrong container", func(t *testing.T) {
+			ok, err := VerifyScripts(network, verif, inv, testContainer{3, 2, 1})
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+		t.Run("no invocation", func(t *testing.T) {
+			_, err := VerifyScripts(network, verif, nil, container)
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("multisignature", func(t *testing.T) {
+		verif, err := CreateMultiSigRedeemScript(2, pubs)
+		require.NoError(t, err)
+
+		// Keys are sorted by CreateMultiSigRedeemScript, signatures are to
+		// follow the same order.
+		var sigs [][]byte
+		for _, pub := range pubs {
+			for _, priv := range privs[:2] {
+				if priv.PublicKey().Equal(pub) {
+					sigs = append(sigs, priv.SignHashable(network, container))
+				}
+			}
+		}
+		ok, err := VerifyScripts(network, verif, getInvocationScript(t, sigs...), container)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = VerifyScripts(network, verif, getInvocationScript(t, sigs[0], sigs[0]), container)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("unsupported syscall", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Syscall(w.BinWriter, "System.Runtime.GetTime")
+		_, err := VerifyScripts(network, w.Bytes(), nil, container)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid script", func(t *testing.T) {
+		_, err := VerifyScripts(network, []byte{byte(opcode.JMP), 0x7f}, nil, container)
+		require.Error(t, err)
+	})
+}
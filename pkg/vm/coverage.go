@@ -0,0 +1,81 @@
+package vm
+
+import (
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// CoverageReport contains instruction coverage data for one script.
+type CoverageReport struct {
+	ScriptHash util.Uint160
+	// Total is the number of instructions in the script.
+	Total int
+	// Covered is the number of executed instructions.
+	Covered int
+	// Uncovered contains sorted offsets of instructions that were not
+	// executed.
+	Uncovered []int
+}
+
+// coverage tracks executed instruction offsets for every script loaded.
+type coverage map[util.Uint160]*scriptCoverage
+
+type scriptCoverage struct {
+	prog     []byte
+	executed map[int]bool
+}
+
+// EnableCoverage turns on instruction coverage collection for all scripts
+// executed by the VM (including the ones called from the loaded script). It
+// slows down execution, so it's intended for tests only.
+func (v *VM) EnableCoverage() {
+	if v.coverage == nil {
+		v.coverage = make(coverage)
+	}
+}
+
+// Coverage returns coverage reports for all scripts executed since
+// EnableCoverage call sorted by script hash. It returns nil if coverage
+// collection is not enabled.
+func (v *VM) Coverage() []CoverageReport {
+	if v.coverage == nil {
+		return nil
+	}
+	res := make([]CoverageReport, 0, len(v.coverage))
+	for h, sc := range v.coverage {
+		r := CoverageReport{ScriptHash: h}
+		ctx := NewContext(sc.prog)
+		for {
+			_, _, err := ctx.Next()
+			if err != nil || ctx.ip >= len(sc.prog) {
+				break
+			}
+			r.Total++
+			if sc.executed[ctx.ip] {
+				r.Covered++
+			} else {
+				r.Uncovered = append(r.Uncovered, ctx.ip)
+			}
+		}
+		res = append(res, r)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ScriptHash.Less(res[j].ScriptHash)
+	})
+	return res
+}
+
+// add marks current instruction of ctx as executed.
+func (c coverage) add(ctx *Context) {
+	if ctx.ip >= len(ctx.prog) {
+		return // Implicit RET.
+	}
+	h := ctx.ScriptHash()
+	sc, ok := c[h]
+	if !ok {
+		sc = &scriptCoverage{prog: ctx.prog, executed: make(map[int]bool)}
+		c[h] = sc
+	}
+	sc.executed[ctx.ip] = true
+}
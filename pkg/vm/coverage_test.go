@@ -0,0 +1,50 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverage(t *testing.T) {
+	// 0: PUSH1, 1: JMPIF +3 (to 4), 3: PUSH2, 4: PUSH3, 5: RET
+	prog := []byte{byte(opcode.PUSH1), byte(opcode.JMPIF), 3, byte(opcode.PUSH2), byte(opcode.PUSH3), byte(opcode.RET)}
+
+	t.Run("disabled", func(t *testing.T) {
+		v := New()
+		v.LoadScript(prog)
+		require.NoError(t, v.Run())
+		require.Nil(t, v.Coverage())
+	})
+
+	v := New()
+	v.EnableCoverage()
+	v.LoadScript(prog)
+	require.NoError(t, v.Run())
+	require.Equal(t, []CoverageReport{{
+		ScriptHash: hash.Hash160(prog),
+		Total:      5,
+		Covered:    4,
+		Uncovered:  []int{3},
+	}}, v.Coverage())
+
+	t.Run("several scripts", func(t *testing.T) {
+		// Implicit RET is not counted.
+		other := []byte{byte(opcode.PUSH0), byte(opcode.JMPIF), 3, byte(opcode.PUSH2), byte(opcode.PUSH3)}
+		v.LoadScript(other)
+		require.NoError(t, v.Run())
+		reports := v.Coverage()
+		require.Equal(t, 2, len(reports))
+		for _, r := range reports {
+			if r.ScriptHash == hash.Hash160(other) {
+				require.Equal(t, 4, r.Total)
+				require.Equal(t, 4, r.Covered)
+				require.Nil(t, r.Uncovered)
+			} else {
+				require.Equal(t, []int{3}, r.Uncovered)
+			}
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package vm
+
+import (
+	"encoding/json"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// TraceStep is a single executed instruction record.
+type TraceStep struct {
+	ScriptHash util.Uint160
+	IP         int
+	Opcode     opcode.Opcode
+	// GasConsumed is the amount of GAS consumed before this instruction.
+	GasConsumed int64
+	// StackSize is the evaluation stack size before this instruction.
+	StackSize int
+}
+
+// traceStepAux is an auxiliary struct for TraceStep JSON marshalling.
+type traceStepAux struct {
+	ScriptHash  util.Uint160 `json:"scripthash"`
+	IP          int          `json:"ip"`
+	Opcode      string       `json:"opcode"`
+	GasConsumed int64        `json:"gasconsumed,string"`
+	StackSize   int          `json:"stacksize"`
+}
+
+// trace keeps the last executed instructions.
+type trace struct {
+	max   int
+	steps []TraceStep
+}
+
+// EnableTrace turns on execution tracing keeping up to maxSteps last
+// executed instructions (of all scripts executed by the VM). It slows down
+// execution, so it's intended for debugging only.
+func (v *VM) EnableTrace(maxSteps int) {
+	v.trace = &trace{max: maxSteps}
+}
+
+// Trace returns executed instructions (the last ones if there were more than
+// maxSteps passed to EnableTrace) in the order of their execution. It returns
+// nil if tracing is not enabled.
+func (v *VM) Trace() []TraceStep {
+	if v.trace == nil {
+		return nil
+	}
+	return v.trace.steps
+}
+
+// add records current instruction of ctx.
+func (t *trace) add(v *VM, ctx *Context, op opcode.Opcode) {
+	if t.max <= 0 {
+		return
+	}
+	if len(t.steps) == t.max {
+		t.steps = t.steps[1:]
+	}
+	t.steps = append(t.steps, TraceStep{
+		ScriptHash:  ctx.ScriptHash(),
+		IP:          ctx.ip,
+		Opcode:      op,
+		GasConsumed: v.gasConsumed,
+		StackSize:   v.estack.Len(),
+	})
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (s TraceStep) MarshalJSON() ([]byte, error) {
+	return json.Marshal(traceStepAux{
+		ScriptHash:  s.ScriptHash,
+		IP:          s.IP,
+		Opcode:      s.Opcode.String(),
+		GasConsumed: s.GasConsumed,
+		StackSize:   s.StackSize,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (s *TraceStep) UnmarshalJSON(data []byte) error {
+	aux := new(traceStepAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	op, err := opcode.FromString(aux.Opcode)
+	if err != nil {
+		return err
+	}
+	*s = TraceStep{
+		ScriptHash:  aux.ScriptHash,
+		IP:          aux.IP,
+		Opcode:      op,
+		GasConsumed: aux.GasConsumed,
+		StackSize:   aux.StackSize,
+	}
+	return nil
+}
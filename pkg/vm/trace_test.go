@@ -0,0 +1,56 @@
+package vm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	// 0: PUSH1, 1: JMPIF +3 (to 4), 3: PUSH2, 4: PUSH3, 5: RET
+	prog := []byte{byte(opcode.PUSH1), byte(opcode.JMPIF), 3, byte(opcode.PUSH2), byte(opcode.PUSH3), byte(opcode.RET)}
+	h := hash.Hash160(prog)
+
+	t.Run("disabled", func(t *testing.T) {
+		v := New()
+		v.LoadScript(prog)
+		require.NoError(t, v.Run())
+		require.Nil(t, v.Trace())
+	})
+
+	v := New()
+	v.EnableTrace(10)
+	v.LoadScript(prog)
+	require.NoError(t, v.Run())
+	require.Equal(t, []TraceStep{
+		{ScriptHash: h, IP: 0, Opcode: opcode.PUSH1, StackSize: 0},
+		{ScriptHash: h, IP: 1, Opcode: opcode.JMPIF, StackSize: 1},
+		{ScriptHash: h, IP: 4, Opcode: opcode.PUSH3, StackSize: 0},
+		{ScriptHash: h, IP: 5, Opcode: opcode.RET, StackSize: 1},
+	}, v.Trace())
+
+	t.Run("limited", func(t *testing.T) {
+		v := New()
+		v.EnableTrace(2)
+		v.LoadScript(prog)
+		require.NoError(t, v.Run())
+		require.Equal(t, []TraceStep{
+			{ScriptHash: h, IP: 4, Opcode: opcode.PUSH3, StackSize: 0},
+			{ScriptHash: h, IP: 5, Opcode: opcode.RET, StackSize: 1},
+		}, v.Trace())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		step := TraceStep{ScriptHash: h, IP: 1, Opcode: opcode.JMPIF, GasConsumed: 42, StackSize: 1}
+		data, err := json.Marshal(step)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"opcode":"JMPIF"`)
+
+		var actual TraceStep
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, step, actual)
+	})
+}
@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"context"
 	"crypto/elliptic"
 	"encoding/binary"
 	"encoding/json"
@@ -87,6 +88,11 @@ type VM struct {
 
 	// Invocations is a script invocation counter.
 	Invocations map[util.Uint160]int
+
+	// coverage is an optional instruction coverage collector.
+	coverage coverage
+	// trace is an optional executed instructions collector.
+	trace *trace
 }
 
 // New returns a new VM object ready to load AVM bytecode scripts.
@@ -123,6 +129,11 @@ func (v *VM) SetPriceGetter(f func(opcode.Opcode, []byte) int64) {
 	v.getPrice = f
 }
 
+// GetPriceGetter returns the PriceGetterFunc registered in v.
+func (v *VM) GetPriceGetter() func(opcode.Opcode, []byte) int64 {
+	return v.getPrice
+}
+
 // GasConsumed returns the amount of GAS consumed during execution.
 func (v *VM) GasConsumed() int64 {
 	return v.gasConsumed
@@ -363,6 +374,20 @@ func (v *VM) Ready() bool {
 
 // Run starts the execution of the loaded program.
 func (v *VM) Run() error {
+	return v.run(nil)
+}
+
+// RunWithContext is similar to Run, but it also stops execution with FAULT
+// state when the given context is done.
+func (v *VM) RunWithContext(ctx context.Context) error {
+	return v.run(ctx)
+}
+
+func (v *VM) run(ctx context.Context) error {
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
 	if !v.Ready() {
 		v.state = FaultState
 		return errors.New("no program loaded")
@@ -385,6 +410,14 @@ func (v *VM) Run() error {
 			// Normal exit from this loop.
 			return nil
 		case v.state == NoneState:
+			if done != nil {
+				select {
+				case <-done:
+					v.state = FaultState
+					return fmt.Errorf("execution cancelled: %w", ctx.Err())
+				default:
+				}
+			}
 			if err := v.Step(); err != nil {
 				return err
 			}
@@ -527,6 +560,12 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		}
 	}()
 
+	if v.coverage != nil {
+		v.coverage.add(ctx)
+	}
+	if v.trace != nil && ctx.ip < len(ctx.prog) {
+		v.trace.add(v, ctx, op)
+	}
 	if v.getPrice != nil && ctx.ip < len(ctx.prog) {
 		v.gasConsumed += v.getPrice(op, parameter)
 		if v.GasLimit >= 0 && v.gasConsumed > v.GasLimit {
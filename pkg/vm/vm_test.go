@@ -2,6 +2,7 @@ package vm
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"math/big"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
@@ -138,6 +140,20 @@ func checkVMFailed(t *testing.T, vm *VM) {
 	assert.Equal(t, true, vm.HasFailed())
 }
 
+func TestRunWithContext(t *testing.T) {
+	// JMP 0 is an endless loop.
+	v := load([]byte{byte(opcode.JMP), 0})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := v.RunWithContext(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.True(t, v.HasFailed())
+
+	v = load([]byte{byte(opcode.PUSH1)})
+	require.NoError(t, v.RunWithContext(context.Background()))
+	require.False(t, v.HasFailed())
+}
+
 func TestStackLimitPUSH1Good(t *testing.T) {
 	prog := make([]byte, MaxStackSize*2)
 	for i := 0; i < MaxStackSize; i++ {
@@ -82,8 +82,47 @@ func NewAccount() (*Account, error) {
 	return NewAccountFromPrivateKey(priv), nil
 }
 
+// ErrWatchOnly is returned when watch-only account is used for signing.
+var ErrWatchOnly = errors.New("watch-only account can't sign")
+
+// NewWatchOnlyAccount creates a watch-only account for the given address. It
+// has no private key and no contract, so it can only be used to track the
+// address state (like balances), any signing attempt fails.
+func NewWatchOnlyAccount(addr string) (*Account, error) {
+	h, err := address.StringToUint160(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{Address: address.Uint160ToString(h)}, nil
+}
+
+// NewWatchOnlyAccountFromPublicKey creates a watch-only account for the
+// standard signature contract of the given public key. It has no private key,
+// so any signing attempt fails.
+func NewWatchOnlyAccountFromPublicKey(pub *keys.PublicKey) *Account {
+	return &Account{
+		publicKey: pub.Bytes(),
+		Address:   pub.Address(),
+		Contract: &Contract{
+			Script:     pub.GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}
+}
+
+// IsWatchOnly returns true if the account has neither private key (decrypted
+// or encrypted) nor the contract that can be used without a key, so it can't
+// be used for signing.
+func (a *Account) IsWatchOnly() bool {
+	return a.privateKey == nil && a.EncryptedWIF == "" &&
+		(a.Contract == nil || len(a.Contract.Parameters) != 0)
+}
+
 // SignTx signs transaction t and updates it's Witnesses.
 func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
+	if a.IsWatchOnly() {
+		return ErrWatchOnly
+	}
 	if len(a.Contract.Parameters) == 0 {
 		t.Scripts = append(t.Scripts, transaction.Witness{})
 		return nil
@@ -114,6 +153,9 @@ func (a *Account) GetVerificationScript() []byte {
 	if a.Contract != nil {
 		return a.Contract.Script
 	}
+	if a.privateKey == nil {
+		return nil
+	}
 	return a.PrivateKey().PublicKey().GetVerificationScript()
 }
 
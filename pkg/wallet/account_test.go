@@ -3,11 +3,15 @@ package wallet
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -143,3 +147,47 @@ func compareFields(t *testing.T, tk keytestcases.Ktype, acc *Account) {
 	want, have = tk.PrivateKey, acc.privateKey.String()
 	require.Equalf(t, want, have, "expected priv key %s got %s", want, have)
 }
+
+func TestNewWatchOnlyAccount(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: pub.GetScriptHash()}}
+
+	t.Run("address", func(t *testing.T) {
+		_, err := NewWatchOnlyAccount("not an address")
+		require.Error(t, err)
+
+		acc, err := NewWatchOnlyAccount(pub.Address())
+		require.NoError(t, err)
+		require.Equal(t, pub.Address(), acc.Address)
+		require.True(t, acc.IsWatchOnly())
+		require.Nil(t, acc.GetVerificationScript())
+		require.True(t, errors.Is(acc.SignTx(netmode.UnitTestNet, tx), ErrWatchOnly))
+		require.Equal(t, 0, len(tx.Scripts))
+	})
+	t.Run("public key", func(t *testing.T) {
+		acc := NewWatchOnlyAccountFromPublicKey(pub)
+		require.Equal(t, pub.Address(), acc.Address)
+		require.Equal(t, pub.GetVerificationScript(), acc.GetVerificationScript())
+		require.True(t, acc.IsWatchOnly())
+		require.True(t, errors.Is(acc.SignTx(netmode.UnitTestNet, tx), ErrWatchOnly))
+		require.Equal(t, 0, len(tx.Scripts))
+
+		data, err := json.Marshal(acc)
+		require.NoError(t, err)
+		actual := new(Account)
+		require.NoError(t, json.Unmarshal(data, actual))
+		require.True(t, actual.IsWatchOnly())
+	})
+	t.Run("regular accounts", func(t *testing.T) {
+		acc := NewAccountFromPrivateKey(priv)
+		require.False(t, acc.IsWatchOnly())
+		require.NoError(t, acc.Encrypt("pass"))
+		require.False(t, (&Account{EncryptedWIF: acc.EncryptedWIF, Contract: acc.Contract}).IsWatchOnly())
+		// Contract-based account doesn't need a key.
+		require.False(t, (&Account{Contract: &Contract{Script: []byte{byte(opcode.PUSHT)}}}).IsWatchOnly())
+	})
+}
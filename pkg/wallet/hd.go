@@ -0,0 +1,169 @@
+package wallet
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// Hierarchical deterministic key derivation follows BIP-32 adapted for
+// secp256r1 curve as specified in SLIP-0010, so that keys can be derived
+// from the same seed by other compatible implementations.
+const (
+	// HardenedKeyStart is the first index of hardened child keys.
+	HardenedKeyStart uint32 = 0x80000000
+
+	// DefaultDerivationPath is BIP-44 path prefix for Neo accounts, child
+	// account index is appended to it.
+	DefaultDerivationPath = "m/44'/888'/0'/0"
+
+	// MinSeedSize and MaxSeedSize limit master seed size.
+	MinSeedSize = 16
+	MaxSeedSize = 64
+
+	masterKeySalt = "Nist256p1 seed"
+)
+
+// HDKey is an extended private key that can be used to derive child keys.
+type HDKey struct {
+	key       *keys.PrivateKey
+	chainCode []byte
+}
+
+// NewMasterKey creates master extended key from the given seed.
+func NewMasterKey(seed []byte) (*HDKey, error) {
+	if len(seed) < MinSeedSize || len(seed) > MaxSeedSize {
+		return nil, fmt.Errorf("invalid seed length: expected %d-%d bytes, got %d", MinSeedSize, MaxSeedSize, len(seed))
+	}
+	data := seed
+	for {
+		i := hmacSHA512([]byte(masterKeySalt), data)
+		k := new(big.Int).SetBytes(i[:32])
+		if k.Sign() != 0 && k.Cmp(elliptic.P256().Params().N) < 0 {
+			return newHDKey(k, i[32:])
+		}
+		data = i
+	}
+}
+
+// Child derives child extended key with the given index. Indexes starting
+// from HardenedKeyStart produce hardened keys.
+func (k *HDKey) Child(index uint32) (*HDKey, error) {
+	var (
+		n        = elliptic.P256().Params().N
+		data     []byte
+		indexBuf = make([]byte, 4)
+	)
+	binary.BigEndian.PutUint32(indexBuf, index)
+	if index >= HardenedKeyStart {
+		data = append([]byte{0}, k.key.Bytes()...)
+	} else {
+		data = k.key.PublicKey().Bytes()
+	}
+	data = append(data, indexBuf...)
+	for {
+		i := hmacSHA512(k.chainCode, data)
+		child := new(big.Int).SetBytes(i[:32])
+		if child.Cmp(n) < 0 {
+			child.Add(child, k.key.D)
+			child.Mod(child, n)
+			if child.Sign() != 0 {
+				return newHDKey(child, i[32:])
+			}
+		}
+		data = append([]byte{1}, i[32:]...)
+		data = append(data, indexBuf...)
+	}
+}
+
+// Derive derives extended key using the given path (a list of child indexes)
+// starting from k.
+func (k *HDKey) Derive(path []uint32) (*HDKey, error) {
+	var err error
+	for _, index := range path {
+		k, err = k.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// PrivateKey returns private key of the extended key.
+func (k *HDKey) PrivateKey() *keys.PrivateKey {
+	return k.key
+}
+
+// ChainCode returns chain code of the extended key.
+func (k *HDKey) ChainCode() []byte {
+	return k.chainCode
+}
+
+// ParseDerivationPath parses BIP-32 path string like "m/44'/888'/0'/0/1"
+// into a list of indexes, hardened indexes are marked with ' or h suffix.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, errors.New("derivation path should start with 'm'")
+	}
+	res := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") {
+			offset = HardenedKeyStart
+			p = p[:len(p)-1]
+		}
+		index, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid path element: %s", p)
+		}
+		res = append(res, uint32(index)+offset)
+	}
+	return res, nil
+}
+
+// NewAccountFromSeed creates a new Account with a private key derived from
+// the given seed using DefaultDerivationPath and account index.
+func NewAccountFromSeed(seed []byte, index uint32) (*Account, error) {
+	path, err := ParseDerivationPath(DefaultDerivationPath)
+	if err != nil {
+		return nil, err
+	}
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	k, err := master.Derive(append(path, index))
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountFromPrivateKey(k.PrivateKey()), nil
+}
+
+func newHDKey(k *big.Int, chainCode []byte) (*HDKey, error) {
+	kb := k.Bytes()
+	b := make([]byte, 32)
+	copy(b[32-len(kb):], kb)
+	priv, err := keys.NewPrivateKeyFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return &HDKey{
+		key:       priv,
+		chainCode: append([]byte{}, chainCode...),
+	}, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
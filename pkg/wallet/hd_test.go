@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHDKey(t *testing.T) {
+	// SLIP-0010 test vector 1 for nist256p1 curve.
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	testCases := []struct {
+		path      string
+		chainCode string
+		key       string
+	}{
+		{"m", "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea", "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2"},
+		{"m/0'", "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+	}
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+	for _, tc := range testCases {
+		path, err := ParseDerivationPath(tc.path)
+		require.NoError(t, err)
+		k, err := master.Derive(path)
+		require.NoError(t, err)
+		require.Equal(t, tc.chainCode, hex.EncodeToString(k.ChainCode()), tc.path)
+		require.Equal(t, tc.key, hex.EncodeToString(k.PrivateKey().Bytes()), tc.path)
+	}
+
+	t.Run("invalid seed", func(t *testing.T) {
+		_, err := NewMasterKey(seed[:MinSeedSize-1])
+		require.Error(t, err)
+		_, err = NewMasterKey(make([]byte, MaxSeedSize+1))
+		require.Error(t, err)
+	})
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	path, err := ParseDerivationPath("m/44'/888h/0'/0/1")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{44 + HardenedKeyStart, 888 + HardenedKeyStart, HardenedKeyStart, 0, 1}, path)
+
+	path, err = ParseDerivationPath("m")
+	require.NoError(t, err)
+	require.Equal(t, 0, len(path))
+
+	for _, p := range []string{"", "44'/0", "m/", "m/a", "m/-1", "m/2147483648", "m/1''"} {
+		_, err := ParseDerivationPath(p)
+		require.Error(t, err, p)
+	}
+}
+
+func TestNewAccountFromSeed(t *testing.T) {
+	seed, err := hex.DecodeString("fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542")
+	require.NoError(t, err)
+
+	acc1, err := NewAccountFromSeed(seed, 0)
+	require.NoError(t, err)
+	acc2, err := NewAccountFromSeed(seed, 0)
+	require.NoError(t, err)
+	require.Equal(t, acc1.PrivateKey().Bytes(), acc2.PrivateKey().Bytes())
+	require.Equal(t, acc1.Address, acc2.Address)
+	// Derived key doesn't change between runs and versions.
+	require.Equal(t, "02f34efcabd67906174f378e8f8f97dcf8ccc7356848eef8b52e95d9668d90c7", hex.EncodeToString(acc1.PrivateKey().Bytes()))
+
+	acc3, err := NewAccountFromSeed(seed, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, acc1.Address, acc3.Address)
+
+	_, err = NewAccountFromSeed(seed[:1], 0)
+	require.Error(t, err)
+}
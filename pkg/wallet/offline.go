@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// OfflineParams contains all the data needed to build and sign a transaction
+// without any network access, it's expected to be fetched in advance.
+type OfflineParams struct {
+	// Network is the magic of the network transaction is signed for.
+	Network netmode.Magic
+	// Script is the transaction script.
+	Script []byte
+	// Account is the sender account, it must be unlocked.
+	Account *Account
+	// Scopes is the sender witness scope, CalledByEntry is used if it's
+	// not set.
+	Scopes transaction.WitnessScope
+	// Attributes are transaction attributes.
+	Attributes []transaction.Attribute
+	// Nonce is the transaction nonce.
+	Nonce uint32
+	// ValidUntilBlock is the height transaction is valid until, it must be
+	// set.
+	ValidUntilBlock uint32
+	// SystemFee is the transaction system fee.
+	SystemFee int64
+	// NetworkFee is the transaction network fee if FeePerByte is not set and
+	// an extra fee added to the calculated one otherwise.
+	NetworkFee int64
+	// FeePerByte and ExecFeeFactor are the current Policy contract values,
+	// when FeePerByte is set network fee is calculated using them for the
+	// standard (signature or multisignature) account.
+	FeePerByte    int64
+	ExecFeeFactor int64
+}
+
+// BuildOffline creates a transaction from the given parameters and signs it
+// with the account, so that it's ready to be broadcasted. Multisignature
+// account witness only contains one signature, other signatures are to be
+// added by other signers.
+func BuildOffline(params OfflineParams) (*transaction.Transaction, error) {
+	acc := params.Account
+	if acc == nil {
+		return nil, errors.New("no account")
+	}
+	if len(params.Script) == 0 {
+		return nil, errors.New("no script")
+	}
+	if params.ValidUntilBlock == 0 {
+		return nil, errors.New("ValidUntilBlock is not set")
+	}
+	if params.SystemFee < 0 || params.NetworkFee < 0 {
+		return nil, errors.New("negative fee")
+	}
+	scopes := params.Scopes
+	if scopes == transaction.None {
+		scopes = transaction.CalledByEntry
+	}
+	tx := transaction.New(params.Script, params.SystemFee)
+	tx.Nonce = params.Nonce
+	tx.ValidUntilBlock = params.ValidUntilBlock
+	tx.Attributes = params.Attributes
+	tx.Signers = []transaction.Signer{{
+		Account: acc.Contract.ScriptHash(),
+		Scopes:  scopes,
+	}}
+	tx.NetworkFee = params.NetworkFee
+	if params.FeePerByte != 0 {
+		if acc.Contract.Deployed {
+			return nil, errors.New("can't calculate network fee for deployed contract")
+		}
+		netFee, sizeDelta := fee.Calculate(params.ExecFeeFactor, acc.Contract.Script)
+		if netFee == 0 {
+			return nil, errors.New("can't calculate network fee for non-standard account")
+		}
+		tx.NetworkFee += netFee + int64(io.GetVarSize(tx)+sizeDelta)*params.FeePerByte
+	}
+	if err := acc.SignTx(params.Network, tx); err != nil {
+		return nil, fmt.Errorf("can't sign transaction: %w", err)
+	}
+	return tx, nil
+}
@@ -0,0 +1,87 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildOffline(t *testing.T) {
+	acc, err := NewAccount()
+	require.NoError(t, err)
+	from := acc.Contract.ScriptHash()
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, util.Uint160{1, 2, 3}, "transfer", callflag.All, from, util.Uint160{4, 5, 6}, int64(100), nil)
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	require.NoError(t, w.Err)
+
+	params := OfflineParams{
+		Network:         netmode.UnitTestNet,
+		Script:          w.Bytes(),
+		Account:         acc,
+		Nonce:           42,
+		ValidUntilBlock: 1000,
+		SystemFee:       1_0000000,
+		NetworkFee:      100,
+		FeePerByte:      1000,
+		ExecFeeFactor:   30,
+	}
+	tx, err := BuildOffline(params)
+	require.NoError(t, err)
+	require.Equal(t, params.Script, tx.Script)
+	require.Equal(t, uint32(42), tx.Nonce)
+	require.Equal(t, uint32(1000), tx.ValidUntilBlock)
+	require.Equal(t, int64(1_0000000), tx.SystemFee)
+	require.Equal(t, []transaction.Signer{{Account: from, Scopes: transaction.CalledByEntry}}, tx.Signers)
+
+	netFee, _ := fee.Calculate(params.ExecFeeFactor, acc.Contract.Script)
+	require.Equal(t, params.NetworkFee+netFee+int64(io.GetVarSize(tx))*params.FeePerByte, tx.NetworkFee)
+
+	require.Equal(t, 1, len(tx.Scripts))
+	ok, err := smartcontract.VerifyScripts(uint32(netmode.UnitTestNet), tx.Scripts[0].VerificationScript,
+		tx.Scripts[0].InvocationScript, tx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	t.Run("serialization", func(t *testing.T) {
+		decoded, err := transaction.NewTransactionFromBytes(tx.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), decoded.Hash())
+	})
+	t.Run("explicit network fee", func(t *testing.T) {
+		p := params
+		p.FeePerByte = 0
+		p.Scopes = transaction.Global
+		tx, err := BuildOffline(p)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), tx.NetworkFee)
+		require.Equal(t, transaction.Global, tx.Signers[0].Scopes)
+	})
+	t.Run("bad params", func(t *testing.T) {
+		for name, f := range map[string]func(p *OfflineParams){
+			"no account":   func(p *OfflineParams) { p.Account = nil },
+			"no script":    func(p *OfflineParams) { p.Script = nil },
+			"no VUB":       func(p *OfflineParams) { p.ValidUntilBlock = 0 },
+			"negative fee": func(p *OfflineParams) { p.SystemFee = -1 },
+			"watch-only":   func(p *OfflineParams) { p.Account = NewWatchOnlyAccountFromPublicKey(acc.PrivateKey().PublicKey()) },
+			"locked":       func(p *OfflineParams) { p.Account = &Account{Contract: acc.Contract} },
+		} {
+			t.Run(name, func(t *testing.T) {
+				p := params
+				f(&p)
+				_, err := BuildOffline(p)
+				require.Error(t, err)
+			})
+		}
+	})
+}
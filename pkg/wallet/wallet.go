@@ -199,7 +199,7 @@ func (w *Wallet) GetChangeAddress() util.Uint160 {
 
 	for i := range w.Accounts {
 		if acc == nil || w.Accounts[i].Default {
-			if w.Accounts[i].Contract != nil && vm.IsSignatureContract(w.Accounts[i].Contract.Script) {
+			if w.Accounts[i].Contract != nil && vm.IsSignatureContract(w.Accounts[i].Contract.Script) && !w.Accounts[i].IsWatchOnly() {
 				acc = w.Accounts[i]
 				if w.Accounts[i].Default {
 					break
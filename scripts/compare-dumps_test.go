@@ -8,6 +8,6 @@ import (
 )
 
 func TestCompatibility(t *testing.T) {
-	cs := native.NewContracts(false, map[string][]uint32{})
+	cs := native.NewContracts(false, map[string][]uint32{}, 0)
 	require.Equal(t, cs.Ledger.ID, int32(ledgerContractID))
 }